@@ -48,6 +48,7 @@ type Capability struct {
 	Center         string      `json:"center,omitempty"`
 	Status         string      `json:"status,omitempty"`
 	Description    string      `json:"description,omitempty"`
+	Example        string      `json:"example,omitempty"`
 
 	//trait only
 	AppliesTo []string `json:"appliesTo,omitempty"`
@@ -6,14 +6,24 @@ const (
 	DefaultOAMRuntimeChartName = "vela-core"
 	DefaultOAMVersion          = ">0.0.0-0"
 
+	DefaultKEDANS        = "keda"
+	DefaultKEDAChartName = "keda"
+
 	DefaultEnvName      = "default"
 	DefaultAppNamespace = "default"
 )
 
 const (
 	AnnDescription = "definition.oam.dev/description"
+	AnnExample     = "definition.oam.dev/example"
+
+	// AnnPause marks the application as paused, KubeVela controllers will skip reconciling it
+	AnnPause = "app.oam.dev/pause"
 
 	LabelPodSpecable = "workload.oam.dev/podspecable"
+
+	// LabelControllerRevisionComponent is the label of the component on its ControllerRevisions
+	LabelControllerRevisionComponent = "controller.oam.dev/component"
 )
 
 const (
@@ -26,6 +36,11 @@ type EnvMeta struct {
 	Namespace string `json:"namespace"`
 	Email     string `json:"email,omitempty"`
 	Domain    string `json:"domain,omitempty"`
+	// Context is the kubeconfig context of the cluster the env is bound to, use the current context if empty
+	Context string `json:"context,omitempty"`
+	// Settings are the default settings of services deployed in the env, keyed by the parameter names like image,
+	// the settings specified when deploying the service take precedence
+	Settings map[string]string `json:"settings,omitempty"`
 
 	// Below are not arguments, should be auto-generated
 	Issuer  string `json:"issuer"`
@@ -32,6 +32,13 @@ type TriggerType string
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:categories={oam}
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="PHASE",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="TARGET-KIND",type="string",JSONPath=".status.targetWorkload.kind"
+// +kubebuilder:printcolumn:name="TARGET",type="string",JSONPath=".status.targetWorkload.name"
+// +kubebuilder:printcolumn:name="MIN",type="integer",JSONPath=".spec.minReplicas"
+// +kubebuilder:printcolumn:name="MAX",type="integer",JSONPath=".spec.maxReplicas"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // Autoscaler is the Schema for the autoscalers API
 type Autoscaler struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -62,11 +69,49 @@ type Trigger struct {
 	// Name is the trigger name, if not set, it will be automatically generated and make it globally unique
 	Name string `json:"name,omitempty"`
 
-	// Type allows value in [cpu/memory/storage/ephemeral-storage、cron、pps、qps/rps、custom]
+	// Type allows value in [cpu, memory, storage, ephemeral-storage, cron, prometheus, kafka, rabbitmq, external],
+	// other types are rejected, scalers of KEDA not listed could be used by the external type
 	Type TriggerType `json:"type"`
 
 	// Condition set the condition when to trigger scaling
 	Condition map[string]string `json:"condition"`
+
+	// Windows lists extra time windows of a cron trigger, which share the days and timezone in the condition
+	// +optional
+	Windows []CronWindow `json:"windows,omitempty"`
+
+	// AuthRef provides the credentials of the trigger, such as the SASL password of kafka, by the keys of a Secret
+	// +optional
+	AuthRef *TriggerAuthRef `json:"authRef,omitempty"`
+}
+
+// TriggerAuthRef references an existing KEDA TriggerAuthentication, or the keys of a Secret which a
+// TriggerAuthentication is created for
+type TriggerAuthRef struct {
+	// Name is the name of an existing TriggerAuthentication in the namespace of the autoscaler,
+	// it's ignored if SecretName is set
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// SecretName is the name of the Secret in the namespace of the autoscaler
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// SecretKeys maps the parameters of the KEDA scaler to the keys of the Secret, for example, `password: kafka-password`
+	// +optional
+	SecretKeys map[string]string `json:"secretKeys,omitempty"`
+}
+
+// CronWindow is a time window of a cron trigger, in which the workload is scaled to the replicas
+type CronWindow struct {
+	// StartAt is the time when the window starts, in format `"HH:MM"`, for example, "08:00"
+	StartAt string `json:"startAt"`
+
+	// Duration means how long the window lasts, for example, "2h"
+	Duration string `json:"duration"`
+
+	// Replicas is the expected replicas in the window
+	Replicas int32 `json:"replicas"`
 }
 
 // AutoscalerSpec defines the desired state of Autoscaler
@@ -79,6 +124,24 @@ type AutoscalerSpec struct {
 	// +optional
 	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
 
+	// InitialReplicas is the replicas set to the target workload once when the autoscaler is first created
+	// +optional
+	InitialReplicas *int32 `json:"initialReplicas,omitempty"`
+
+	// IdleReplicas is the replicas when no trigger is active, which must be less than MinReplicas. It's validated but
+	// not passed through to the KEDA ScaledObject yet, as the keda-api in use doesn't support idleReplicaCount
+	// +optional
+	IdleReplicas *int32 `json:"idleReplicas,omitempty"`
+
+	// CooldownPeriod is the seconds to wait after the last trigger reported active before scaling the workload to zero,
+	// default to the KEDA default 300 seconds
+	// +optional
+	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
+
+	// PollingInterval is the interval in seconds to check each trigger on, default to the KEDA default 30 seconds
+	// +optional
+	PollingInterval *int32 `json:"pollingInterval,omitempty"`
+
 	// Triggers lists all triggers
 	Triggers []Trigger `json:"triggers"`
 
@@ -86,8 +149,26 @@ type AutoscalerSpec struct {
 	// it could be WorkloadReference or the child resource of it
 	TargetWorkload TargetWorkload `json:"targetWorkload,omitempty"`
 
+	// TargetSelector selects the Deployment or StatefulSet to scale by labels among the workload and its child
+	// resources, exactly one of them has to match. It's for workloads with generated names, and takes precedence
+	// over choosing the target by kinds
+	// +optional
+	TargetSelector *metav1.LabelSelector `json:"targetSelector,omitempty"`
+
 	// WorkloadReference marks the owner of the workload
 	WorkloadReference runtimev1alpha1.TypedReference `json:"workloadRef,omitempty"`
+
+	// Advanced holds advanced configurations passed through to the KEDA ScaledObject
+	// +optional
+	Advanced *AdvancedConfig `json:"advanced,omitempty"`
+}
+
+// AdvancedConfig holds advanced configurations of the autoscaler
+type AdvancedConfig struct {
+	// RestoreToOriginalReplicaCount specifies whether the target workload is scaled back to its original replicas
+	// when the autoscaler is deleted, default to false which keeps the current replicas
+	// +optional
+	RestoreToOriginalReplicaCount bool `json:"restoreToOriginalReplicaCount,omitempty"`
 }
 
 // TargetWorkload holds the a reference to the scale target Object
@@ -99,9 +180,44 @@ type TargetWorkload struct {
 	Kind string `json:"kind,omitempty"`
 }
 
+// AutoscalerPhase is a brief summary of the autoscaler computed from its conditions
+// +kubebuilder:validation:Enum=Pending;Ready;Degraded;Error
+type AutoscalerPhase string
+
+const (
+	// AutoscalerPhasePending means the autoscaler hasn't been reconciled successfully yet
+	AutoscalerPhasePending AutoscalerPhase = "Pending"
+	// AutoscalerPhaseReady means the autoscaler is reconciled and scaling the target workload
+	AutoscalerPhaseReady AutoscalerPhase = "Ready"
+	// AutoscalerPhaseDegraded means the autoscaler is reconciled but not available
+	AutoscalerPhaseDegraded AutoscalerPhase = "Degraded"
+	// AutoscalerPhaseError means the autoscaler failed to reconcile
+	AutoscalerPhaseError AutoscalerPhase = "Error"
+)
+
 // AutoscalerStatus defines the observed state of Autoscaler
 type AutoscalerStatus struct {
 	runtimev1alpha1.ConditionedStatus `json:",inline"`
+
+	// Phase is a brief summary of the autoscaler computed from the conditions
+	// +optional
+	Phase AutoscalerPhase `json:"phase,omitempty"`
+
+	// InitialScaleApplied marks whether InitialReplicas has been set to the target workload
+	// +optional
+	InitialScaleApplied bool `json:"initialScaleApplied,omitempty"`
+
+	// ScaledObjectName is the name of the KEDA ScaledObject bound to the autoscaler
+	// +optional
+	ScaledObjectName string `json:"scaledObjectName,omitempty"`
+
+	// ScaledObjectNamespace is the namespace of the KEDA ScaledObject bound to the autoscaler
+	// +optional
+	ScaledObjectNamespace string `json:"scaledObjectNamespace,omitempty"`
+
+	// TargetWorkload is the workload resolved to be scaled by the autoscaler
+	// +optional
+	TargetWorkload *TargetWorkload `json:"targetWorkload,omitempty"`
 }
 
 // +kubebuilder:object:root=true
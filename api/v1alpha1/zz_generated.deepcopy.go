@@ -22,9 +22,25 @@ package v1alpha1
 
 import (
 	corev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdvancedConfig) DeepCopyInto(out *AdvancedConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdvancedConfig.
+func (in *AdvancedConfig) DeepCopy() *AdvancedConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AdvancedConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Autoscaler) DeepCopyInto(out *Autoscaler) {
 	*out = *in
@@ -97,6 +113,26 @@ func (in *AutoscalerSpec) DeepCopyInto(out *AutoscalerSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.InitialReplicas != nil {
+		in, out := &in.InitialReplicas, &out.InitialReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IdleReplicas != nil {
+		in, out := &in.IdleReplicas, &out.IdleReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CooldownPeriod != nil {
+		in, out := &in.CooldownPeriod, &out.CooldownPeriod
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PollingInterval != nil {
+		in, out := &in.PollingInterval, &out.PollingInterval
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Triggers != nil {
 		in, out := &in.Triggers, &out.Triggers
 		*out = make([]Trigger, len(*in))
@@ -105,7 +141,17 @@ func (in *AutoscalerSpec) DeepCopyInto(out *AutoscalerSpec) {
 		}
 	}
 	out.TargetWorkload = in.TargetWorkload
+	if in.TargetSelector != nil {
+		in, out := &in.TargetSelector, &out.TargetSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	out.WorkloadReference = in.WorkloadReference
+	if in.Advanced != nil {
+		in, out := &in.Advanced, &out.Advanced
+		*out = new(AdvancedConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalerSpec.
@@ -122,6 +168,11 @@ func (in *AutoscalerSpec) DeepCopy() *AutoscalerSpec {
 func (in *AutoscalerStatus) DeepCopyInto(out *AutoscalerStatus) {
 	*out = *in
 	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.TargetWorkload != nil {
+		in, out := &in.TargetWorkload, &out.TargetWorkload
+		*out = new(TargetWorkload)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalerStatus.
@@ -170,6 +221,21 @@ func (in *BackendServiceRef) DeepCopy() *BackendServiceRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CronWindow) DeepCopyInto(out *CronWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronWindow.
+func (in *CronWindow) DeepCopy() *CronWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(CronWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricsTrait) DeepCopyInto(out *MetricsTrait) {
 	*out = *in
@@ -584,6 +650,16 @@ func (in *Trigger) DeepCopyInto(out *Trigger) {
 			(*out)[key] = val
 		}
 	}
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]CronWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.AuthRef != nil {
+		in, out := &in.AuthRef, &out.AuthRef
+		*out = new(TriggerAuthRef)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Trigger.
@@ -595,3 +671,25 @@ func (in *Trigger) DeepCopy() *Trigger {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerAuthRef) DeepCopyInto(out *TriggerAuthRef) {
+	*out = *in
+	if in.SecretKeys != nil {
+		in, out := &in.SecretKeys, &out.SecretKeys
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthRef.
+func (in *TriggerAuthRef) DeepCopy() *TriggerAuthRef {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerAuthRef)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -9,6 +9,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -31,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -38,6 +40,7 @@ import (
 	velacoreoamdev "github.com/oam-dev/kubevela/api/core.oam.dev/v1alpha2"
 	velacore "github.com/oam-dev/kubevela/api/v1alpha1"
 	velacontroller "github.com/oam-dev/kubevela/pkg/controller"
+	velacommon "github.com/oam-dev/kubevela/pkg/controller/common"
 	"github.com/oam-dev/kubevela/pkg/controller/dependency"
 	velawebhook "github.com/oam-dev/kubevela/pkg/webhook"
 )
@@ -75,6 +78,9 @@ func main() {
 	var useWebhook, useTraitInjector bool
 	var controllerArgs oamcontroller.Args
 	var healthAddr string
+	var autoscalerTargetKinds, autoscalerOwnerRefKinds, autoscalerPropagatedKeys, autoscalerExcludedKeys string
+	var manageOwnerRefs, discoverScalable, requireKEDA bool
+	var watchNamespaces string
 
 	flag.BoolVar(&useWebhook, "use-webhook", false, "Enable Admission Webhook")
 	flag.BoolVar(&useTraitInjector, "use-trait-injector", false, "Enable TraitInjector")
@@ -89,6 +95,23 @@ func main() {
 	flag.IntVar(&controllerArgs.RevisionLimit, "revision-limit", 50,
 		"RevisionLimit is the maximum number of revisions that will be maintained. The default value is 50.")
 	flag.StringVar(&healthAddr, "health-addr", ":9440", "The address the health endpoint binds to.")
+	flag.StringVar(&autoscalerTargetKinds, "autoscaler-target-kinds", strings.Join(velacommon.DefaultAutoscalerTargetKinds, ","),
+		"Comma separated workload kinds which autoscaler could choose as scale target, the former kind has higher priority.")
+	flag.StringVar(&autoscalerOwnerRefKinds, "autoscaler-owner-reference-kinds", strings.Join(velacommon.DefaultAutoscalerOwnerRefKinds, ","),
+		"Comma separated child resource kinds which autoscaler will set owner reference to, other kinds are not touched.")
+	flag.BoolVar(&manageOwnerRefs, "manage-owner-references", true,
+		"Enable autoscaler to set itself as an owner of the child resources of --autoscaler-owner-reference-kinds, "+
+			"disable it if owner references are managed externally.")
+	flag.BoolVar(&discoverScalable, "autoscaler-discover-scalable", true,
+		"Enable autoscaler to discover the scale subresource of workloads, so that any workload exposing it could be scaled.")
+	flag.BoolVar(&requireKEDA, "autoscaler-require-keda", false,
+		"Fail to start if the KEDA ScaledObject CRD is missing, otherwise autoscaler falls back to HPA for cpu, memory and storage triggers.")
+	flag.StringVar(&autoscalerPropagatedKeys, "autoscaler-propagate-keys", strings.Join(velacommon.DefaultAutoscalerPropagatedKeys, ","),
+		"Comma separated label and annotation keys which autoscaler copies onto the ScaledObject, a key ending with * matches the prefix, empty to copy nothing.")
+	flag.StringVar(&autoscalerExcludedKeys, "autoscaler-exclude-keys", strings.Join(velacommon.DefaultAutoscalerExcludedKeys, ","),
+		"Comma separated label and annotation keys which autoscaler never copies onto the ScaledObject, a key ending with * matches the prefix.")
+	flag.StringVar(&watchNamespaces, "watch-namespaces", "",
+		"Comma separated namespaces the controllers watch and reconcile, default to watch all namespaces.")
 	flag.Parse()
 
 	// setup logging
@@ -108,15 +131,19 @@ func main() {
 		o.DestWritter = w
 	}))
 
+	// the config is resolved once for all controllers, from the --kubeconfig flag, the KUBECONFIG env var, the in-cluster
+	// config, then ~/.kube/config, so that the controllers work both in and out of the cluster
+	restConfig := ctrl.GetConfigOrDie()
+
 	// install dependency charts first
-	k8sClient, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme})
 	if err != nil {
 		setupLog.Error(err, "unable to create a kubernetes client")
 		os.Exit(1)
 	}
 	go dependency.Install(k8sClient)
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	mgrOptions := ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     metricsAddr,
 		LeaderElection:         enableLeaderElection,
@@ -124,7 +151,12 @@ func main() {
 		Port:                   webhookPort,
 		CertDir:                certDir,
 		HealthProbeBindAddress: healthAddr,
-	})
+	}
+	if namespaces := splitList(watchNamespaces); len(namespaces) > 0 {
+		setupLog.Info("vela controllers will only watch namespaces " + strings.Join(namespaces, ","))
+		restrictNamespaces(&mgrOptions, namespaces)
+	}
+	mgr, err := ctrl.NewManager(restConfig, mgrOptions)
 	if err != nil {
 		setupLog.Error(err, "unable to create a controller manager")
 		os.Exit(1)
@@ -153,7 +185,16 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = velacontroller.Setup(mgr); err != nil {
+	velaArgs := velacommon.Args{
+		AutoscalerTargetKinds:      splitList(autoscalerTargetKinds),
+		AutoscalerOwnerRefKinds:    splitList(autoscalerOwnerRefKinds),
+		AutoscalerManageOwnerRefs:  manageOwnerRefs,
+		AutoscalerDiscoverScalable: discoverScalable,
+		AutoscalerRequireKEDA:      requireKEDA,
+		AutoscalerPropagatedKeys:   splitList(autoscalerPropagatedKeys),
+		AutoscalerExcludedKeys:     splitList(autoscalerExcludedKeys),
+	}
+	if err = velacontroller.Setup(mgr, velaArgs); err != nil {
 		setupLog.Error(err, "unable to setup the vela core controller")
 		os.Exit(1)
 	}
@@ -197,6 +238,28 @@ func registerHealthChecks(mgr ctrl.Manager) error {
 	return nil
 }
 
+// splitList splits the comma separated flag value, the empty elements are dropped, so that an empty value gives an empty
+// list, and the kinds fall back to the defaults
+func splitList(value string) []string {
+	var list []string
+	for _, e := range strings.Split(value, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			list = append(list, e)
+		}
+	}
+	return list
+}
+
+// restrictNamespaces restricts the cache and reconcile scope of the manager to the namespaces,
+// the manager watches all namespaces if none is given
+func restrictNamespaces(options *ctrl.Options, namespaces []string) {
+	if len(namespaces) == 1 {
+		options.Namespace = namespaces[0]
+	} else if len(namespaces) > 1 {
+		options.NewCache = cache.MultiNamespacedCacheBuilder(namespaces)
+	}
+}
+
 // waitWebhookSecretVolume waits for webhook secret ready to avoid mgr running crash
 func waitWebhookSecretVolume(certDir string, timeout, interval time.Duration) error {
 	start := time.Now()
@@ -8,6 +8,7 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 var (
@@ -64,3 +65,38 @@ var _ = Describe("test waitSecretVolume", func() {
 	})
 
 })
+
+var _ = Describe("test splitList", func() {
+	It("split comma separated values", func() {
+		Expect(splitList("Deployment,StatefulSet")).To(Equal([]string{"Deployment", "StatefulSet"}))
+		Expect(splitList(" Deployment, ,StatefulSet,")).To(Equal([]string{"Deployment", "StatefulSet"}))
+	})
+
+	It("return empty list for empty value", func() {
+		Expect(splitList("")).To(BeEmpty())
+		Expect(splitList(",")).To(BeEmpty())
+	})
+})
+
+var _ = Describe("test restrictNamespaces", func() {
+	It("watch all namespaces by default", func() {
+		options := ctrl.Options{}
+		restrictNamespaces(&options, nil)
+		Expect(options.Namespace).To(BeEmpty())
+		Expect(options.NewCache).To(BeNil())
+	})
+
+	It("watch the single namespace", func() {
+		options := ctrl.Options{}
+		restrictNamespaces(&options, []string{"team-a"})
+		Expect(options.Namespace).To(Equal("team-a"))
+		Expect(options.NewCache).To(BeNil())
+	})
+
+	It("watch multiple namespaces by the multi-namespaced cache", func() {
+		options := ctrl.Options{}
+		restrictNamespaces(&options, splitList("team-a, team-b"))
+		Expect(options.Namespace).To(BeEmpty())
+		Expect(options.NewCache).NotTo(BeNil())
+	})
+})
@@ -1,11 +1,13 @@
 package main
 
 import (
+	"errors"
 	"math/rand"
 	"os"
 	"time"
 
 	"github.com/oam-dev/kubevela/pkg/commands"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
 )
 
 func main() {
@@ -14,6 +16,10 @@ func main() {
 	command := commands.NewCommand()
 
 	if err := command.Execute(); err != nil {
-		os.Exit(1)
+		var exitErr *cmdutil.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
+		os.Exit(cmdutil.DefaultErrorExitCode)
 	}
 }
@@ -0,0 +1,35 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/oam-dev/kubevela/pkg/server/apis"
+	"github.com/oam-dev/kubevela/pkg/server/util"
+)
+
+// PostJSON posts body marshalled as JSON to the API path of the dashboard, such as "/envs/",
+// and unmarshal the response, the raw response body is returned in the error if it's not a valid apis.Response
+func PostJSON(path string, body interface{}) (apis.Response, error) {
+	var r apis.Response
+	data, err := json.Marshal(body)
+	if err != nil {
+		return r, err
+	}
+	resp, err := http.Post(util.URL(path), "application/json", bytes.NewReader(data))
+	if err != nil {
+		return r, err
+	}
+	defer resp.Body.Close()
+	result, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return r, err
+	}
+	if err := json.Unmarshal(result, &r); err != nil {
+		return r, fmt.Errorf("unmarshal response of POST %s err %v: %s", path, err, string(result))
+	}
+	return r, nil
+}
@@ -71,7 +71,23 @@ var _ = ginkgo.Describe("API", func() {
 			err = json.Unmarshal(result, &r)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(http.StatusOK).To(gomega.Equal(r.Code))
-			//TODO(zzxwill) Need to compare r.Data with envMeta
+			environment := r.Data.(map[string]interface{})
+			gomega.Expect(environment["envName"]).To(gomega.Equal(envHelloMeta.EnvName))
+			gomega.Expect(environment["namespace"]).To(gomega.Equal(envHelloMeta.Namespace))
+		})
+
+		ginkgo.It("should report not found for not existed env", func() {
+			resp, err := http.Get(util.URL("/envs/" + notExistedEnvMeta.EnvName))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+			gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusNotFound))
+			result, err := ioutil.ReadAll(resp.Body)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			var r apis.Response
+			err = json.Unmarshal(result, &r)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(http.StatusNotFound).To(gomega.Equal(r.Code))
+			gomega.Expect(r.Error.Code).To(gomega.Equal("EnvNotFound"))
 		})
 	})
 
@@ -95,6 +111,40 @@ var _ = ginkgo.Describe("API", func() {
 		})
 	})
 
+	ginkgo.Context("put /envs/:envName/current", func() {
+		ginkgo.It("should switch to the env and return it", func() {
+			req, err := http.NewRequest("PUT", util.URL("/envs/"+envHelloMeta.EnvName+"/current"), nil)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			resp, err := http.DefaultClient.Do(req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+			result, err := ioutil.ReadAll(resp.Body)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			var r apis.Response
+			err = json.Unmarshal(result, &r)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(http.StatusOK).To(gomega.Equal(r.Code))
+			environment := r.Data.(map[string]interface{})
+			gomega.Expect(environment["envName"]).To(gomega.Equal(envHelloMeta.EnvName))
+			gomega.Expect(environment["current"]).To(gomega.Equal("*"))
+		})
+
+		ginkgo.It("should report not found for not existed env", func() {
+			req, err := http.NewRequest("PUT", util.URL("/envs/"+notExistedEnvMeta.EnvName+"/current"), nil)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			resp, err := http.DefaultClient.Do(req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+			result, err := ioutil.ReadAll(resp.Body)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			var r apis.Response
+			err = json.Unmarshal(result, &r)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(http.StatusNotFound).To(gomega.Equal(r.Code))
+			gomega.Expect(r.Error.Code).To(gomega.Equal("EnvNotFound"))
+		})
+	})
+
 	ginkgo.Context("get /envs/", func() {
 		ginkgo.It("should get an env", func() {
 			resp, err := http.Get(util.URL("/envs/"))
@@ -110,6 +160,25 @@ var _ = ginkgo.Describe("API", func() {
 		})
 	})
 
+	ginkgo.Context("get /envs/?offset=&limit=", func() {
+		ginkgo.It("should get a page of envs", func() {
+			resp, err := http.Get(util.URL("/envs/?offset=1&limit=1"))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+			result, err := ioutil.ReadAll(resp.Body)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			var r apis.Response
+			err = json.Unmarshal(result, &r)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(http.StatusOK).To(gomega.Equal(r.Code))
+			gomega.Expect(r.Data.([]interface{})).To(gomega.HaveLen(1))
+			gomega.Expect(r.Meta).NotTo(gomega.BeNil())
+			gomega.Expect(r.Meta.Total).To(gomega.BeNumerically(">=", 2))
+			gomega.Expect(r.Meta.Offset).To(gomega.Equal(1))
+			gomega.Expect(r.Meta.Limit).To(gomega.Equal(1))
+		})
+	})
+
 	ginkgo.Context("put /envs/:envName", func() {
 		ginkgo.It("should update an env", func() {
 			data, _ := json.Marshal(&envWorldMetaUpdate)
@@ -128,9 +197,11 @@ var _ = ginkgo.Describe("API", func() {
 		})
 	})
 
+	e2e.APIEnvDeleteContext("delete /envs/:envName", envWorldMeta.EnvName)
+
 	ginkgo.Context("delete /envs/:envName", func() {
-		ginkgo.It("should delete an env", func() {
-			req, err := http.NewRequest("DELETE", util.URL("/envs/"+envWorldMeta.EnvName), nil)
+		ginkgo.It("should refuse to delete the current env", func() {
+			req, err := http.NewRequest("DELETE", util.URL("/envs/"+envHelloMeta.EnvName), nil)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			resp, err := http.DefaultClient.Do(req)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
@@ -140,8 +211,40 @@ var _ = ginkgo.Describe("API", func() {
 			var r apis.Response
 			err = json.Unmarshal(result, &r)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
-			gomega.Expect(http.StatusOK).To(gomega.Equal(r.Code))
-			gomega.Expect(r.Data.(string)).To(gomega.ContainSubstring(envWorldMeta.EnvName + " deleted"))
+			gomega.Expect(http.StatusConflict).To(gomega.Equal(r.Code))
+			gomega.Expect(r.Error.Code).To(gomega.Equal("EnvInUse"))
+		})
+	})
+
+	ginkgo.Context("probes", func() {
+		ginkgo.It("should be healthy and ready", func() {
+			for _, path := range []string{util.HealthzPath, util.ReadyzPath} {
+				resp, err := http.Get(fmt.Sprintf("http://127.0.0.1%s%s", util.DefaultDashboardPort, path))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				result, err := ioutil.ReadAll(resp.Body)
+				resp.Body.Close()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusOK), string(result))
+			}
+		})
+	})
+
+	ginkgo.Context("request ID", func() {
+		ginkgo.It("should respond the request ID", func() {
+			resp, err := http.Get(util.URL("/envs/"))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			resp.Body.Close()
+			gomega.Expect(resp.Header.Get(string(util.HeaderRequestID))).NotTo(gomega.BeEmpty())
+		})
+
+		ginkgo.It("should reuse the request ID of the request", func() {
+			req, err := http.NewRequest("GET", util.URL("/envs/"), nil)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			req.Header.Set(string(util.HeaderRequestID), "e2e-request-id")
+			resp, err := http.DefaultClient.Do(req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			resp.Body.Close()
+			gomega.Expect(resp.Header.Get(string(util.HeaderRequestID))).To(gomega.Equal("e2e-request-id"))
 		})
 	})
 
@@ -161,40 +264,29 @@ var _ = ginkgo.Describe("API", func() {
 			gomega.Expect(http.StatusInternalServerError).To(gomega.Equal(r.Code))
 			expectedContent := fmt.Sprintf("env %s not exist", envName)
 			gomega.Expect(r.Data.(string)).To(gomega.ContainSubstring(expectedContent))
+			gomega.Expect(r.Error).NotTo(gomega.BeNil())
+			gomega.Expect(r.Error.Code).To(gomega.Equal("StatusInternalServerError"))
+			gomega.Expect(r.Error.Message).To(gomega.ContainSubstring(expectedContent))
 		})
 	})
 
 	ginkgo.Context("Workloads", func() {
 		ginkgo.It("run workload", func() {
-			data, err := json.Marshal(&workloadRunBody)
+			r, err := e2e.PostJSON("/workloads/", &workloadRunBody)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
-			resp, err := http.Post(util.URL("/workloads/"), "application/json", strings.NewReader(string(data)))
-			gomega.Expect(err).NotTo(gomega.HaveOccurred())
-			defer resp.Body.Close()
-			result, err := ioutil.ReadAll(resp.Body)
-			gomega.Expect(err).NotTo(gomega.HaveOccurred())
-			var r apis.Response
-			err = json.Unmarshal(result, &r)
-			gomega.Expect(err).NotTo(gomega.HaveOccurred())
-			gomega.Expect(http.StatusOK).Should(gomega.Equal(r.Code), string(result))
+			gomega.Expect(http.StatusOK).Should(gomega.Equal(r.Code), r.Data)
 			output := fmt.Sprintf("App %s deployed", workloadName)
 			gomega.Expect(r.Data.(string)).To(gomega.ContainSubstring(output))
 		})
 
 		ginkgo.It("run workload without compulsory flag", func() {
-			data, err := json.Marshal(&workloadRunBodyWithoutImageFlag)
-			gomega.Expect(err).NotTo(gomega.HaveOccurred())
-			resp, err := http.Post(util.URL("/workloads/"), "application/json", strings.NewReader(string(data)))
-			gomega.Expect(err).NotTo(gomega.HaveOccurred())
-			defer resp.Body.Close()
-			result, err := ioutil.ReadAll(resp.Body)
-			gomega.Expect(err).NotTo(gomega.HaveOccurred())
-			var r apis.Response
-			err = json.Unmarshal(result, &r)
+			r, err := e2e.PostJSON("/workloads/", &workloadRunBodyWithoutImageFlag)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(http.StatusInternalServerError).Should(gomega.Equal(r.Code))
 			output := "required flag(s) \"image\" not set"
 			gomega.Expect(r.Data.(string)).To(gomega.ContainSubstring(output))
+			gomega.Expect(r.Error).NotTo(gomega.BeNil())
+			gomega.Expect(r.Error.Message).To(gomega.ContainSubstring(output))
 		})
 
 		ginkgo.It("should list all WorkloadDefinitions", func() {
@@ -214,6 +306,79 @@ var _ = ginkgo.Describe("API", func() {
 			}
 		})
 
+		ginkgo.It("should list applications with their services", func() {
+			resp, err := http.Get(util.URL("/apps/?env=" + envHelloMeta.EnvName))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+			result, err := ioutil.ReadAll(resp.Body)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			var r struct {
+				Code int                `json:"code"`
+				Data []apis.AppListItem `json:"data"`
+			}
+			err = json.Unmarshal(result, &r)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(r.Code).To(gomega.Equal(http.StatusOK), string(result))
+			var found bool
+			for _, app := range r.Data {
+				if app.Name != workloadName {
+					continue
+				}
+				found = true
+				gomega.Expect(app.Services).To(gomega.HaveLen(1))
+				gomega.Expect(app.Services[0].Name).To(gomega.Equal(workloadName))
+				gomega.Expect(app.Services[0].Type).To(gomega.Equal(workloadType))
+			}
+			gomega.Expect(found).To(gomega.BeTrue(), string(result))
+		})
+
+		ginkgo.It("should report not found listing applications of not existed env", func() {
+			resp, err := http.Get(util.URL("/apps/?env=" + notExistedEnvMeta.EnvName))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+			result, err := ioutil.ReadAll(resp.Body)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			var r apis.Response
+			err = json.Unmarshal(result, &r)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(r.Code).To(gomega.Equal(http.StatusNotFound))
+			gomega.Expect(r.Error).NotTo(gomega.BeNil())
+			gomega.Expect(r.Error.Code).To(gomega.Equal("EnvNotFound"))
+		})
+
+		ginkgo.It("should get the status of an application", func() {
+			resp, err := http.Get(util.URL("/apps/" + workloadName + "/status?env=" + envHelloMeta.EnvName))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+			result, err := ioutil.ReadAll(resp.Body)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			var r struct {
+				Code int            `json:"code"`
+				Data apis.AppStatus `json:"data"`
+			}
+			err = json.Unmarshal(result, &r)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(r.Code).To(gomega.Equal(http.StatusOK), string(result))
+			gomega.Expect(r.Data.Name).To(gomega.Equal(workloadName))
+			gomega.Expect(r.Data.Health).To(gomega.Or(gomega.Equal("Healthy"), gomega.Equal("Unhealthy")))
+			gomega.Expect(r.Data.Services).To(gomega.HaveLen(1))
+			gomega.Expect(r.Data.Services[0].Service).To(gomega.Equal(workloadName))
+		})
+
+		ginkgo.It("should report not found getting the status of not existed application", func() {
+			resp, err := http.Get(util.URL("/apps/app-e2e-api-NOT-EXISTED/status?env=" + envHelloMeta.EnvName))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+			result, err := ioutil.ReadAll(resp.Body)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			var r apis.Response
+			err = json.Unmarshal(result, &r)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(r.Code).To(gomega.Equal(http.StatusNotFound))
+			gomega.Expect(r.Error).NotTo(gomega.BeNil())
+			gomega.Expect(r.Error.Code).To(gomega.Equal("AppNotFound"))
+		})
+
 		ginkgo.It("should delete an application", func() {
 			req, err := http.NewRequest("DELETE", util.URL("/envs/"+envHelloMeta.EnvName+"/apps/"+workloadRunBody.WorkloadName), nil)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
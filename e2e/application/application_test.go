@@ -27,6 +27,7 @@ var _ = ginkgo.Describe("Application", func() {
 	e2e.ComponentListContext("ls", applicationName, workloadType, traitAlias)
 	e2e.ApplicationShowContext("show", applicationName, workloadType)
 	e2e.ApplicationStatusContext("status", applicationName, workloadType)
+	e2e.ComponentStatusContext("svc status", applicationName, workloadType)
 	e2e.ApplicationStatusDeeplyContext("status", applicationName, workloadType, envName)
 	e2e.ApplicationExecContext("exec -- COMMAND", applicationName)
 	e2e.ApplicationPortForwardContext("port-forward", applicationName)
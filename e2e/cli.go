@@ -1,6 +1,7 @@
 package e2e
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
 	"path"
@@ -58,6 +59,43 @@ func LongTimeExec(cli string, timeout time.Duration) (string, error) {
 	return string(s.Out.Contents()) + string(s.Err.Contents()), nil
 }
 
+// ExecWithTimeout executes the command and returns an error if it doesn't exit before timeout,
+// the command will be killed after timeout so that a hung command won't block the whole suite
+func ExecWithTimeout(cli string, timeout time.Duration) (string, error) {
+	session, err := AsyncExec(cli)
+	if err != nil {
+		return "", err
+	}
+	select {
+	case <-session.Exited:
+	case <-time.After(timeout):
+		session.Kill()
+		return string(session.Out.Contents()) + string(session.Err.Contents()),
+			fmt.Errorf("command %q timed out after %s", cli, timeout)
+	}
+	return string(session.Out.Contents()) + string(session.Err.Contents()), nil
+}
+
+// retryInterval is the interval between executions of ExecAndRetry
+const retryInterval = 3 * time.Second
+
+// ExecAndRetry executes the command repeatedly until its output contains substr, it returns an error along with the
+// last output if substr doesn't appear before timeout, which suits asserting on asynchronous operations
+func ExecAndRetry(cli string, substr string, timeout time.Duration) (string, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		remaining := time.Until(deadline)
+		output, err := ExecWithTimeout(cli, remaining)
+		if err == nil && strings.Contains(output, substr) {
+			return output, nil
+		}
+		if time.Until(deadline) <= retryInterval {
+			return output, fmt.Errorf("output of command %q doesn't contain %q after %s", cli, substr, timeout)
+		}
+		time.Sleep(retryInterval)
+	}
+}
+
 func AsyncExec(cli string) (*gexec.Session, error) {
 	c := strings.Fields(cli)
 	commandName := path.Join(rudrPath, c[0])
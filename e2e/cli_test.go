@@ -0,0 +1,44 @@
+package e2e
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+// useSystemBinaries runs commands from /bin instead of the built vela binaries, it returns the func to restore
+func useSystemBinaries() func() {
+	origin := rudrPath
+	rudrPath = "/bin"
+	return func() { rudrPath = origin }
+}
+
+func TestExecWithTimeout(t *testing.T) {
+	g := NewGomegaWithT(t)
+	defer useSystemBinaries()()
+
+	output, err := ExecWithTimeout("echo hello", 10*time.Second)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(output).To(Equal("hello\n"))
+
+	start := time.Now()
+	_, err = ExecWithTimeout("sleep 30", 100*time.Millisecond)
+	g.Expect(err).To(MatchError(`command "sleep 30" timed out after 100ms`))
+	// the hung command is killed instead of waited
+	g.Expect(time.Since(start)).To(BeNumerically("<", 10*time.Second))
+}
+
+func TestExecAndRetry(t *testing.T) {
+	g := NewGomegaWithT(t)
+	defer useSystemBinaries()()
+
+	output, err := ExecAndRetry("echo ready", "ready", 10*time.Second)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(output).To(ContainSubstring("ready"))
+
+	// no retry is left within the timeout, so the last output is returned along with the error
+	output, err = ExecAndRetry("echo pending", "ready", time.Second)
+	g.Expect(err).To(MatchError(`output of command "echo pending" doesn't contain "ready" after 1s`))
+	g.Expect(output).To(Equal("pending\n"))
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/Netflix/go-expect"
@@ -24,7 +23,7 @@ var (
 	SystemInitContext = func(context string) bool {
 		return ginkgo.Context(context, func() {
 			ginkgo.It("Install OAM runtime and vela builtin capabilities.", func() {
-				output, err := LongTimeExec("vela install --wait", 180*time.Second)
+				output, err := ExecWithTimeout("vela install --wait", 180*time.Second)
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 				gomega.Expect(output).To(gomega.ContainSubstring("- Installing OAM Kubernetes Runtime"))
 				gomega.Expect(output).To(gomega.ContainSubstring("- Installing builtin capabilities"))
@@ -47,6 +46,19 @@ var (
 		})
 	}
 
+	// EnvRenameContext used for test env rename
+	EnvRenameContext = func(context string, oldName, newName string) bool {
+		return ginkgo.Context(context, func() {
+			ginkgo.It("should print environment renamed message", func() {
+				cli := fmt.Sprintf("vela env rename %s %s", oldName, newName)
+				output, err := Exec(cli)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				expectedOutput := fmt.Sprintf("environment %s renamed to %s", oldName, newName)
+				gomega.Expect(output).To(gomega.ContainSubstring(expectedOutput))
+			})
+		})
+	}
+
 	DeleteEnvFunc = func(context string, envName string) bool {
 		return ginkgo.Context(context, func() {
 			ginkgo.It("should print env does not exist message", func() {
@@ -64,6 +76,7 @@ var (
 				output, err := Exec(cli)
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 				gomega.Expect(output).To(gomega.ContainSubstring("NAME"))
+				gomega.Expect(output).To(gomega.ContainSubstring("CURRENT"))
 				gomega.Expect(output).To(gomega.ContainSubstring("NAMESPACE"))
 				gomega.Expect(output).To(gomega.ContainSubstring(envName))
 			})
@@ -109,9 +122,8 @@ var (
 	WorkloadRunContext = func(context string, cli string) bool {
 		return ginkgo.Context(context, func() {
 			ginkgo.It("should print successful creation information", func() {
-				output, err := Exec(cli)
-				gomega.Expect(err).NotTo(gomega.HaveOccurred())
-				gomega.Expect(output).To(gomega.ContainSubstring("deployed"))
+				output, err := ExecAndRetry(cli, "deployed", 90*time.Second)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred(), output)
 			})
 		})
 	}
@@ -161,12 +173,25 @@ var (
 		})
 	}
 
+	// TraitDetachContext used for test trait detach success
+	TraitDetachContext = func(context string, traitAlias string, applicationName string) bool {
+		return ginkgo.Context(context, func() {
+			ginkgo.It("should print successful detached information", func() {
+				cli := fmt.Sprintf("vela trait detach %s %s --svc %s", traitAlias, applicationName, applicationName)
+				output, err := LongTimeExec(cli, 180*time.Second)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(output).To(gomega.ContainSubstring("Removing " + traitAlias + " for app"))
+				gomega.Expect(output).To(gomega.ContainSubstring("Succeeded!"))
+			})
+		})
+	}
+
 	// ComponentListContext used for test vela svc ls
 	ComponentListContext = func(context string, applicationName string, workloadType string, traitAlias string) bool {
 		return ginkgo.Context("ls", func() {
 			ginkgo.It("should list all applications", func() {
-				output, err := Exec("vela ls")
-				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				output, err := ExecAndRetry("vela ls", applicationName, 60*time.Second)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred(), output)
 				gomega.Expect(output).To(gomega.ContainSubstring("SERVICE"))
 				gomega.Expect(output).To(gomega.ContainSubstring(applicationName))
 				gomega.Expect(output).To(gomega.ContainSubstring(workloadType))
@@ -181,10 +206,30 @@ var (
 		return ginkgo.Context(context, func() {
 			ginkgo.It("should get status for the application", func() {
 				cli := fmt.Sprintf("vela status %s", applicationName)
-				output, err := Exec(cli)
-				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				output, err := ExecAndRetry(cli, "Healthy", 120*time.Second)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred(), output)
 				gomega.Expect(output).To(gomega.ContainSubstring(applicationName))
-				// TODO(roywang) add more assertion to check health status
+			})
+		})
+	}
+
+	ComponentStatusContext = func(context string, svcName, workloadType string) bool {
+		return ginkgo.Context(context, func() {
+			ginkgo.It("should print status of the service as json", func() {
+				cli := fmt.Sprintf("vela svc status %s -o json", svcName)
+				output, err := ExecAndRetry(cli, `"health": "Healthy"`, 120*time.Second)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred(), output)
+				var status struct {
+					Type     string               `json:"type"`
+					Service  string               `json:"service"`
+					Health   string               `json:"health"`
+					Workload *apis.ResourceHealth `json:"workload"`
+				}
+				gomega.Expect(json.Unmarshal([]byte(output), &status)).To(gomega.Succeed(), output)
+				gomega.Expect(status.Service).To(gomega.Equal(svcName))
+				gomega.Expect(status.Type).To(gomega.Equal(workloadType))
+				gomega.Expect(status.Health).To(gomega.Equal("Healthy"))
+				gomega.Expect(status.Workload).NotTo(gomega.BeNil())
 			})
 		})
 	}
@@ -218,8 +263,7 @@ var (
 				cli := fmt.Sprintf("vela show %s", applicationName)
 				output, err := Exec(cli)
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
-				// TODO(zzxwill) need to check workloadType after app show is refined
-				//gomega.Expect(output).To(gomega.ContainSubstring(workloadType))
+				gomega.Expect(output).To(gomega.ContainSubstring(workloadType))
 				gomega.Expect(output).To(gomega.ContainSubstring(applicationName))
 			})
 		})
@@ -307,9 +351,22 @@ var (
 	APIEnvInitContext = func(context string, envMeta apis.Environment) bool {
 		return ginkgo.Context("Post /envs/", func() {
 			ginkgo.It("should create an env", func() {
-				data, err := json.Marshal(&envMeta)
+				r, err := PostJSON("/envs/", &envMeta)
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
-				resp, err := http.Post(util.URL("/envs/"), "application/json", strings.NewReader(string(data)))
+				gomega.Expect(http.StatusOK).Should(gomega.Equal(r.Code))
+				gomega.Expect(r.Error).To(gomega.BeNil())
+				gomega.Expect(r.Data.(string)).To(gomega.ContainSubstring("created"))
+			})
+		})
+	}
+
+	// APIEnvDeleteContext used for test api env delete
+	APIEnvDeleteContext = func(context string, envName string) bool {
+		return ginkgo.Context("Delete /envs/:envName", func() {
+			ginkgo.It("should delete an env", func() {
+				req, err := http.NewRequest("DELETE", util.URL("/envs/"+envName), nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				resp, err := http.DefaultClient.Do(req)
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 				defer resp.Body.Close()
 				result, err := ioutil.ReadAll(resp.Body)
@@ -318,7 +375,8 @@ var (
 				err = json.Unmarshal(result, &r)
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 				gomega.Expect(http.StatusOK).Should(gomega.Equal(r.Code))
-				gomega.Expect(r.Data.(string)).To(gomega.ContainSubstring("created"))
+				gomega.Expect(r.Error).To(gomega.BeNil())
+				gomega.Expect(r.Data.(string)).To(gomega.ContainSubstring(envName + " deleted"))
 			})
 		})
 	}
@@ -10,6 +10,7 @@ import (
 var (
 	envName  = "env-hello"
 	envName2 = "env-world"
+	envName3 = "env-renamed"
 )
 
 var _ = ginkgo.Describe("Env", func() {
@@ -23,12 +24,15 @@ var _ = ginkgo.Describe("Env", func() {
 			output, err := e2e.Exec("vela env ls")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(output).To(gomega.ContainSubstring("NAME"))
+			gomega.Expect(output).To(gomega.ContainSubstring("CURRENT"))
 			gomega.Expect(output).To(gomega.ContainSubstring("NAMESPACE"))
 			gomega.Expect(output).To(gomega.ContainSubstring(envName))
 			gomega.Expect(output).To(gomega.ContainSubstring(envName2))
 		})
 	})
 
+	e2e.EnvRenameContext("env rename", envName2, envName3)
+	e2e.EnvRenameContext("env rename back", envName3, envName2)
 	e2e.EnvDeleteContext("env delete", envName2)
 	e2e.EnvDeleteCurrentUsingContext("env delete currently using one", envName)
 	// TODO(zzxwill) Delete an env which does not exist
@@ -44,5 +44,16 @@ var _ = ginkgo.Describe("Trait", func() {
 		})
 	})
 
+	e2e.TraitDetachContext("vela detach trait", traitAlias, applicationName)
+
+	ginkgo.Context("vela detach a trait not attached", func() {
+		ginkgo.It("should alert trait not attached", func() {
+			cli := fmt.Sprintf("vela trait detach %s %s --svc %s", traitAlias, applicationName, applicationName)
+			output, err := e2e.Exec(cli)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(output).To(gomega.ContainSubstring("trait " + traitAlias + " is not attached to service " + applicationName))
+		})
+	})
+
 	e2e.WorkloadDeleteContext("delete", applicationName)
 })
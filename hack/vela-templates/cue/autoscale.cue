@@ -6,6 +6,9 @@ output: {
 	spec: {
 		minReplicas: parameter.min
 		maxReplicas: parameter.max
+		if parameter["restoreToOriginalReplicaCount"] != _|_ {
+			advanced: restoreToOriginalReplicaCount: parameter.restoreToOriginalReplicaCount
+		}
 		if parameter["cpuPercent"] != _|_ && parameter["cron"] != _|_ {
 			triggers: [cpuScaler, cronScaler]
 		}
@@ -36,9 +39,14 @@ cronScaler: {
 			duration: parameter.cron.duration
 			days:     parameter.cron.days
 			replicas: strconv.FormatInt(parameter.cron.replicas, 10)
-			timezone: parameter.cron.timezone
+			if parameter.cron["timezone"] != _|_ {
+				timezone: parameter.cron.timezone
+			}
 		}
 	}
+	if parameter["cron"] != _|_ && parameter.cron["windows"] != _|_ {
+		windows: parameter.cron.windows
+	}
 }
 
 parameter: {
@@ -49,7 +57,7 @@ parameter: {
 	// +usage=specify the value for CPU utilization, like 80, which means 80%
 	// +alias=cpu-percent
 	cpuPercent?: int
-	// +usage=just for `appfile`, not available for Cli usage
+	// +usage=cron scaling policy, set by `--cron-*` flags in Cli
 	cron?: {
 		// +usage=the time to start scaling, like `08:00`
 		startAt: string
@@ -59,7 +67,16 @@ parameter: {
 		days: string
 		// +usage=the target replicas to be scaled to
 		replicas: int
-		// +usage=timezone, like "America/Los_Angeles"
-		timezone: string
+		// +usage=IANA time zone, like "America/Los_Angeles", default to the time zone of the cluster
+		timezone?: string
+		// +usage=more time windows of the days besides the one above, each has its own startAt, duration and replicas
+		windows?: [...{
+			startAt:  string
+			duration: string
+			replicas: int
+		}]
 	}
+	// +usage=scale the workload back to its original replicas when the autoscale trait is detached
+	// +alias=restore-original-replicas
+	restoreToOriginalReplicaCount?: bool
 }
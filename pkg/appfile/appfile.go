@@ -49,9 +49,13 @@ func LoadFromFile(filename string) (*AppFile, error) {
 	if err != nil {
 		return nil, err
 	}
+	return LoadFromBytes(b)
+}
+
+// LoadFromBytes parses Appfile from its content, which could be YAML or JSON
+func LoadFromBytes(b []byte) (*AppFile, error) {
 	af := NewAppFile()
-	err = yaml.Unmarshal(b, af)
-	if err != nil {
+	if err := yaml.Unmarshal(b, af); err != nil {
 		return nil, err
 	}
 	return af, nil
@@ -43,7 +43,7 @@ func (s Service) GetConfig() map[string]interface{} {
 outerLoop:
 	for k, v := range s {
 		switch k {
-		case "build", "type", "config": // skip
+		case "build", "type", "config", "labels", "annotations": // skip
 			continue outerLoop
 		}
 		config[k] = v
@@ -51,6 +51,31 @@ outerLoop:
 	return config
 }
 
+// GetLabels returns the labels of the service, which will be applied to the component and its workload
+func (s Service) GetLabels() map[string]string {
+	return s.getStringMap("labels")
+}
+
+// GetAnnotations returns the annotations of the service, which will be applied to the component and its workload
+func (s Service) GetAnnotations() map[string]string {
+	return s.getStringMap("annotations")
+}
+
+func (s Service) getStringMap(key string) map[string]string {
+	result := make(map[string]string)
+	switch v := s[key].(type) {
+	case map[string]string:
+		for k, val := range v {
+			result[k] = val
+		}
+	case map[string]interface{}:
+		for k, val := range v {
+			result[k] = fmt.Sprint(val)
+		}
+	}
+	return result
+}
+
 func (s Service) GetBuild() *Build {
 	v, ok := s["build"]
 	if !ok {
@@ -108,6 +133,9 @@ func (s Service) RenderService(tm template.Manager, name, ns string, cg configGe
 	if err != nil {
 		return nil, nil, fmt.Errorf("eval service failed: %w", err)
 	}
+	component.SetLabels(s.GetLabels())
+	component.SetAnnotations(s.GetAnnotations())
+	mergeMetadata(u, s.GetLabels(), s.GetAnnotations())
 	component.Spec.Workload.Object = u
 
 	// render traits
@@ -137,6 +165,30 @@ func (s Service) RenderService(tm template.Manager, name, ns string, cg configGe
 	return acComp, component, nil
 }
 
+// mergeMetadata merges labels and annotations into the object, existing keys will be overridden
+func mergeMetadata(u *unstructured.Unstructured, labels, annotations map[string]string) {
+	if len(labels) > 0 {
+		l := u.GetLabels()
+		if l == nil {
+			l = make(map[string]string)
+		}
+		for k, v := range labels {
+			l[k] = v
+		}
+		u.SetLabels(l)
+	}
+	if len(annotations) > 0 {
+		a := u.GetAnnotations()
+		if a == nil {
+			a = make(map[string]string)
+		}
+		for k, v := range annotations {
+			a[k] = v
+		}
+		u.SetAnnotations(a)
+	}
+}
+
 func (af *AppFile) GetServices() map[string]Service {
 	return af.Services
 }
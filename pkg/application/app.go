@@ -59,6 +59,20 @@ func LoadFromFile(fileName string) (*Application, error) {
 	return app, app.Validate()
 }
 
+// LoadFromBytes loads an application from the content of its appfile, which could be YAML or JSON
+func LoadFromBytes(data []byte) (*Application, error) {
+	tm, err := template.Load()
+	if err != nil {
+		return nil, err
+	}
+	f, err := appfile.LoadFromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	app := newApplication(f, tm)
+	return app, app.Validate()
+}
+
 func Load(envName, appName string) (*Application, error) {
 	appDir, err := getApplicationDir(envName)
 	if err != nil {
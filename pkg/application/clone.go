@@ -0,0 +1,45 @@
+package application
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/oam-dev/kubevela/pkg/appfile"
+)
+
+// Clone returns a copy of the application with a new name. Services will be renamed as `<name>-<service>`
+// if renameServices is true, this avoids the conflicts of components when cloning in the same namespace.
+// images maps the service name to the new image it will use.
+func (app *Application) Clone(name string, renameServices bool, images map[string]string) (*Application, error) {
+	data, err := yaml.Marshal(app.AppFile)
+	if err != nil {
+		return nil, err
+	}
+	f := appfile.NewAppFile()
+	if err = yaml.Unmarshal(data, f); err != nil {
+		return nil, err
+	}
+	for svcName := range images {
+		if _, ok := f.Services[svcName]; !ok {
+			return nil, fmt.Errorf("service %s not found in app %s", svcName, app.Name)
+		}
+	}
+	f.Name = name
+	f.CreateTime = time.Time{}
+	f.UpdateTime = time.Time{}
+	services := make(map[string]appfile.Service)
+	for svcName, svc := range f.Services {
+		if image, ok := images[svcName]; ok {
+			svc["image"] = image
+		}
+		if renameServices {
+			svcName = name + "-" + svcName
+		}
+		services[svcName] = svc
+	}
+	f.Services = services
+	clone := newApplication(f, app.tm)
+	return clone, clone.Validate()
+}
@@ -0,0 +1,61 @@
+package application
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/oam-dev/kubevela/pkg/appfile/template"
+)
+
+func TestClone(t *testing.T) {
+	raw := `name: myapp
+services:
+  frontend:
+    image: nginx:1.18
+  backend:
+    type: worker
+    image: "back:v1"
+`
+	cases := map[string]struct {
+		renameServices bool
+		images         map[string]string
+		expComponents  []string
+		expImage       map[string]string
+		expErr         error
+	}{
+		"keep service names": {
+			expComponents: []string{"backend", "frontend"},
+			expImage:      map[string]string{"frontend": "nginx:1.18", "backend": "back:v1"},
+		},
+		"rename services and set image": {
+			renameServices: true,
+			images:         map[string]string{"frontend": "nginx:1.19"},
+			expComponents:  []string{"myapp-copy-backend", "myapp-copy-frontend"},
+			expImage:       map[string]string{"myapp-copy-frontend": "nginx:1.19", "myapp-copy-backend": "back:v1"},
+		},
+		"set image of a service not exist": {
+			images: map[string]string{"notexist": "nginx:1.19"},
+			expErr: errors.New("service notexist not found in app myapp"),
+		},
+	}
+	for caseName, c := range cases {
+		app := newApplication(nil, template.NewFakeTemplateManager())
+		assert.NoError(t, yaml.Unmarshal([]byte(raw), &app), caseName)
+		clone, err := app.Clone("myapp-copy", c.renameServices, c.images)
+		if c.expErr != nil {
+			assert.Equal(t, c.expErr, err, caseName)
+			continue
+		}
+		assert.NoError(t, err, caseName)
+		assert.Equal(t, "myapp-copy", clone.Name, caseName)
+		assert.Equal(t, c.expComponents, clone.GetComponents(), caseName)
+		for svc, image := range c.expImage {
+			assert.Equal(t, image, clone.Services[svc]["image"], caseName)
+		}
+		// the source application should not be modified
+		assert.Equal(t, "nginx:1.18", app.Services["frontend"]["image"], caseName)
+	}
+}
@@ -2,6 +2,7 @@ package application
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/oam-dev/kubevela/pkg/appfile"
 )
@@ -70,3 +71,33 @@ func (app *Application) RemoveComponent(componentName string) error {
 	delete(app.Services, componentName)
 	return nil
 }
+
+// SetServiceMetadata merges labels and annotations into the service, they will be applied to the component and its workload
+func (app *Application) SetServiceMetadata(componentName string, labels, annotations map[string]string) error {
+	if app == nil {
+		return errors.New("app is nil pointer")
+	}
+
+	s, ok := app.Services[componentName]
+	if !ok {
+		return fmt.Errorf("service %s doesn't exist", componentName)
+	}
+	mergeStringMap(s, "labels", s.GetLabels(), labels)
+	mergeStringMap(s, "annotations", s.GetAnnotations(), annotations)
+	app.Services[componentName] = s
+	return nil
+}
+
+func mergeStringMap(s appfile.Service, key string, existing, data map[string]string) {
+	if len(data) == 0 {
+		return
+	}
+	m := make(map[string]interface{})
+	for k, v := range existing {
+		m[k] = v
+	}
+	for k, v := range data {
+		m[k] = v
+	}
+	s[key] = m
+}
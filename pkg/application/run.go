@@ -2,10 +2,13 @@ package application
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
 	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	ctypes "k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -21,6 +24,81 @@ func (app *Application) BuildRun(ctx context.Context, client client.Client, env
 	return app.Run(ctx, client, appconfig, components, scopes)
 }
 
+// RenderResources renders the workload and traits of the component into Kubernetes resources without applying them,
+// all components are rendered if componentName is empty
+func (app *Application) RenderResources(componentName string, env *types.EnvMeta, io cmdutil.IOStreams) ([]*unstructured.Unstructured, error) {
+	comps, appConfig, _, err := app.OAM(env, io, true)
+	if err != nil {
+		return nil, err
+	}
+	var resources []*unstructured.Unstructured
+	for _, comp := range comps {
+		if componentName != "" && comp.Name != componentName {
+			continue
+		}
+		workload, ok := comp.Spec.Workload.Object.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		// the workload is named after the component if it's not set, same as what OAM runtime does
+		if workload.GetName() == "" {
+			workload.SetName(comp.Name)
+		}
+		workload.SetNamespace(env.Namespace)
+		resources = append(resources, workload)
+	}
+	for _, acComp := range appConfig.Spec.Components {
+		if componentName != "" && acComp.ComponentName != componentName {
+			continue
+		}
+		for _, t := range acComp.Traits {
+			trait, ok := t.Trait.Object.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			trait.SetNamespace(env.Namespace)
+			resources = append(resources, trait)
+		}
+	}
+	return resources, nil
+}
+
+// RenderManifests renders the application into the Components, scopes and ApplicationConfiguration which BuildRun
+// applies, in the same order, without applying them
+func (app *Application) RenderManifests(env *types.EnvMeta, io cmdutil.IOStreams) ([]*unstructured.Unstructured, error) {
+	comps, appConfig, scopes, err := app.OAM(env, io, true)
+	if err != nil {
+		return nil, err
+	}
+	var objs []runtime.Object
+	for _, comp := range comps {
+		comp.SetGroupVersionKind(v1alpha2.ComponentGroupVersionKind)
+		objs = append(objs, comp)
+	}
+	for _, scope := range scopes {
+		objs = append(objs, scope)
+	}
+	appConfig.SetGroupVersionKind(v1alpha2.ApplicationConfigurationGroupVersionKind)
+	objs = append(objs, appConfig)
+
+	manifests := make([]*unstructured.Unstructured, 0, len(objs))
+	for _, obj := range objs {
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		manifest := &unstructured.Unstructured{}
+		if err := manifest.UnmarshalJSON(data); err != nil {
+			return nil, err
+		}
+		// drop the fields which are set by the cluster
+		unstructured.RemoveNestedField(manifest.Object, "metadata", "creationTimestamp")
+		unstructured.RemoveNestedField(manifest.Object, "status")
+		manifests = append(manifests, manifest)
+	}
+	return manifests, nil
+}
+
 func (app *Application) Run(ctx context.Context, client client.Client,
 	ac *v1alpha2.ApplicationConfiguration, comps []*v1alpha2.Component, scopes []oam.Object) error {
 	for _, comp := range comps {
@@ -0,0 +1,91 @@
+package application
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/oam-dev/kubevela/api/types"
+	"github.com/oam-dev/kubevela/pkg/appfile/template"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+)
+
+func TestRenderResources(t *testing.T) {
+	appfile := `name: myapp
+services:
+  frontend:
+    image: nginx
+    scaler:
+      replicas: 2
+  backend:
+    image: redis
+`
+	templateWebservice := `parameter: #webservice
+#webservice: {
+  image: string
+}
+
+output: {
+  apiVersion: "apps/v1"
+  kind: "Deployment"
+  spec: {
+    image: parameter.image
+  }
+}
+`
+	templateScaler := `parameter: #scaler
+#scaler: {
+  replicas: int
+}
+
+output: {
+  apiVersion: "core.oam.dev/v1alpha2"
+  kind: "ManualScalerTrait"
+  spec: {
+    replicaCount: parameter.replicas
+  }
+}
+`
+	tm := template.NewFakeTemplateManager()
+	tm.Templates["webservice"] = &template.Template{Captype: types.TypeWorkload, Raw: templateWebservice}
+	tm.Templates["scaler"] = &template.Template{Captype: types.TypeTrait, Raw: templateScaler}
+	env := &types.EnvMeta{Name: "default", Namespace: "staging"}
+
+	cases := map[string]struct {
+		componentName string
+		expResources  []string
+	}{
+		"only the given service": {
+			componentName: "frontend",
+			expResources:  []string{"Deployment/frontend", "ManualScalerTrait/"},
+		},
+		"service without traits": {
+			componentName: "backend",
+			expResources:  []string{"Deployment/backend"},
+		},
+		"all services": {
+			expResources: []string{"Deployment/backend", "Deployment/frontend", "ManualScalerTrait/"},
+		},
+		"service not found": {
+			componentName: "worker",
+		},
+	}
+	for name, c := range cases {
+		app := newApplication(nil, tm)
+		assert.NoError(t, yaml.Unmarshal([]byte(appfile), &app), name)
+		var b bytes.Buffer
+		resources, err := app.RenderResources(c.componentName, env, cmdutil.IOStreams{Out: &b})
+		assert.NoError(t, err, name)
+		var got []string
+		for _, r := range resources {
+			// resources are rendered into the namespace of env
+			assert.Equal(t, env.Namespace, r.GetNamespace(), name)
+			got = append(got, r.GetKind()+"/"+r.GetName())
+		}
+		assert.ElementsMatch(t, c.expResources, got, name)
+		// rendering resources doesn't print progress
+		assert.Empty(t, b.String(), name)
+	}
+}
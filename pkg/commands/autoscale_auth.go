@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	kedav1alpha1 "github.com/wonderflow/keda-api/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/api/types"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+)
+
+const (
+	// FromSecret is the flag name of the secret to generate TriggerAuthentication from
+	FromSecret = "from-secret"
+	// SecretMap is the flag name of mappings from KEDA parameters to keys of the secret
+	SecretMap = "map"
+)
+
+// NewAutoscaleAuthCommand creates `auth` command of autoscale trait to manage authentications of KEDA triggers
+func NewAutoscaleAuthCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Manage authentications of autoscale triggers",
+		Long:  "Manage KEDA TriggerAuthentication objects which could be referenced by authenticationRef of autoscale triggers",
+	}
+	cmd.SetOut(ioStreams.Out)
+	cmd.AddCommand(NewAutoscaleAuthCreateCommand(c, ioStreams))
+	return cmd
+}
+
+// NewAutoscaleAuthCreateCommand creates a TriggerAuthentication mapping keys of a secret to KEDA trigger parameters
+func NewAutoscaleAuthCreateCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
+	ctx := context.Background()
+	cmd := &cobra.Command{
+		Use:     "create <name>",
+		Short:   "Create a TriggerAuthentication from a secret",
+		Long:    "Create a KEDA TriggerAuthentication which maps keys of a Kubernetes Secret to parameters of autoscale triggers",
+		Example: `vela autoscale auth create redis-auth --from-secret redis-secret --map password=REDIS_PASSWORD`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errors.New("must specify name for the trigger authentication")
+			}
+			secretName, err := cmd.Flags().GetString(FromSecret)
+			if err != nil {
+				return err
+			}
+			if secretName == "" {
+				return fmt.Errorf("must specify the secret by --%s", FromSecret)
+			}
+			mappings, err := cmd.Flags().GetStringSlice(SecretMap)
+			if err != nil {
+				return err
+			}
+			refs, err := parseSecretTargetRefs(secretName, mappings)
+			if err != nil {
+				return err
+			}
+			env, err := GetEnv(cmd)
+			if err != nil {
+				return err
+			}
+			auth := &kedav1alpha1.TriggerAuthentication{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: kedav1alpha1.GroupVersion.String(),
+					Kind:       "TriggerAuthentication",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      args[0],
+					Namespace: env.Namespace,
+				},
+				Spec: kedav1alpha1.TriggerAuthenticationSpec{
+					SecretTargetRef: refs,
+				},
+			}
+			dryRun, err := cmd.Flags().GetBool("dry-run")
+			if err != nil {
+				return err
+			}
+			if dryRun {
+				b, err := yaml.Marshal(auth)
+				if err != nil {
+					return err
+				}
+				ioStreams.Info(string(b))
+				return nil
+			}
+			newClient, err := client.New(c.Config, client.Options{Scheme: c.Schema})
+			if err != nil {
+				return err
+			}
+			if err := applyTriggerAuthentication(ctx, newClient, auth); err != nil {
+				return err
+			}
+			ioStreams.Infof("TriggerAuthentication %s created, reference it by authenticationRef of autoscale triggers\n", auth.Name)
+			return nil
+		},
+	}
+	cmd.Flags().String(FromSecret, "", "name of the secret in the namespace of current env")
+	cmd.Flags().StringSlice(SecretMap, nil, "map a trigger parameter to a key of the secret in the format of parameter=KEY, could be specified multiple times")
+	cmd.Flags().Bool("dry-run", false, "only print the TriggerAuthentication without creating it")
+	cmd.SetOut(ioStreams.Out)
+	return cmd
+}
+
+// parseSecretTargetRefs parses mappings in the format of parameter=KEY into references of the secret
+func parseSecretTargetRefs(secretName string, mappings []string) ([]kedav1alpha1.AuthSecretTargetRef, error) {
+	if len(mappings) == 0 {
+		return nil, fmt.Errorf("must specify at least one mapping by --%s", SecretMap)
+	}
+	refs := make([]kedav1alpha1.AuthSecretTargetRef, 0, len(mappings))
+	for _, m := range mappings {
+		kv := strings.SplitN(m, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return nil, fmt.Errorf("invalid mapping %s, should be in the format of parameter=KEY", m)
+		}
+		refs = append(refs, kedav1alpha1.AuthSecretTargetRef{
+			Parameter: kv[0],
+			Name:      secretName,
+			Key:       kv[1],
+		})
+	}
+	return refs, nil
+}
+
+// applyTriggerAuthentication creates the TriggerAuthentication or updates its spec if it already exists
+func applyTriggerAuthentication(ctx context.Context, c client.Client, auth *kedav1alpha1.TriggerAuthentication) error {
+	var existing kedav1alpha1.TriggerAuthentication
+	err := c.Get(ctx, client.ObjectKey{Namespace: auth.Namespace, Name: auth.Name}, &existing)
+	if apierrors.IsNotFound(err) {
+		return c.Create(ctx, auth)
+	}
+	if err != nil {
+		return err
+	}
+	existing.Spec = auth.Spec
+	return c.Update(ctx, &existing)
+}
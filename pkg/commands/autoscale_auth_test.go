@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	kedav1alpha1 "github.com/wonderflow/keda-api/api/v1alpha1"
+)
+
+func TestParseSecretTargetRefs(t *testing.T) {
+	cases := map[string]struct {
+		mappings []string
+		expect   []kedav1alpha1.AuthSecretTargetRef
+		wantErr  bool
+	}{
+		"single mapping": {
+			mappings: []string{"password=REDIS_PASSWORD"},
+			expect: []kedav1alpha1.AuthSecretTargetRef{
+				{Parameter: "password", Name: "redis-secret", Key: "REDIS_PASSWORD"},
+			},
+		},
+		"multiple mappings": {
+			mappings: []string{"username=USER", "password=PASS"},
+			expect: []kedav1alpha1.AuthSecretTargetRef{
+				{Parameter: "username", Name: "redis-secret", Key: "USER"},
+				{Parameter: "password", Name: "redis-secret", Key: "PASS"},
+			},
+		},
+		"no mapping": {
+			wantErr: true,
+		},
+		"missing key": {
+			mappings: []string{"password="},
+			wantErr:  true,
+		},
+		"missing equal sign": {
+			mappings: []string{"password"},
+			wantErr:  true,
+		},
+	}
+	for name, c := range cases {
+		got, err := parseSecretTargetRefs("redis-secret", c.mappings)
+		if c.wantErr {
+			assert.Error(t, err, name)
+			continue
+		}
+		assert.NoError(t, err, name)
+		assert.Equal(t, c.expect, got, name)
+	}
+}
@@ -0,0 +1,195 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/api/types"
+	"github.com/oam-dev/kubevela/api/v1alpha1"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+)
+
+const (
+	// ExitCodeWaitTimeout is the exit code when autoscalers are not ready before timeout
+	ExitCodeWaitTimeout = 2
+	// ExitCodeWaitError is the exit code when waiting autoscalers failed
+	ExitCodeWaitError = 3
+
+	waitAutoscalerInterval = 2 * time.Second
+)
+
+var kindAutoscaler = reflect.TypeOf(v1alpha1.Autoscaler{}).Name()
+
+// AutoscalerReadiness is the readiness of an autoscaler trait
+type AutoscalerReadiness struct {
+	Service string                   `json:"service"`
+	Name    string                   `json:"name"`
+	Phase   v1alpha1.AutoscalerPhase `json:"phase"`
+	Ready   bool                     `json:"ready"`
+	Message string                   `json:"message,omitempty"`
+}
+
+func NewWaitAutoscalerReadyCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
+	ctx := context.Background()
+	cmd := &cobra.Command{
+		Use:   "wait-autoscaler-ready APP_NAME",
+		Short: "Wait until autoscalers of an application are ready",
+		Long: fmt.Sprintf("Wait until autoscalers of an application are ready, which means they are in the Ready phase. "+
+			"Exit code is %d if timeout, and %d if waiting failed.", ExitCodeWaitTimeout, ExitCodeWaitError),
+		Example: `vela wait-autoscaler-ready APP_NAME --timeout 5m`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("must specify name for application")
+			}
+			appName := args[0]
+			svcName, err := cmd.Flags().GetString("svc")
+			if err != nil {
+				return err
+			}
+			timeout, err := cmd.Flags().GetDuration("timeout")
+			if err != nil {
+				return err
+			}
+			output, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return err
+			}
+			if output != "" && output != "json" {
+				return fmt.Errorf("unsupported output format %s, only json is supported", output)
+			}
+			env, err := GetEnv(cmd)
+			if err != nil {
+				return err
+			}
+			newClient, err := client.New(c.Config, client.Options{Scheme: c.Schema})
+			if err != nil {
+				return err
+			}
+			readiness, ready, err := waitAutoscalerReady(ctx, newClient, appName, svcName, env, timeout)
+			if err != nil {
+				return &cmdutil.ExitError{Code: ExitCodeWaitError, Err: err}
+			}
+			if err := printAutoscalerReadiness(ioStreams, readiness, output); err != nil {
+				return err
+			}
+			if !ready {
+				return &cmdutil.ExitError{Code: ExitCodeWaitTimeout,
+					Err: fmt.Errorf("autoscalers are not ready after %s", timeout)}
+			}
+			return nil
+		},
+		Annotations: map[string]string{
+			types.TagCommandType: types.TypeApp,
+		},
+	}
+	cmd.Flags().StringP("svc", "s", "", "only wait for autoscalers of the specified service")
+	cmd.Flags().Duration("timeout", 5*time.Minute, "the max time to wait for autoscalers to be ready")
+	cmd.Flags().StringP("output", "o", "", "output format of the final state, only support json")
+	cmd.SetOut(ioStreams.Out)
+	return cmd
+}
+
+// waitAutoscalerReady polls autoscalers of the application until all of them are ready or timeout
+func waitAutoscalerReady(ctx context.Context, c client.Client, appName, svcName string, env *types.EnvMeta,
+	timeout time.Duration) ([]AutoscalerReadiness, bool, error) {
+	start := time.Now()
+	for {
+		readiness, ready, err := checkAutoscalerReadiness(ctx, c, appName, svcName, env)
+		if err != nil {
+			return nil, false, err
+		}
+		if ready || time.Since(start) > timeout {
+			return readiness, ready, nil
+		}
+		time.Sleep(waitAutoscalerInterval)
+	}
+}
+
+func checkAutoscalerReadiness(ctx context.Context, c client.Client, appName, svcName string,
+	env *types.EnvMeta) ([]AutoscalerReadiness, bool, error) {
+	_, appConfig, err := getApp(ctx, c, "", appName, env)
+	if err != nil {
+		return nil, false, err
+	}
+	var readiness []AutoscalerReadiness
+	allReady := true
+	for _, wl := range appConfig.Status.Workloads {
+		if svcName != "" && wl.ComponentName != svcName {
+			continue
+		}
+		for _, tr := range wl.Traits {
+			if tr.Reference.Kind != kindAutoscaler {
+				continue
+			}
+			r, err := getAutoscalerReadiness(ctx, c, appConfig, wl.ComponentName, tr.Reference.Name)
+			if err != nil {
+				return nil, false, err
+			}
+			allReady = allReady && r.Ready
+			readiness = append(readiness, r)
+		}
+	}
+	if readiness == nil {
+		if len(appConfig.Status.Workloads) == 0 {
+			// the application is not reconciled yet, keep waiting
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("no autoscaler found in application %s", appName)
+	}
+	return readiness, allReady, nil
+}
+
+// getAutoscalerReadiness tells the readiness by the phase of the autoscaler, which is computed by the controller
+// no matter the autoscaler scales by KEDA or falls back to HPA
+func getAutoscalerReadiness(ctx context.Context, c client.Client, appConfig *v1alpha2.ApplicationConfiguration,
+	svcName, scalerName string) (AutoscalerReadiness, error) {
+	r := AutoscalerReadiness{Service: svcName, Name: scalerName, Phase: v1alpha1.AutoscalerPhasePending}
+	var scaler v1alpha1.Autoscaler
+	if err := c.Get(ctx, client.ObjectKey{Namespace: appConfig.Namespace, Name: scalerName}, &scaler); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Message = "Autoscaler is not created"
+			return r, nil
+		}
+		return r, err
+	}
+	// the phase is empty until the autoscaler is reconciled
+	if scaler.Status.Phase != "" {
+		r.Phase = scaler.Status.Phase
+	}
+	r.Ready = r.Phase == v1alpha1.AutoscalerPhaseReady
+	if r.Phase == v1alpha1.AutoscalerPhaseError {
+		r.Message = scaler.GetCondition(runtimev1alpha1.TypeSynced).Message
+	} else {
+		r.Message = scaler.GetCondition(runtimev1alpha1.TypeReady).Message
+	}
+	return r, nil
+}
+
+func printAutoscalerReadiness(ioStreams cmdutil.IOStreams, readiness []AutoscalerReadiness, output string) error {
+	if output == "json" {
+		b, err := json.MarshalIndent(readiness, "", "  ")
+		if err != nil {
+			return err
+		}
+		ioStreams.Info(string(b))
+		return nil
+	}
+	table := uitable.New()
+	table.MaxColWidth = 60
+	table.AddRow("SERVICE", "AUTOSCALER", "PHASE", "READY", "MESSAGE")
+	for _, r := range readiness {
+		table.AddRow(r.Service, r.Name, r.Phase, r.Ready, r.Message)
+	}
+	ioStreams.Info(table.String())
+	return nil
+}
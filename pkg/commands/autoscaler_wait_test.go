@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/api/v1alpha1"
+)
+
+func TestGetAutoscalerReadiness(t *testing.T) {
+	appConfig := &v1alpha2.ApplicationConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}}
+	scaler := func(phase v1alpha1.AutoscalerPhase, conditions ...runtimev1alpha1.Condition) *v1alpha1.Autoscaler {
+		s := &v1alpha1.Autoscaler{}
+		s.SetConditions(conditions...)
+		s.Status.Phase = phase
+		return s
+	}
+	getErr := errors.New("get failed")
+
+	cases := map[string]struct {
+		scaler       *v1alpha1.Autoscaler
+		getErr       error
+		expReadiness AutoscalerReadiness
+		expErr       error
+	}{
+		"autoscaler not found": {
+			expReadiness: AutoscalerReadiness{Service: "web", Name: "scaler", Phase: v1alpha1.AutoscalerPhasePending,
+				Message: "Autoscaler is not created"},
+		},
+		"autoscaler not reconciled yet": {
+			scaler:       scaler(""),
+			expReadiness: AutoscalerReadiness{Service: "web", Name: "scaler", Phase: v1alpha1.AutoscalerPhasePending},
+		},
+		"autoscaler failed to reconcile": {
+			scaler: scaler(v1alpha1.AutoscalerPhaseError, runtimev1alpha1.ReconcileError(errors.New("invalid trigger"))),
+			expReadiness: AutoscalerReadiness{Service: "web", Name: "scaler", Phase: v1alpha1.AutoscalerPhaseError,
+				Message: "invalid trigger"},
+		},
+		"autoscaler degraded": {
+			scaler: scaler(v1alpha1.AutoscalerPhaseDegraded, runtimev1alpha1.ReconcileSuccess(),
+				runtimev1alpha1.Unavailable().WithMessage("ScaledTarget not found")),
+			expReadiness: AutoscalerReadiness{Service: "web", Name: "scaler", Phase: v1alpha1.AutoscalerPhaseDegraded,
+				Message: "ScaledTarget not found"},
+		},
+		"ready with KEDA": {
+			scaler: scaler(v1alpha1.AutoscalerPhaseReady, runtimev1alpha1.ReconcileSuccess(),
+				runtimev1alpha1.Available().WithMessage("ScaledObject is defined correctly")),
+			expReadiness: AutoscalerReadiness{Service: "web", Name: "scaler", Phase: v1alpha1.AutoscalerPhaseReady,
+				Ready: true, Message: "ScaledObject is defined correctly"},
+		},
+		"ready with the HPA fallback": {
+			scaler: scaler(v1alpha1.AutoscalerPhaseReady, runtimev1alpha1.ReconcileSuccess()),
+			expReadiness: AutoscalerReadiness{Service: "web", Name: "scaler", Phase: v1alpha1.AutoscalerPhaseReady,
+				Ready: true},
+		},
+		"failed to get the autoscaler": {
+			getErr:       getErr,
+			expReadiness: AutoscalerReadiness{Service: "web", Name: "scaler", Phase: v1alpha1.AutoscalerPhasePending},
+			expErr:       getErr,
+		},
+	}
+	for name, c := range cases {
+		cli := &test.MockClient{
+			MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+				if c.getErr != nil {
+					return c.getErr
+				}
+				if c.scaler == nil {
+					return apierrors.NewNotFound(schema.GroupResource{Resource: "autoscalers"}, key.Name)
+				}
+				c.scaler.DeepCopyInto(obj.(*v1alpha1.Autoscaler))
+				return nil
+			},
+		}
+		r, err := getAutoscalerReadiness(context.Background(), cli, appConfig, "web", "scaler")
+		assert.Equal(t, c.expErr, err, name)
+		assert.Equal(t, c.expReadiness, r, name)
+	}
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	"github.com/oam-dev/kubevela/api/types"
 	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
 	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/plugins"
 )
 
 func CapabilityCommandGroup(c types.Args, ioStream cmdutil.IOStreams) *cobra.Command {
@@ -30,6 +32,7 @@ func CapabilityCommandGroup(c types.Args, ioStream cmdutil.IOStreams) *cobra.Com
 		NewCapListCommand(ioStream),
 		NewCapInstallCommand(c, ioStream),
 		NewCapUninstallCommand(c, ioStream),
+		NewCapShowCommand(ioStream),
 	)
 	return cmd
 }
@@ -94,7 +97,7 @@ func NewCapInstallCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Comm
 			if err != nil {
 				return err
 			}
-			if _, err = oam.AddCapabilityIntoCluster(newClient, mapper, args[0]); err != nil {
+			if _, err = oam.AddCapabilityIntoCluster(context.Background(), newClient, mapper, args[0]); err != nil {
 				return err
 			}
 			return nil
@@ -126,7 +129,7 @@ func NewCapUninstallCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Co
 				}
 				name = l[1]
 			}
-			return oam.RemoveCapability(newClient, name, ioStreams)
+			return oam.RemoveCapability(context.Background(), newClient, name, ioStreams)
 		},
 	}
 	cmd.PersistentFlags().StringP("token", "t", "", "Github Repo token")
@@ -182,6 +185,45 @@ func NewCapListCommand(ioStreams cmdutil.IOStreams) *cobra.Command {
 	return cmd
 }
 
+func NewCapShowCommand(ioStreams cmdutil.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "show <name>",
+		Short:   "Show details of a workload type or trait",
+		Long:    "Show details of a workload type or trait",
+		Example: `vela cap show autoscale --examples`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("you must specify <name> for capability you want to show")
+			}
+			capability, err := plugins.LoadCapabilityByName(args[0])
+			if err != nil {
+				return err
+			}
+			showExamples, err := cmd.Flags().GetBool("examples")
+			if err != nil {
+				return err
+			}
+			if showExamples {
+				if capability.Example == "" {
+					return fmt.Errorf("no examples found for %s", capability.Name)
+				}
+				ioStreams.Info(capability.Example)
+				return nil
+			}
+			ioStreams.Infof("NAME: %s\nTYPE: %s\nDESCRIPTION: %s\n\n", capability.Name, capability.Type, capability.Description)
+			table := uitable.New()
+			table.AddRow("PARAMETER", "TYPE", "REQUIRED", "DEFAULT", "USAGE")
+			for _, p := range capability.Parameters {
+				table.AddRow(p.Name, p.Type, p.Required, p.Default, p.Usage)
+			}
+			ioStreams.Info(table.String())
+			return nil
+		},
+	}
+	cmd.Flags().Bool("examples", false, "print example snippets of the capability")
+	return cmd
+}
+
 func NewCapCenterListCommand(ioStreams cmdutil.IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "ls",
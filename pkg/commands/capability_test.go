@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/oam-dev/kubevela/api/types"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+	"github.com/oam-dev/kubevela/pkg/plugins"
+	"github.com/oam-dev/kubevela/pkg/utils/system"
+)
+
+func TestCapShowExamples(t *testing.T) {
+	assert.NoError(t, os.Setenv(system.VelaHomeEnv, ".test_vela_cap_show"))
+	home, err := system.GetVelaHomeDir()
+	assert.NoError(t, err)
+	defer os.RemoveAll(home)
+	capDir, err := system.GetCapabilityDir()
+	assert.NoError(t, err)
+	example := "autoscale:\n  min: 1\n  max: 5\n"
+	assert.Equal(t, 2, plugins.SinkTemp2Local([]types.Capability{
+		{Name: "autoscale", Type: types.TypeTrait, Example: example},
+		{Name: "route", Type: types.TypeTrait},
+	}, capDir))
+
+	cases := map[string]struct {
+		name      string
+		expOutput string
+		expErr    string
+	}{
+		"print examples": {
+			name:      "autoscale",
+			expOutput: example + "\n",
+		},
+		"no examples": {
+			name:   "route",
+			expErr: "no examples found for route",
+		},
+		"capability not installed": {
+			name:   "rollout",
+			expErr: "rollout not found",
+		},
+	}
+	for name, c := range cases {
+		var b bytes.Buffer
+		cmd := NewCapShowCommand(cmdutil.IOStreams{Out: &b})
+		cmd.SetArgs([]string{c.name, "--examples"})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		err := cmd.Execute()
+		if c.expErr != "" {
+			assert.EqualError(t, err, c.expErr, name)
+			continue
+		}
+		assert.NoError(t, err, name)
+		assert.Equal(t, c.expOutput, b.String(), name)
+	}
+}
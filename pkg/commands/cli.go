@@ -1,8 +1,10 @@
 package commands
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"runtime"
 
@@ -62,17 +64,27 @@ func NewCommand() *cobra.Command {
 	cmds.AddCommand(
 		// Getting Start
 		NewInstallCommand(commandArgs, fake.ChartSource, ioStream),
+		NewUninstallCommand(commandArgs, ioStream),
 		NewInitCommand(commandArgs, ioStream),
 		NewUpCommand(commandArgs, ioStream),
 
 		// Apps
 		NewListCommand(commandArgs, ioStream),
 		NewDeleteCommand(commandArgs, ioStream),
+		NewCloneCommand(commandArgs, ioStream),
+		NewGetResourcesCommand(commandArgs, ioStream),
+		NewDependenciesCommand(commandArgs, ioStream),
+		NewDiffCommand(commandArgs, ioStream),
+		NewRollbackCommand(commandArgs, ioStream),
+		NewEventsCommand(commandArgs, ioStream),
 		NewAppShowCommand(ioStream),
 		NewAppStatusCommand(commandArgs, ioStream),
+		NewWaitAutoscalerReadyCommand(commandArgs, ioStream),
 		NewExecCommand(commandArgs, ioStream),
 		NewPortForwardCommand(commandArgs, ioStream),
 		NewLogsCommand(commandArgs, ioStream),
+		NewSuspendCommand(commandArgs, ioStream),
+		NewResumeCommand(commandArgs, ioStream),
 		NewEnvCommand(commandArgs, ioStream),
 		NewConfigCommand(commandArgs, ioStream),
 
@@ -80,6 +92,7 @@ func NewCommand() *cobra.Command {
 		CapabilityCommandGroup(commandArgs, ioStream),
 		NewTemplateCommand(commandArgs, ioStream),
 		NewTraitsCommand(commandArgs, ioStream),
+		NewTraitCommand(commandArgs, ioStream),
 		NewWorkloadsCommand(commandArgs, ioStream),
 
 		// Helper
@@ -117,22 +130,85 @@ func PrintHelpByTag(cmd *cobra.Command, all []*cobra.Command, tag string) {
 	cmd.Println()
 }
 
+// VersionInfo is the structured version information of vela cli and the runtime in cluster
+type VersionInfo struct {
+	Version       string `json:"version"`
+	GitRevision   string `json:"gitRevision"`
+	BuildDate     string `json:"buildDate"`
+	GolangVersion string `json:"golangVersion"`
+	OAMRuntime    string `json:"oamRuntime,omitempty"`
+	// OAMRuntimeChart is the version of vela core chart, which could be pinned by `vela install --version`
+	OAMRuntimeChart string `json:"oamRuntimeChart,omitempty"`
+	KEDA            string `json:"keda,omitempty"`
+	KEDAInstalled   bool   `json:"kedaInstalled"`
+}
+
+// GetVersionInfo discovers versions of vela cli, and vela core and KEDA installed in cluster
+func GetVersionInfo() VersionInfo {
+	info := VersionInfo{
+		Version:       version.VelaVersion,
+		GitRevision:   version.GitRevision,
+		BuildDate:     version.BuildDate,
+		GolangVersion: runtime.Version(),
+	}
+	if oamChart, err := getOAMReleaseChart(types.DefaultOAMNS); err == nil {
+		info.OAMRuntime = oamChart.AppVersion()
+		info.OAMRuntimeChart = oamChart.Metadata.Version
+	}
+	if kedaVersion, err := GetKEDAReleaseVersion(types.DefaultKEDANS); err == nil {
+		info.KEDA = kedaVersion
+		info.KEDAInstalled = true
+	}
+	return info
+}
+
 func NewVersionCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "version",
 		Short: "Prints out build version information",
-		Long:  "Prints out build version information",
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Printf(`Version: %v
-GitRevision: %v
-GolangVersion: %v
-`,
-				version.VelaVersion,
-				version.GitRevision,
-				runtime.Version())
+		Long:  "Prints out build version information of vela cli, and versions of Vela Core and KEDA installed in cluster",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return err
+			}
+			switch output {
+			case "json":
+				b, err := json.MarshalIndent(GetVersionInfo(), "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(b))
+			case "":
+				printVersionInfo(cmd.OutOrStdout(), GetVersionInfo())
+			default:
+				return fmt.Errorf("unsupported output format %s, only json is supported", output)
+			}
+			return nil
 		},
 		Annotations: map[string]string{
 			types.TagCommandType: types.TypeSystem,
 		},
 	}
+	cmd.Flags().StringP("output", "o", "", "output format of version information, only support json")
+	return cmd
+}
+
+// printVersionInfo prints the versions in cluster after the ones of the cli, a component is not found in cluster
+// if it's not installed or the cluster is not accessible
+func printVersionInfo(w io.Writer, info VersionInfo) {
+	fmt.Fprintf(w, "Version: %v\n", info.Version)
+	fmt.Fprintf(w, "GitRevision: %v\n", info.GitRevision)
+	fmt.Fprintf(w, "BuildDate: %v\n", info.BuildDate)
+	fmt.Fprintf(w, "GolangVersion: %v\n", info.GolangVersion)
+	core := "not found"
+	if info.OAMRuntime != "" {
+		core = fmt.Sprintf("%s (chart %s)", info.OAMRuntime, info.OAMRuntimeChart)
+	}
+	fmt.Fprintf(w, "Vela Core: %s\n", core)
+	keda := "not found"
+	if info.KEDAInstalled {
+		keda = info.KEDA
+	}
+	fmt.Fprintf(w, "KEDA: %s\n", keda)
 }
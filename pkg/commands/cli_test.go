@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintVersionInfo(t *testing.T) {
+	cli := VersionInfo{Version: "v0.2.0", GitRevision: "abc123", BuildDate: "2020-11-20", GolangVersion: "go1.13"}
+	installed := cli
+	installed.OAMRuntime, installed.OAMRuntimeChart = "v0.2.0", "0.2.1"
+	installed.KEDA, installed.KEDAInstalled = "2.0.0", true
+	cases := map[string]struct {
+		info      VersionInfo
+		expOutput string
+	}{
+		"installed in cluster": {
+			info:      installed,
+			expOutput: "Vela Core: v0.2.0 (chart 0.2.1)\nKEDA: 2.0.0\n",
+		},
+		"not found in cluster": {
+			info:      cli,
+			expOutput: "Vela Core: not found\nKEDA: not found\n",
+		},
+	}
+	for name, c := range cases {
+		var b bytes.Buffer
+		printVersionInfo(&b, c.info)
+		assert.Equal(t, "Version: v0.2.0\nGitRevision: abc123\nBuildDate: 2020-11-20\nGolangVersion: go1.13\n"+
+			c.expOutput, b.String(), name)
+	}
+}
+
+func TestVersionInfoJSON(t *testing.T) {
+	info := VersionInfo{Version: "v0.2.0", GitRevision: "abc123", BuildDate: "2020-11-20", GolangVersion: "go1.13"}
+	b, err := json.Marshal(info)
+	assert.NoError(t, err)
+	// versions in cluster are omitted if they're not found, while kedaInstalled is always present
+	assert.JSONEq(t, `{"version":"v0.2.0","gitRevision":"abc123","buildDate":"2020-11-20","golangVersion":"go1.13",
+		"kedaInstalled":false}`, string(b))
+}
+
+func TestVersionCommandUnsupportedOutput(t *testing.T) {
+	cmd := NewVersionCommand()
+	cmd.SetArgs([]string{"-o", "yaml"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	assert.EqualError(t, cmd.Execute(), "unsupported output format yaml, only json is supported")
+}
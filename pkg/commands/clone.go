@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/api/types"
+	"github.com/oam-dev/kubevela/pkg/application"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+	"github.com/oam-dev/kubevela/pkg/utils/env"
+)
+
+// NewCloneCommand clones an application under a new name
+func NewCloneCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "clone SRC_APP_NAME DST_APP_NAME",
+		DisableFlagsInUseLine: true,
+		Short:                 "Clone an application under a new name",
+		Long:                  "Clone an application under a new name, in the same or another environment",
+		Annotations: map[string]string{
+			types.TagCommandType: types.TypeApp,
+		},
+		Example: "vela clone frontend frontend-test --set-image frontend=nginx:1.19 --to-env test",
+	}
+	cmd.SetOut(ioStreams.Out)
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return errors.New("must specify names for both the source and the destination app")
+		}
+		srcEnv, err := GetEnv(cmd)
+		if err != nil {
+			return err
+		}
+		dstEnv := srcEnv
+		toEnv, err := cmd.Flags().GetString("to-env")
+		if err != nil {
+			return err
+		}
+		if toEnv != "" {
+			if dstEnv, err = env.GetEnvByName(toEnv); err != nil {
+				return err
+			}
+		}
+		imageFlags, err := cmd.Flags().GetStringSlice("set-image")
+		if err != nil {
+			return err
+		}
+		images, err := parseSetImages(imageFlags)
+		if err != nil {
+			return err
+		}
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return err
+		}
+
+		if args[0] == args[1] && srcEnv.Name == dstEnv.Name {
+			return fmt.Errorf("cannot clone application %s onto itself in env %s", args[0], srcEnv.Name)
+		}
+		// never overwrite and redeploy an existing application by the clone, a missing one is loaded as an empty app
+		dst, err := application.Load(dstEnv.Name, args[1])
+		if err != nil {
+			return err
+		}
+		if dst.Name != "" {
+			return fmt.Errorf("application %s already exists in env %s", args[1], dstEnv.Name)
+		}
+		app, err := application.Load(srcEnv.Name, args[0])
+		if err != nil {
+			return err
+		}
+		// components are named after services, rename them when cloning in the same namespace to avoid conflicts
+		clone, err := app.Clone(args[1], srcEnv.Namespace == dstEnv.Namespace, images)
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			out, err := yaml.Marshal(clone.AppFile)
+			if err != nil {
+				return err
+			}
+			ioStreams.Info(string(out))
+			return nil
+		}
+
+		newClient, err := client.New(c.Config, client.Options{Scheme: c.Schema})
+		if err != nil {
+			return err
+		}
+		if err = clone.Save(dstEnv.Name); err != nil {
+			return err
+		}
+		if err = clone.BuildRun(context.Background(), newClient, dstEnv, ioStreams); err != nil {
+			return err
+		}
+		ioStreams.Infof("Application \"%s\" cloned to \"%s\" in env %s\n", args[0], args[1], dstEnv.Name)
+		return nil
+	}
+	cmd.Flags().String("to-env", "", "the environment to clone the app into, default to the current environment")
+	cmd.Flags().StringSlice("set-image", nil, "rewrite the image of a service in the clone, in format <service>=<image>")
+	cmd.Flags().Bool("dry-run", false, "only print the cloned application without applying it")
+	return cmd
+}
+
+func parseSetImages(flags []string) (map[string]string, error) {
+	images := make(map[string]string)
+	for _, f := range flags {
+		kv := strings.SplitN(f, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return nil, fmt.Errorf("invalid --set-image %s, should be in format <service>=<image>", f)
+		}
+		images[kv[0]] = kv[1]
+	}
+	return images, nil
+}
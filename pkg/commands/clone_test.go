@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/oam-dev/kubevela/api/types"
+	"github.com/oam-dev/kubevela/pkg/application"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+	"github.com/oam-dev/kubevela/pkg/utils/system"
+)
+
+func TestCloneDestinationExists(t *testing.T) {
+	assert.NoError(t, os.Setenv(system.VelaHomeEnv, ".test_vela_clone"))
+	home, err := system.GetVelaHomeDir()
+	assert.NoError(t, err)
+	defer os.RemoveAll(home)
+	assert.NoError(t, system.InitDefaultEnv())
+	for _, name := range []string{"frontend", "backend"} {
+		app, err := application.LoadFromBytes([]byte("name: " + name + "\nservices:\n  web:\n    image: nginx\n"))
+		assert.NoError(t, err)
+		assert.NoError(t, app.Save(types.DefaultEnvName))
+	}
+
+	cases := map[string]struct {
+		args   []string
+		expErr string
+	}{
+		"clone onto itself": {
+			args:   []string{"frontend", "frontend"},
+			expErr: "cannot clone application frontend onto itself in env default",
+		},
+		"destination exists": {
+			args:   []string{"frontend", "backend"},
+			expErr: "application backend already exists in env default",
+		},
+		"destination not exist": {
+			args: []string{"frontend", "frontend-test", "--dry-run"},
+		},
+	}
+	for name, c := range cases {
+		var b bytes.Buffer
+		cmd := NewCloneCommand(types.Args{}, cmdutil.IOStreams{Out: &b})
+		cmd.PersistentFlags().StringP("env", "e", "", "")
+		cmd.SetArgs(c.args)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		err := cmd.Execute()
+		if c.expErr != "" {
+			assert.EqualError(t, err, c.expErr, name)
+			continue
+		}
+		assert.NoError(t, err, name)
+		assert.Contains(t, b.String(), "name: frontend-test", name)
+	}
+	// the existing application is left untouched
+	app, err := application.Load(types.DefaultEnvName, "backend")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"web"}, app.GetComponents())
+}
@@ -1,16 +1,28 @@
 package commands
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/oam-dev/kubevela/api/types"
+	"github.com/oam-dev/kubevela/pkg/application"
 	"github.com/oam-dev/kubevela/pkg/commands/util"
 	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
 	"github.com/oam-dev/kubevela/pkg/oam"
 	"github.com/oam-dev/kubevela/pkg/plugins"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -20,6 +32,14 @@ const (
 	WorkloadType = "type"
 	TraitDetach  = "detach"
 	Service      = "svc"
+	Label        = "label"
+	Annotation   = "annotation"
+	// OutputResources is the flag to render resources of the service without applying
+	OutputResources = "output-resources"
+	// DeployFile is the flag to deploy applications from an appfile, or from stdin if it's "-"
+	DeployFile = "file"
+	// DryRun is the flag to render the Components and ApplicationConfiguration of the application without applying
+	DryRun = "dry-run"
 )
 
 type runOptions oam.RunOptions
@@ -39,6 +59,8 @@ func AddCompCommands(c types.Args, ioStreams util.IOStreams) *cobra.Command {
 
 	compCommands.AddCommand(
 		NewCompDeployCommands(c, ioStreams),
+		NewCompScaleCommand(c, ioStreams),
+		NewCompStatusCommand(c, ioStreams),
 	)
 	return compCommands
 }
@@ -51,7 +73,7 @@ func NewCompDeployCommands(c types.Args, ioStreams util.IOStreams) *cobra.Comman
 		DisableFlagParsing: true,
 		Short:              "Initialize and run a service",
 		Long:               "Initialize and run a service. The app name would be the same as service name, if it's not specified.",
-		Example:            "vela svc deploy -t <SERVICE_TYPE>",
+		Example:            "vela svc deploy -t <SERVICE_TYPE>\nvela svc deploy -f app.yaml\nvela svc deploy -t <SERVICE_TYPE> --dry-run -o yaml",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 0 || args[0] == "-h" {
 				err := cmd.Help()
@@ -70,6 +92,13 @@ func NewCompDeployCommands(c types.Args, ioStreams util.IOStreams) *cobra.Comman
 			if err != nil {
 				return err
 			}
+			file, err := getDeployFile(cmd, args)
+			if err != nil {
+				return err
+			}
+			if file != "" {
+				return o.RunFile(cmd, file, ioStreams)
+			}
 			if err := o.Complete(cmd, args); err != nil {
 				return err
 			}
@@ -83,6 +112,12 @@ func NewCompDeployCommands(c types.Args, ioStreams util.IOStreams) *cobra.Comman
 
 	runCmd.Flags().BoolP(Staging, "s", false, "only save changes locally without real update application")
 	runCmd.Flags().StringP(WorkloadType, "t", "", "specify workload type of the service")
+	runCmd.Flags().Bool(OutputResources, false, "only render the Kubernetes resources the service and its traits would generate, without saving or applying")
+	runCmd.Flags().Bool(DryRun, false, "only render the Components and ApplicationConfiguration the application would be deployed as, without saving or applying")
+	runCmd.Flags().StringP("output", "o", "yaml", "output format of --output-resources and --dry-run, support yaml and json")
+	runCmd.Flags().StringArray(Label, nil, "specify label in key=value format which will be applied to the service and its workload, can be repeated")
+	runCmd.Flags().StringArray(Annotation, nil, "specify annotation in key=value format which will be applied to the service and its workload, can be repeated")
+	runCmd.Flags().StringP(DeployFile, "f", "", "deploy applications from an appfile which could contain multiple YAML documents, use - to read from stdin")
 
 	return runCmd
 }
@@ -148,24 +183,277 @@ func (o *runOptions) Complete(cmd *cobra.Command, args []string) error {
 	if err = flags.Parse(args); err != nil {
 		return err
 	}
-	app, err := oam.BaseComplete(envName, workloadName, appName, flags, workloadType)
+	if err = applyEnvSettings(flags, template.Parameters, o.Env.Settings); err != nil {
+		return err
+	}
+	labels, annotations, err := getMetadataFromFlags(flags)
+	if err != nil {
+		return err
+	}
+	render, _, err := getRenderOptions(flags)
+	if err != nil {
+		return err
+	}
+	// keep local application untouched when only rendering resources
+	prepare := oam.BaseComplete
+	if render {
+		prepare = oam.PrepareApp
+	}
+	app, err := prepare(envName, workloadName, appName, flags, workloadType)
 	if err != nil {
 		return err
 	}
+	if len(labels) > 0 || len(annotations) > 0 {
+		if err = app.SetServiceMetadata(workloadName, labels, annotations); err != nil {
+			return err
+		}
+		if !render {
+			if err = app.Save(envName); err != nil {
+				return err
+			}
+		}
+	}
+	o.WorkloadName = workloadName
 
 	o.App = app
 	return err
 }
 
+// applyEnvSettings sets the default settings of the env to the flags of workload parameters not specified,
+// a setting could be keyed by the name or the alias of the parameter
+func applyEnvSettings(flags *pflag.FlagSet, params []types.Parameter, settings map[string]string) error {
+	for _, v := range params {
+		name := v.Name
+		if v.Alias != "" {
+			name = v.Alias
+		}
+		value, ok := settings[name]
+		if !ok {
+			value, ok = settings[v.Name]
+		}
+		flag := flags.Lookup(name)
+		if !ok || flag == nil || flag.Changed {
+			continue
+		}
+		if err := flags.Set(name, value); err != nil {
+			return fmt.Errorf("invalid default setting %s=%s of env: %v", name, value, err)
+		}
+	}
+	return nil
+}
+
+// getMetadataFromFlags parses and validates labels and annotations specified by --label and --annotation
+func getMetadataFromFlags(flags *pflag.FlagSet) (map[string]string, map[string]string, error) {
+	labelFlags, err := flags.GetStringArray(Label)
+	if err != nil {
+		return nil, nil, err
+	}
+	annotationFlags, err := flags.GetStringArray(Annotation)
+	if err != nil {
+		return nil, nil, err
+	}
+	labels, err := parseKeyValues(labelFlags, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid label: %v", err)
+	}
+	annotations, err := parseKeyValues(annotationFlags, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid annotation: %v", err)
+	}
+	return labels, annotations, nil
+}
+
+// parseKeyValues parses key=value pairs, keys must be qualified names and label values must be valid label values
+func parseKeyValues(kvs []string, isLabel bool) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, kv := range kvs {
+		i := strings.Index(kv, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("%s should be in key=value format", kv)
+		}
+		key, value := kv[:i], kv[i+1:]
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("key %s: %s", key, strings.Join(errs, "; "))
+		}
+		if isLabel {
+			if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+				return nil, fmt.Errorf("value %s of key %s: %s", value, key, strings.Join(errs, "; "))
+			}
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+// getRenderOptions tells whether the application is only rendered by --output-resources or --dry-run,
+// and the output format of the rendered objects
+func getRenderOptions(flags *pflag.FlagSet) (bool, string, error) {
+	outputResources, err := flags.GetBool(OutputResources)
+	if err != nil {
+		return false, "", err
+	}
+	dryRun, err := flags.GetBool(DryRun)
+	if err != nil {
+		return false, "", err
+	}
+	if outputResources && dryRun {
+		return false, "", fmt.Errorf("--%s can't be used along with --%s", OutputResources, DryRun)
+	}
+	output, err := flags.GetString("output")
+	if err != nil {
+		return false, "", err
+	}
+	if output != "yaml" && output != "json" {
+		return false, "", fmt.Errorf("unsupported output format %s, only yaml and json are supported", output)
+	}
+	return outputResources || dryRun, output, nil
+}
+
 func (o *runOptions) Run(cmd *cobra.Command, io cmdutil.IOStreams) error {
+	outputResources, err := cmd.Flags().GetBool(OutputResources)
+	if err != nil {
+		return err
+	}
+	dryRun, err := cmd.Flags().GetBool(DryRun)
+	if err != nil {
+		return err
+	}
+	if outputResources || dryRun {
+		_, output, err := getRenderOptions(cmd.Flags())
+		if err != nil {
+			return err
+		}
+		var objs []*unstructured.Unstructured
+		if outputResources {
+			objs, err = o.App.RenderResources(o.WorkloadName, o.Env, io)
+		} else {
+			objs, err = o.App.RenderManifests(o.Env, io)
+		}
+		if err != nil {
+			return err
+		}
+		return printResources(io, objs, output)
+	}
 	staging, err := cmd.Flags().GetBool(Staging)
 	if err != nil {
 		return err
 	}
-	msg, err := oam.BaseRun(staging, o.App, o.KubeClient, o.Env, io)
+	msg, err := oam.BaseRun(context.Background(), staging, o.App, o.KubeClient, o.Env, io)
 	if err != nil {
 		return err
 	}
 	o.Info(msg)
 	return nil
 }
+
+// getDeployFile parses the appfile to deploy from, flags of workload parameters are not known yet so they're ignored
+func getDeployFile(cmd *cobra.Command, args []string) (string, error) {
+	flags := cmd.Flags()
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	if err := flags.Parse(args); err != nil {
+		return "", err
+	}
+	return flags.GetString(DeployFile)
+}
+
+// RunFile deploys all applications in the appfile, they're saved into the env just like deployed by flags
+func (o *runOptions) RunFile(cmd *cobra.Command, file string, ioStreams cmdutil.IOStreams) error {
+	outputResources, err := cmd.Flags().GetBool(OutputResources)
+	if err != nil {
+		return err
+	}
+	if outputResources {
+		return fmt.Errorf("--%s can't be used along with --%s", OutputResources, DeployFile)
+	}
+	dryRun, err := cmd.Flags().GetBool(DryRun)
+	if err != nil {
+		return err
+	}
+	staging, err := cmd.Flags().GetBool(Staging)
+	if err != nil {
+		return err
+	}
+	in := ioStreams.In
+	if file != "-" {
+		f, err := os.Open(filepath.Clean(file))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	apps, err := loadAppsFromReader(in)
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		_, output, err := getRenderOptions(cmd.Flags())
+		if err != nil {
+			return err
+		}
+		var manifests []*unstructured.Unstructured
+		for _, app := range apps {
+			objs, err := app.RenderManifests(o.Env, ioStreams)
+			if err != nil {
+				return err
+			}
+			manifests = append(manifests, objs...)
+		}
+		return printResources(ioStreams, manifests, output)
+	}
+	for _, app := range apps {
+		if err := app.Save(o.Env.Name); err != nil {
+			return err
+		}
+		msg, err := oam.BaseRun(context.Background(), staging, app, o.KubeClient, o.Env, ioStreams)
+		if err != nil {
+			return err
+		}
+		o.Info(msg)
+	}
+	return nil
+}
+
+// loadAppsFromReader loads applications from YAML documents or JSON of appfiles
+func loadAppsFromReader(in io.Reader) ([]*application.Application, error) {
+	reader := k8syaml.NewYAMLReader(bufio.NewReader(in))
+	var apps []*application.Application
+	names := make(map[string]bool)
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		// the reader keeps the leading separator in a document, which is empty if there's nothing else
+		if isEmptyDocument(doc) {
+			continue
+		}
+		app, err := application.LoadFromBytes(doc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid appfile document %d: %v", len(apps)+1, err)
+		}
+		if names[app.Name] {
+			return nil, fmt.Errorf("application %s is defined more than once", app.Name)
+		}
+		names[app.Name] = true
+		apps = append(apps, app)
+	}
+	if len(apps) == 0 {
+		return nil, errors.New("no application found in the appfile")
+	}
+	return apps, nil
+}
+
+// isEmptyDocument checks whether a YAML document holds nothing but separators and blank lines
+func isEmptyDocument(doc []byte) bool {
+	for _, line := range bytes.Split(doc, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) != 0 && !bytes.Equal(line, []byte("---")) {
+			return false
+		}
+	}
+	return true
+}
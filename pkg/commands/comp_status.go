@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/api/types"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/server/apis"
+)
+
+// ServiceStatus is the schema of a service printed by `vela svc status -o json|yaml`, it holds the status and
+// replicas of the workload and the health of each trait
+type ServiceStatus struct {
+	App  string `json:"app"`
+	Type string `json:"type"`
+	apis.ServiceHealth
+}
+
+// NewCompStatusCommand shows the status of a service
+func NewCompStatusCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
+	ctx := context.Background()
+	cmd := &cobra.Command{
+		Use:                   "status SERVICE",
+		DisableFlagsInUseLine: true,
+		Short:                 "Show status of a service",
+		Long:                  "Show status of a service, including the workload and traits of it.",
+		Example:               "vela svc status frontend -o json",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svcName, err := GetWorkloadNameFromArgs(args)
+			if err != nil {
+				return err
+			}
+			output, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return err
+			}
+			if output != "" && output != "json" && output != "yaml" {
+				return fmt.Errorf("unsupported output format %s, only json and yaml are supported", output)
+			}
+			appName, err := cmd.Flags().GetString(App)
+			if err != nil {
+				return err
+			}
+			env, err := GetEnv(cmd)
+			if err != nil {
+				return err
+			}
+			newClient, err := client.New(c.Config, client.Options{Scheme: c.Schema})
+			if err != nil {
+				return err
+			}
+			if output == "" {
+				return printComponentStatus(ctx, newClient, ioStreams, svcName, appName, env)
+			}
+			status, err := getServiceStatus(ctx, newClient, svcName, appName, env)
+			if err != nil {
+				return err
+			}
+			return printServiceStatus(ioStreams, status, output)
+		},
+		Annotations: map[string]string{
+			types.TagCommandType: types.TypeApp,
+		},
+	}
+	cmd.Flags().StringP("output", "o", "", "output format of the status, support json and yaml")
+	cmd.SetOut(ioStreams.Out)
+	return cmd
+}
+
+func getServiceStatus(ctx context.Context, c client.Client, svcName, appName string, env *types.EnvMeta) (ServiceStatus, error) {
+	app, appConfig, err := getApp(ctx, c, svcName, appName, env)
+	if err != nil {
+		return ServiceStatus{}, err
+	}
+	svc, ok := app.Services[svcName]
+	if !ok {
+		return ServiceStatus{}, fmt.Errorf(ErrServiceNotFound, svcName)
+	}
+	health, err := oam.GetServicesHealth(ctx, c, appConfig, []string{svcName})
+	if err != nil {
+		return ServiceStatus{}, err
+	}
+	return ServiceStatus{App: app.Name, Type: svc.GetType(), ServiceHealth: health[0]}, nil
+}
+
+func printServiceStatus(ioStreams cmdutil.IOStreams, status ServiceStatus, output string) error {
+	var b []byte
+	var err error
+	if output == "json" {
+		b, err = json.MarshalIndent(status, "", "  ")
+	} else {
+		b, err = yaml.Marshal(status)
+	}
+	if err != nil {
+		return err
+	}
+	ioStreams.Info(string(b))
+	return nil
+}
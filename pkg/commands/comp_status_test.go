@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+	"github.com/oam-dev/kubevela/pkg/server/apis"
+)
+
+func TestPrintServiceStatus(t *testing.T) {
+	status := ServiceStatus{
+		App:  "myapp",
+		Type: "webservice",
+		ServiceHealth: apis.ServiceHealth{
+			Service: "frontend",
+			Health:  "Unhealthy",
+			Reason:  "Deployment frontend: 1/2 replicas ready",
+			Workload: &apis.ResourceHealth{
+				Kind:          "Deployment",
+				Name:          "frontend",
+				Replicas:      pointer.Int64Ptr(2),
+				ReadyReplicas: pointer.Int64Ptr(1),
+				Reasons:       []string{"1/2 replicas ready"},
+			},
+			Traits: []apis.ResourceHealth{{Kind: "Route", Name: "frontend-route", Ready: true}},
+		},
+	}
+	var b bytes.Buffer
+	ioStreams := cmdutil.IOStreams{Out: &b}
+	assert.NoError(t, printServiceStatus(ioStreams, status, "json"))
+	assert.Equal(t, `{
+  "app": "myapp",
+  "type": "webservice",
+  "service": "frontend",
+  "health": "Unhealthy",
+  "reason": "Deployment frontend: 1/2 replicas ready",
+  "workload": {
+    "kind": "Deployment",
+    "name": "frontend",
+    "ready": false,
+    "replicas": 2,
+    "readyReplicas": 1,
+    "reasons": [
+      "1/2 replicas ready"
+    ]
+  },
+  "traits": [
+    {
+      "kind": "Route",
+      "name": "frontend-route",
+      "ready": true
+    }
+  ]
+}
+`, b.String())
+}
@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"cuelang.org/go/cue"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/oam-dev/kubevela/api/types"
+)
+
+func TestParseKeyValues(t *testing.T) {
+	cases := map[string]struct {
+		kvs     []string
+		isLabel bool
+		expect  map[string]string
+		wantErr bool
+	}{
+		"valid labels": {
+			kvs:     []string{"app=web", "oam.dev/tier=frontend"},
+			isLabel: true,
+			expect:  map[string]string{"app": "web", "oam.dev/tier": "frontend"},
+		},
+		"value contains equal sign": {
+			kvs:    []string{"note=a=b"},
+			expect: map[string]string{"note": "a=b"},
+		},
+		"missing equal sign": {
+			kvs:     []string{"app"},
+			wantErr: true,
+		},
+		"invalid key": {
+			kvs:     []string{"-app=web"},
+			wantErr: true,
+		},
+		"invalid label value": {
+			kvs:     []string{"app=hello world"},
+			isLabel: true,
+			wantErr: true,
+		},
+		"annotation value is not restricted": {
+			kvs:    []string{"description=hello world"},
+			expect: map[string]string{"description": "hello world"},
+		},
+	}
+	for name, c := range cases {
+		got, err := parseKeyValues(c.kvs, c.isLabel)
+		if c.wantErr {
+			assert.Error(t, err, name)
+			continue
+		}
+		assert.NoError(t, err, name)
+		assert.Equal(t, c.expect, got, name)
+	}
+}
+
+func TestLoadAppsFromReader(t *testing.T) {
+	cases := map[string]struct {
+		content string
+		expect  []string
+		wantErr string
+	}{
+		"multiple documents": {
+			content: `name: frontend
+services:
+  web:
+    image: nginx
+---
+name: backend
+services:
+  api:
+    image: busybox
+`,
+			expect: []string{"frontend", "backend"},
+		},
+		"json": {
+			content: `{"name": "frontend", "services": {"web": {"image": "nginx"}}}`,
+			expect:  []string{"frontend"},
+		},
+		"empty documents are skipped": {
+			content: "---\nname: frontend\nservices:\n  web:\n    image: nginx\n---\n",
+			expect:  []string{"frontend"},
+		},
+		"duplicated applications": {
+			content: "name: frontend\nservices:\n  web:\n    image: nginx\n---\nname: frontend\nservices:\n  api:\n    image: busybox\n",
+			wantErr: "application frontend is defined more than once",
+		},
+		"invalid application": {
+			content: "name: frontend\n",
+			wantErr: "invalid appfile document 1",
+		},
+		"no application": {
+			content: "---\n",
+			wantErr: "no application found",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			apps, err := loadAppsFromReader(strings.NewReader(tc.content))
+			if tc.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			var names []string
+			for _, app := range apps {
+				names = append(names, app.Name)
+			}
+			assert.Equal(t, tc.expect, names)
+		})
+	}
+}
+
+func TestApplyEnvSettings(t *testing.T) {
+	params := []types.Parameter{
+		{Name: "image", Type: cue.StringKind, Default: ""},
+		{Name: "port", Alias: "p", Type: cue.IntKind, Default: int64(80)},
+		{Name: "cpu", Type: cue.StringKind, Default: ""},
+	}
+	newFlags := func(args ...string) *pflag.FlagSet {
+		flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		for _, v := range params {
+			types.SetFlagBy(flags, v)
+		}
+		assert.NoError(t, flags.Parse(args))
+		return flags
+	}
+
+	// settings are applied to the parameters not specified, keyed by names or aliases
+	flags := newFlags("--cpu", "0.5")
+	settings := map[string]string{"image": "registry.example.com/app:v1", "port": "8080", "cpu": "1"}
+	assert.NoError(t, applyEnvSettings(flags, params, settings))
+	image, _ := flags.GetString("image")
+	assert.Equal(t, "registry.example.com/app:v1", image)
+	port, _ := flags.GetInt64("p")
+	assert.Equal(t, int64(8080), port)
+	cpu, _ := flags.GetString("cpu")
+	assert.Equal(t, "0.5", cpu)
+
+	// no settings
+	flags = newFlags()
+	assert.NoError(t, applyEnvSettings(flags, params, nil))
+	port, _ = flags.GetInt64("p")
+	assert.Equal(t, int64(80), port)
+
+	err := applyEnvSettings(newFlags(), params, map[string]string{"p": "eighty"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid default setting p=eighty of env")
+}
+
+func TestGetRenderOptions(t *testing.T) {
+	cases := map[string]struct {
+		args      []string
+		expRender bool
+		expOutput string
+		wantErr   string
+	}{
+		"apply": {
+			expOutput: "yaml",
+		},
+		"dry run in json": {
+			args:      []string{"--dry-run", "-o", "json"},
+			expRender: true,
+			expOutput: "json",
+		},
+		"output resources": {
+			args:      []string{"--output-resources"},
+			expRender: true,
+			expOutput: "yaml",
+		},
+		"exclusive flags": {
+			args:    []string{"--dry-run", "--output-resources"},
+			wantErr: "--output-resources can't be used along with --dry-run",
+		},
+		"unsupported output": {
+			args:    []string{"--dry-run", "-o", "table"},
+			wantErr: "unsupported output format table",
+		},
+	}
+	for name, c := range cases {
+		flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		flags.Bool(OutputResources, false, "")
+		flags.Bool(DryRun, false, "")
+		flags.StringP("output", "o", "yaml", "")
+		assert.NoError(t, flags.Parse(c.args), name)
+		render, output, err := getRenderOptions(flags)
+		if c.wantErr != "" {
+			assert.Error(t, err, name)
+			assert.Contains(t, err.Error(), c.wantErr, name)
+			continue
+		}
+		assert.NoError(t, err, name)
+		assert.Equal(t, c.expRender, render, name)
+		assert.Equal(t, c.expOutput, output, name)
+	}
+}
@@ -63,6 +63,8 @@ func NewDashboardCommand(c types.Args, ioStreams cmdutil.IOStreams, frontendSour
 	cmd.Flags().BoolVar(&o.development, "development", true, "Development mode.")
 	cmd.Flags().StringVar(&o.staticPath, "static", "", "specify local static file directory")
 	cmd.Flags().StringVar(&o.port, "port", util.DefaultDashboardPort, "specify port for dashboard")
+	cmd.Flags().StringVar(&o.logFormat, "log-format", util.LogFormatText, "format of the access log, support text and json")
+	cmd.Flags().DurationVar(&o.requestTimeout, "request-timeout", util.DefaultRequestTimeout, "timeout of handling a request, 0 means no timeout")
 	cmd.SetOut(ioStreams.Out)
 	return cmd
 }
@@ -74,6 +76,8 @@ type Options struct {
 	development    bool
 	staticPath     string
 	port           string
+	requestTimeout time.Duration
+	logFormat      string
 	frontendSource string
 }
 
@@ -148,12 +152,15 @@ func SetupAPIServer(c types.Args, cmd *cobra.Command, o Options) error {
 		}
 	}
 
+	if o.requestTimeout < 0 {
+		return fmt.Errorf("--request-timeout must be non-negative, got %s", o.requestTimeout)
+	}
 	if !strings.HasPrefix(o.port, ":") {
 		o.port = ":" + o.port
 	}
 
 	//Setup RESTful server
-	server, err := server.New(c, o.port, o.staticPath)
+	server, err := server.New(c, o.port, o.staticPath, o.requestTimeout, o.logFormat)
 	if err != nil {
 		return err
 	}
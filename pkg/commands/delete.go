@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"errors"
 
 	"github.com/oam-dev/kubevela/api/types"
@@ -47,7 +48,7 @@ func NewDeleteCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command
 		}
 		if svcname == "" {
 			ioStreams.Infof("Deleting Application \"%s\"\n", o.AppName)
-			info, err := o.DeleteApp()
+			info, err := o.DeleteApp(context.Background())
 			if err != nil {
 				if apierrors.IsNotFound(err) {
 					ioStreams.Info("Already deleted")
@@ -59,7 +60,7 @@ func NewDeleteCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command
 		} else {
 			ioStreams.Infof("Deleting Service %s from Application \"%s\"\n", svcname, o.AppName)
 			o.CompName = svcname
-			message, err := o.DeleteComponent(ioStreams)
+			message, err := o.DeleteComponent(context.Background(), ioStreams)
 			if err != nil {
 				return err
 			}
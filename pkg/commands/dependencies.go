@@ -0,0 +1,177 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/api/types"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+)
+
+// ComponentDependency is a data dependency between two components of an application
+type ComponentDependency struct {
+	From       string
+	To         string
+	DataOutput string
+}
+
+// DependencyGraph holds data dependencies of components in an application and the blocked ones
+type DependencyGraph struct {
+	Components   []string
+	Dependencies []ComponentDependency
+	// Blocked maps the blocked component to the reasons of unsatisfied dependencies
+	Blocked map[string][]string
+}
+
+func NewDependenciesCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
+	ctx := context.Background()
+	cmd := &cobra.Command{
+		Use:     "dependencies APP_NAME",
+		Short:   "Show dependencies between services of an application",
+		Long:    "Show data dependencies between services of an application declared by dataInputs and dataOutputs, and highlight services blocked by unsatisfied dependencies.",
+		Example: `vela dependencies APP_NAME -o dot | dot -Tpng > deps.png`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("must specify name for application")
+			}
+			appName := args[0]
+			output, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return err
+			}
+			if output != "" && output != "dot" {
+				return fmt.Errorf("unsupported output format %s, only dot is supported", output)
+			}
+			env, err := GetEnv(cmd)
+			if err != nil {
+				return err
+			}
+			newClient, err := client.New(c.Config, client.Options{Scheme: c.Schema})
+			if err != nil {
+				return err
+			}
+			_, appConfig, err := getApp(ctx, newClient, "", appName, env)
+			if err != nil {
+				return err
+			}
+			graph := buildDependencyGraph(appConfig)
+			if output == "dot" {
+				ioStreams.Info(graph.Dot(appName))
+				return nil
+			}
+			printDependencyGraph(ioStreams, graph)
+			return nil
+		},
+		Annotations: map[string]string{
+			types.TagCommandType: types.TypeApp,
+		},
+	}
+	cmd.Flags().StringP("output", "o", "", "output format of the dependency graph, only support dot")
+	cmd.SetOut(ioStreams.Out)
+	return cmd
+}
+
+// buildDependencyGraph links components by dataInputs and dataOutputs of them and their traits
+func buildDependencyGraph(appConfig *v1alpha2.ApplicationConfiguration) DependencyGraph {
+	graph := DependencyGraph{Blocked: make(map[string][]string)}
+	producers := make(map[string]string)
+	consumers := make(map[string][]string)
+	for _, comp := range appConfig.Spec.Components {
+		name := comp.ComponentName
+		graph.Components = append(graph.Components, name)
+		outputs, inputs := comp.DataOutputs, comp.DataInputs
+		for _, tr := range comp.Traits {
+			outputs = append(outputs, tr.DataOutputs...)
+			inputs = append(inputs, tr.DataInputs...)
+		}
+		for _, out := range outputs {
+			producers[out.Name] = name
+		}
+		for _, in := range inputs {
+			consumers[in.ValueFrom.DataOutputName] = append(consumers[in.ValueFrom.DataOutputName], name)
+		}
+	}
+	for output, names := range consumers {
+		for _, name := range names {
+			graph.Dependencies = append(graph.Dependencies, ComponentDependency{
+				From:       producers[output],
+				To:         name,
+				DataOutput: output,
+			})
+		}
+	}
+	sort.Slice(graph.Dependencies, func(i, j int) bool {
+		if graph.Dependencies[i].To != graph.Dependencies[j].To {
+			return graph.Dependencies[i].To < graph.Dependencies[j].To
+		}
+		return graph.Dependencies[i].DataOutput < graph.Dependencies[j].DataOutput
+	})
+
+	// map names of workloads and traits to components to find out blocked ones
+	owners := make(map[string]string)
+	for _, wl := range appConfig.Status.Workloads {
+		owners[wl.Reference.Name] = wl.ComponentName
+		for _, tr := range wl.Traits {
+			owners[tr.Reference.Name] = wl.ComponentName
+		}
+	}
+	for _, u := range appConfig.Status.Dependency.Unsatisfied {
+		name, ok := owners[u.To.Name]
+		if !ok {
+			name = u.To.Name
+		}
+		graph.Blocked[name] = append(graph.Blocked[name], u.Reason)
+	}
+	return graph
+}
+
+// Dot renders the dependency graph in Graphviz dot format, blocked components are colored red
+func (g DependencyGraph) Dot(appName string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", appName)
+	for _, name := range g.Components {
+		if reasons, ok := g.Blocked[name]; ok {
+			fmt.Fprintf(&b, "  %q [color=red, tooltip=%q];\n", name, strings.Join(reasons, "; "))
+			continue
+		}
+		fmt.Fprintf(&b, "  %q;\n", name)
+	}
+	for _, d := range g.Dependencies {
+		from := d.From
+		if from == "" {
+			from = "<unknown>"
+		}
+		fmt.Fprintf(&b, "  %q -> %q [label=%q];\n", from, d.To, d.DataOutput)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+func printDependencyGraph(ioStreams cmdutil.IOStreams, graph DependencyGraph) {
+	if len(graph.Dependencies) == 0 {
+		ioStreams.Info("No dependencies declared between services")
+		return
+	}
+	table := uitable.New()
+	table.MaxColWidth = 60
+	table.AddRow("SERVICE", "DEPENDS-ON", "DATA-OUTPUT", "STATUS")
+	for _, d := range graph.Dependencies {
+		status := "satisfied"
+		if reasons, ok := graph.Blocked[d.To]; ok {
+			status = "blocked: " + strings.Join(reasons, "; ")
+		}
+		from := d.From
+		if from == "" {
+			from = "<unknown>"
+		}
+		table.AddRow(d.To, from, d.DataOutput, status)
+	}
+	ioStreams.Info(table.String())
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"testing"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildDependencyGraph(t *testing.T) {
+	appConfig := &v1alpha2.ApplicationConfiguration{
+		Spec: v1alpha2.ApplicationConfigurationSpec{
+			Components: []v1alpha2.ApplicationConfigurationComponent{
+				{
+					ComponentName: "db",
+					DataOutputs:   []v1alpha2.DataOutput{{Name: "db-conn"}},
+				},
+				{
+					ComponentName: "web",
+					DataInputs:    []v1alpha2.DataInput{{ValueFrom: v1alpha2.DataInputValueFrom{DataOutputName: "db-conn"}}},
+				},
+			},
+		},
+		Status: v1alpha2.ApplicationConfigurationStatus{
+			Workloads: []v1alpha2.WorkloadStatus{
+				{ComponentName: "db", Reference: runtimev1alpha1.TypedReference{Name: "db"}},
+				{ComponentName: "web", Reference: runtimev1alpha1.TypedReference{Name: "web"}},
+			},
+			Dependency: v1alpha2.DependencyStatus{
+				Unsatisfied: []v1alpha2.UnstaifiedDependency{{
+					Reason: "status.ready not found",
+					To: v1alpha2.DependencyToObject{
+						TypedReference: runtimev1alpha1.TypedReference{Name: "web"},
+					},
+				}},
+			},
+		},
+	}
+
+	graph := buildDependencyGraph(appConfig)
+	assert.Equal(t, []string{"db", "web"}, graph.Components)
+	assert.Equal(t, []ComponentDependency{{From: "db", To: "web", DataOutput: "db-conn"}}, graph.Dependencies)
+	assert.Equal(t, map[string][]string{"web": {"status.ready not found"}}, graph.Blocked)
+	assert.Equal(t, `digraph "app" {
+  "db";
+  "web" [color=red, tooltip="status.ready not found"];
+  "db" -> "web" [label="db-conn"];
+}`, graph.Dot("app"))
+}
@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/ghodss/yaml"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/api/types"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+)
+
+func NewDiffCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
+	ctx := context.Background()
+	cmd := &cobra.Command{
+		Use:   "diff APP_NAME",
+		Short: "Diff the live application against a stored revision",
+		Long: "Diff specs of live services of an application against a stored revision in unified format, " +
+			"exit with code 1 if they differ.",
+		Example: `vela diff APP_NAME --revision 1`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errors.New("must specify name for application")
+			}
+			appName := args[0]
+			revision, err := cmd.Flags().GetInt64("revision")
+			if err != nil {
+				return err
+			}
+			if revision <= 0 {
+				return errors.New("must specify a positive revision by --revision")
+			}
+			svcName, err := cmd.Flags().GetString("svc")
+			if err != nil {
+				return err
+			}
+			env, err := GetEnv(cmd)
+			if err != nil {
+				return err
+			}
+			newClient, err := client.New(c.Config, client.Options{Scheme: c.Schema})
+			if err != nil {
+				return err
+			}
+			differ, err := diffAppWithRevision(ctx, newClient, ioStreams, appName, svcName, revision, env)
+			if err != nil {
+				return err
+			}
+			if differ {
+				os.Exit(1)
+			}
+			return nil
+		},
+		Annotations: map[string]string{
+			types.TagCommandType: types.TypeApp,
+		},
+	}
+	cmd.Flags().Int64("revision", 0, "the revision of services to diff against")
+	cmd.Flags().StringP("svc", "s", "", "only diff the specified service")
+	cmd.SetOut(ioStreams.Out)
+	return cmd
+}
+
+// diffAppWithRevision prints the unified diff between the live component and its revision for each service,
+// it returns true if any of them differ
+func diffAppWithRevision(ctx context.Context, c client.Client, ioStreams cmdutil.IOStreams, appName, svcName string,
+	revision int64, env *types.EnvMeta) (bool, error) {
+	_, appConfig, err := getApp(ctx, c, "", appName, env)
+	if err != nil {
+		return false, err
+	}
+	differ := false
+	for _, acComp := range appConfig.Spec.Components {
+		compName := acComp.ComponentName
+		if svcName != "" && compName != svcName {
+			continue
+		}
+		var live v1alpha2.Component
+		if err = c.Get(ctx, client.ObjectKey{Namespace: env.Namespace, Name: compName}, &live); err != nil {
+			return false, err
+		}
+		stored, err := getComponentRevision(ctx, c, env.Namespace, compName, revision)
+		if err != nil {
+			return false, err
+		}
+		diff, err := diffComponentSpec(stored, &live, fmt.Sprintf("%s (revision %d)", compName, revision),
+			fmt.Sprintf("%s (live)", compName))
+		if err != nil {
+			return false, err
+		}
+		if diff != "" {
+			differ = true
+			ioStreams.Info(diff)
+		}
+	}
+	return differ, nil
+}
+
+// getComponentRevision gets the component stored in the ControllerRevision of the revision
+func getComponentRevision(ctx context.Context, c client.Client, namespace, compName string, revision int64) (*v1alpha2.Component, error) {
+	var revisions appsv1.ControllerRevisionList
+	if err := c.List(ctx, &revisions, client.InNamespace(namespace),
+		client.MatchingLabels{types.LabelControllerRevisionComponent: compName}); err != nil {
+		return nil, err
+	}
+	for _, r := range revisions.Items {
+		if r.Revision != revision {
+			continue
+		}
+		var comp v1alpha2.Component
+		if err := json.Unmarshal(r.Data.Raw, &comp); err != nil {
+			return nil, err
+		}
+		return &comp, nil
+	}
+	return nil, fmt.Errorf("revision %d of service %s not found", revision, compName)
+}
+
+func diffComponentSpec(from, to *v1alpha2.Component, fromName, toName string) (string, error) {
+	fromData, err := yaml.Marshal(from.Spec)
+	if err != nil {
+		return "", err
+	}
+	toData, err := yaml.Marshal(to.Spec)
+	if err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(fromData)),
+		B:        difflib.SplitLines(string(toData)),
+		FromFile: fromName,
+		ToFile:   toName,
+		Context:  3,
+	})
+}
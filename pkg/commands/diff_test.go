@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core"
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/api/types"
+)
+
+func newDiffComponent(name, image string) v1alpha2.Component {
+	return v1alpha2.Component{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1alpha2.ComponentSpec{Workload: runtime.RawExtension{
+			Raw: []byte(`{"apiVersion":"apps/v1","kind":"Deployment","spec":{"image":"` + image + `"}}`)}},
+	}
+}
+
+func TestGetComponentRevision(t *testing.T) {
+	s := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(s))
+	assert.NoError(t, core.AddToScheme(s))
+	newRevision := func(revision int64, comp v1alpha2.Component) *appsv1.ControllerRevision {
+		data, err := json.Marshal(comp)
+		assert.NoError(t, err)
+		return &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-v%d", comp.Name, revision), Namespace: "default",
+				Labels: map[string]string{types.LabelControllerRevisionComponent: comp.Name}},
+			Revision: revision,
+			Data:     runtime.RawExtension{Raw: data},
+		}
+	}
+	c := fake.NewFakeClientWithScheme(s,
+		newRevision(1, newDiffComponent("frontend", "nginx:1.9")),
+		newRevision(2, newDiffComponent("frontend", "nginx:1.10")),
+		newRevision(1, newDiffComponent("backend", "redis:5")))
+
+	cases := map[string]struct {
+		compName string
+		revision int64
+		expImage string
+		expErr   string
+	}{
+		"stored revision": {
+			compName: "frontend",
+			revision: 1,
+			expImage: "nginx:1.9",
+		},
+		"latest revision": {
+			compName: "frontend",
+			revision: 2,
+			expImage: "nginx:1.10",
+		},
+		"revisions of other components are ignored": {
+			compName: "backend",
+			revision: 2,
+			expErr:   "revision 2 of service backend not found",
+		},
+		"component without revisions": {
+			compName: "worker",
+			revision: 1,
+			expErr:   "revision 1 of service worker not found",
+		},
+	}
+	for name, tc := range cases {
+		comp, err := getComponentRevision(context.Background(), c, "default", tc.compName, tc.revision)
+		if tc.expErr != "" {
+			assert.EqualError(t, err, tc.expErr, name)
+			continue
+		}
+		assert.NoError(t, err, name)
+		assert.Equal(t, tc.compName, comp.Name, name)
+		assert.Contains(t, string(comp.Spec.Workload.Raw), tc.expImage, name)
+	}
+}
+
+func TestDiffComponentSpec(t *testing.T) {
+	cases := map[string]struct {
+		from     v1alpha2.Component
+		to       v1alpha2.Component
+		expLines []string
+	}{
+		"identical specs": {
+			from: newDiffComponent("frontend", "nginx:1.9"),
+			to:   newDiffComponent("frontend", "nginx:1.9"),
+		},
+		"image changed": {
+			from: newDiffComponent("frontend", "nginx:1.9"),
+			to:   newDiffComponent("frontend", "nginx:1.10"),
+			expLines: []string{
+				"--- frontend (revision 1)",
+				"+++ frontend (live)",
+				"-    image: nginx:1.9",
+				"+    image: nginx:1.10",
+			},
+		},
+	}
+	for name, tc := range cases {
+		diff, err := diffComponentSpec(&tc.from, &tc.to, "frontend (revision 1)", "frontend (live)")
+		assert.NoError(t, err, name)
+		if len(tc.expLines) == 0 {
+			assert.Empty(t, diff, name)
+			continue
+		}
+		for _, line := range tc.expLines {
+			assert.Contains(t, diff, line, name)
+		}
+	}
+}
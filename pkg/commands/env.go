@@ -2,17 +2,25 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/oam-dev/kubevela/api/types"
+	"github.com/oam-dev/kubevela/pkg/application"
 	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
 	"github.com/oam-dev/kubevela/pkg/utils/env"
 	"github.com/oam-dev/kubevela/pkg/utils/system"
 
+	"github.com/ghodss/yaml"
 	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
 )
 
 func NewEnvCommand(c types.Args, ioStream cmdutil.IOStreams) *cobra.Command {
@@ -26,40 +34,58 @@ func NewEnvCommand(c types.Args, ioStream cmdutil.IOStreams) *cobra.Command {
 		},
 	}
 	cmd.SetOut(ioStream.Out)
-	cmd.AddCommand(NewEnvListCommand(ioStream), NewEnvInitCommand(c, ioStream), NewEnvSetCommand(ioStream), NewEnvDeleteCommand(ioStream))
+	cmd.AddCommand(NewEnvListCommand(ioStream), NewEnvInitCommand(c, ioStream), NewEnvSetCommand(ioStream), NewEnvDeleteCommand(ioStream),
+		NewEnvPruneCommand(c, ioStream), NewEnvRenameCommand(ioStream))
 	return cmd
 }
 
 func NewEnvListCommand(ioStream cmdutil.IOStreams) *cobra.Command {
+	var output string
+	var namespaceOnly bool
 	cmd := &cobra.Command{
 		Use:                   "ls",
 		Aliases:               []string{"list"},
 		DisableFlagsInUseLine: true,
 		Short:                 "List environments",
 		Long:                  "List all environments",
-		Example:               `vela env ls [env-name]`,
+		Example:               "vela env ls [env-name]\nkubectl get pods -n $(vela env ls --namespace-only)",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return ListEnvs(args, ioStream)
+			if namespaceOnly {
+				if output != "" {
+					return fmt.Errorf("--namespace-only can't be used along with --output")
+				}
+				return PrintEnvNamespace(args, ioStream)
+			}
+			return ListEnvs(args, output, ioStream)
 		},
 		Annotations: map[string]string{
 			types.TagCommandType: types.TypeStart,
 		},
 	}
 	cmd.SetOut(ioStream.Out)
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output format of environments, support json and yaml")
+	cmd.Flags().BoolVar(&namespaceOnly, "namespace-only", false, "only print the namespace of the current or the specified environment, without headers")
 	return cmd
 }
 
 func NewEnvInitCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
 	var envArgs types.EnvMeta
-	var syncCluster bool
+	var syncCluster, adopt bool
+	var sets []string
+	var setFile string
 	ctx := context.Background()
 	cmd := &cobra.Command{
 		Use:                   "init <envName>",
 		DisableFlagsInUseLine: true,
 		Short:                 "Create environments",
 		Long:                  "Create environment and set the currently using environment",
-		Example:               `vela env init test --namespace test --email my@email.com`,
+		Example:               "vela env init test --namespace test --email my@email.com\nvela env init test --set image=registry.example.com/team/app:v1",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			settings, err := getEnvSettings(setFile, sets)
+			if err != nil {
+				return err
+			}
+			envArgs.Settings = settings
 			newClient, err := client.New(c.Config, client.Options{Scheme: c.Schema})
 			if err != nil {
 				return err
@@ -69,7 +95,7 @@ func NewEnvInitCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command
 					return err
 				}
 			}
-			return CreateOrUpdateEnv(ctx, newClient, &envArgs, args, ioStreams)
+			return CreateOrUpdateEnv(ctx, newClient, &envArgs, args, adopt, ioStreams)
 		},
 		Annotations: map[string]string{
 			types.TagCommandType: types.TypeStart,
@@ -80,11 +106,15 @@ func NewEnvInitCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command
 	cmd.Flags().StringVar(&envArgs.Email, "email", "", "specify email for production TLS Certificate notification")
 	cmd.Flags().StringVar(&envArgs.Domain, "domain", "", "specify domain your applications")
 	cmd.Flags().BoolVarP(&syncCluster, "sync", "s", true, "synchronize capabilities from cluster into local")
+	cmd.Flags().BoolVar(&adopt, "adopt", false, "reuse the namespace without creating it if it already exists")
+	cmd.Flags().StringArrayVar(&sets, "set", nil, "set default settings of services deployed in the env in key=value format, can be repeated")
+	cmd.Flags().StringVar(&setFile, "set-file", "", "set default settings of services deployed in the env from a YAML file of key: value, overridden by --set")
 	return cmd
 }
 
 func NewEnvDeleteCommand(ioStreams cmdutil.IOStreams) *cobra.Command {
 	ctx := context.Background()
+	var force bool
 	cmd := &cobra.Command{
 		Use:                   "delete",
 		DisableFlagsInUseLine: true,
@@ -92,7 +122,47 @@ func NewEnvDeleteCommand(ioStreams cmdutil.IOStreams) *cobra.Command {
 		Long:                  "Delete environment",
 		Example:               `vela env delete test`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return DeleteEnv(ctx, args, ioStreams)
+			return DeleteEnv(ctx, args, force, ioStreams)
+		},
+		Annotations: map[string]string{
+			types.TagCommandType: types.TypeStart,
+		},
+	}
+	cmd.SetOut(ioStreams.Out)
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "delete the environment even if applications still live in it")
+	return cmd
+}
+
+func NewEnvPruneCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
+	ctx := context.Background()
+	var assumeYes bool
+	cmd := &cobra.Command{
+		Use:                   "prune",
+		DisableFlagsInUseLine: true,
+		Short:                 "Prune stale environments",
+		Long:                  "Remove local metadata of environments whose namespace or kubeconfig context no longer exists",
+		Example:               `vela env prune --yes`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return PruneEnvs(ctx, c, assumeYes, ioStreams)
+		},
+		Annotations: map[string]string{
+			types.TagCommandType: types.TypeStart,
+		},
+	}
+	cmd.SetOut(ioStreams.Out)
+	cmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "prune without confirmation, the current environment still needs to be confirmed")
+	return cmd
+}
+
+func NewEnvRenameCommand(ioStreams cmdutil.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "rename <old-name> <new-name>",
+		DisableFlagsInUseLine: true,
+		Short:                 "Rename an environment",
+		Long:                  "Rename an environment, it keeps the namespace and stays the current one if it was",
+		Example:               `vela env rename test staging`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RenameEnv(args, ioStreams)
 		},
 		Annotations: map[string]string{
 			types.TagCommandType: types.TypeStart,
@@ -121,10 +191,10 @@ func NewEnvSetCommand(ioStreams cmdutil.IOStreams) *cobra.Command {
 	return cmd
 }
 
-func ListEnvs(args []string, ioStreams cmdutil.IOStreams) error {
-	table := uitable.New()
-	table.MaxColWidth = 60
-	table.AddRow("NAME", "CURRENT", "NAMESPACE", "EMAIL", "DOMAIN")
+func ListEnvs(args []string, output string, ioStreams cmdutil.IOStreams) error {
+	if output != "" && output != "json" && output != "yaml" {
+		return fmt.Errorf("unsupported output format %s, only json and yaml are supported", output)
+	}
 	var envName = ""
 	if len(args) > 0 {
 		envName = args[0]
@@ -133,6 +203,12 @@ func ListEnvs(args []string, ioStreams cmdutil.IOStreams) error {
 	if err != nil {
 		return err
 	}
+	if output != "" {
+		return printEnvs(ioStreams, envList, output)
+	}
+	table := uitable.New()
+	table.MaxColWidth = 60
+	table.AddRow("NAME", "CURRENT", "NAMESPACE", "EMAIL", "DOMAIN")
 	for _, env := range envList {
 		table.AddRow(env.Name, env.Current, env.Namespace, env.Email, env.Domain)
 	}
@@ -140,11 +216,66 @@ func ListEnvs(args []string, ioStreams cmdutil.IOStreams) error {
 	return nil
 }
 
-func DeleteEnv(ctx context.Context, args []string, ioStreams cmdutil.IOStreams) error {
+// PrintEnvNamespace prints only the namespace of the env, which is the current env if it's not specified,
+// so that it could be fed into other commands in shell pipelines
+func PrintEnvNamespace(args []string, ioStreams cmdutil.IOStreams) error {
+	var envName string
+	if len(args) > 0 {
+		envName = args[0]
+	} else {
+		var err error
+		if envName, err = env.GetCurrentEnvName(); err != nil {
+			return err
+		}
+	}
+	envMeta, err := env.GetEnvByName(envName)
+	if err != nil {
+		return err
+	}
+	ioStreams.Info(envMeta.Namespace)
+	return nil
+}
+
+func printEnvs(ioStreams cmdutil.IOStreams, envList []*types.EnvMeta, output string) error {
+	if envList == nil {
+		envList = []*types.EnvMeta{}
+	}
+	var b []byte
+	var err error
+	if output == "json" {
+		b, err = json.MarshalIndent(envList, "", "  ")
+	} else {
+		b, err = yaml.Marshal(envList)
+	}
+	if err != nil {
+		return err
+	}
+	ioStreams.Info(string(b))
+	return nil
+}
+
+func RenameEnv(args []string, ioStreams cmdutil.IOStreams) error {
+	if len(args) != 2 {
+		return fmt.Errorf("you must specify the old and new environment name for 'vela env rename' command")
+	}
+	msg, err := env.RenameEnv(args[0], args[1])
+	if err != nil {
+		return err
+	}
+	ioStreams.Info(msg)
+	return nil
+}
+
+func DeleteEnv(ctx context.Context, args []string, force bool, ioStreams cmdutil.IOStreams) error {
 	if len(args) < 1 {
 		return fmt.Errorf("you must specify environment name for 'vela env delete' command")
 	}
 	for _, envName := range args {
+		if !force {
+			if err := checkEnvApps(envName); err != nil {
+				return err
+			}
+		}
 		msg, err := env.DeleteEnv(envName)
 		if err != nil {
 			return err
@@ -154,13 +285,129 @@ func DeleteEnv(ctx context.Context, args []string, ioStreams cmdutil.IOStreams)
 	return nil
 }
 
-func CreateOrUpdateEnv(ctx context.Context, c client.Client, envArgs *types.EnvMeta, args []string, ioStreams cmdutil.IOStreams) error {
+// checkEnvApps refuses deleting the env if applications still live in it, as they would be stranded
+func checkEnvApps(envName string) error {
+	if _, err := env.GetEnvByName(envName); err != nil {
+		// leave it to env.DeleteEnv, which reports the env doesn't exist
+		return nil
+	}
+	if curEnv, err := env.GetCurrentEnvName(); err == nil && curEnv == envName {
+		// the current env is never deleted
+		return nil
+	}
+	apps, err := application.List(envName)
+	if err != nil {
+		return err
+	}
+	if len(apps) == 0 {
+		return nil
+	}
+	appNames := make([]string, 0, len(apps))
+	for _, app := range apps {
+		appNames = append(appNames, app.Name)
+	}
+	return fmt.Errorf("applications %s still live in environment %s, delete them first or use --force",
+		strings.Join(appNames, ", "), envName)
+}
+
+// PruneEnvs removes environments whose namespace or context is gone after confirmation,
+// the current environment is never pruned without an explicit confirmation and the default one is never pruned
+func PruneEnvs(ctx context.Context, c types.Args, assumeYes bool, ioStreams cmdutil.IOStreams) error {
+	envList, err := env.ListEnvs("")
+	if err != nil {
+		return err
+	}
+	clients := make(map[string]client.Client)
+	var staleEnvs []*types.EnvMeta
+	table := uitable.New()
+	table.MaxColWidth = 60
+	table.AddRow("NAME", "CURRENT", "NAMESPACE", "REASON")
+	for _, e := range envList {
+		if e.Name == types.DefaultEnvName {
+			continue
+		}
+		reason, err := getEnvStaleReason(ctx, c, clients, e)
+		if err != nil {
+			ioStreams.Infof("Skip checking env %s: %v\n", e.Name, err)
+			continue
+		}
+		if reason == "" {
+			continue
+		}
+		staleEnvs = append(staleEnvs, e)
+		table.AddRow(e.Name, e.Current, e.Namespace, reason)
+	}
+	if len(staleEnvs) == 0 {
+		ioStreams.Info("No stale environments found")
+		return nil
+	}
+	ioStreams.Info(table.String())
+
+	for _, e := range staleEnvs {
+		var confirmed bool
+		switch {
+		case e.Current != "":
+			confirmed, err = cmdutil.AskToConfirm(fmt.Sprintf("%s is the current environment, switch to %s and prune it?",
+				e.Name, types.DefaultEnvName))
+		case assumeYes:
+			confirmed = true
+		default:
+			confirmed, err = cmdutil.AskToConfirm(fmt.Sprintf("Prune environment %s?", e.Name))
+		}
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			continue
+		}
+		if e.Current != "" {
+			if _, err := env.SetEnv(types.DefaultEnvName); err != nil {
+				return err
+			}
+		}
+		msg, err := env.DeleteEnv(e.Name)
+		if err != nil {
+			return err
+		}
+		ioStreams.Info(msg)
+	}
+	return nil
+}
+
+// getEnvStaleReason returns why the env is stale, or empty if its context and namespace still exist
+func getEnvStaleReason(ctx context.Context, c types.Args, clients map[string]client.Client, e *types.EnvMeta) (string, error) {
+	k8sClient, ok := clients[e.Context]
+	if !ok {
+		restConf := c.Config
+		if e.Context != "" {
+			var err error
+			if restConf, err = config.GetConfigWithContext(e.Context); err != nil {
+				return fmt.Sprintf("context %s not found", e.Context), nil
+			}
+		}
+		var err error
+		if k8sClient, err = client.New(restConf, client.Options{Scheme: c.Schema}); err != nil {
+			return "", err
+		}
+		clients[e.Context] = k8sClient
+	}
+	var ns corev1.Namespace
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: e.Namespace}, &ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf("namespace %s not found", e.Namespace), nil
+		}
+		return "", err
+	}
+	return "", nil
+}
+
+func CreateOrUpdateEnv(ctx context.Context, c client.Client, envArgs *types.EnvMeta, args []string, adopt bool, ioStreams cmdutil.IOStreams) error {
 	if len(args) < 1 {
 		return fmt.Errorf("you must specify environment name for 'vela env init' command")
 	}
 	envName := args[0]
 	envArgs.Name = envName
-	msg, err := env.CreateOrUpdateEnv(ctx, c, envName, envArgs)
+	msg, err := env.CreateOrUpdateEnv(ctx, c, envName, envArgs, adopt)
 	if err != nil {
 		return err
 	}
@@ -168,6 +415,39 @@ func CreateOrUpdateEnv(ctx context.Context, c client.Client, envArgs *types.EnvM
 	return nil
 }
 
+// getEnvSettings reads the default settings from the YAML file and overrides them by key=value pairs
+func getEnvSettings(setFile string, sets []string) (map[string]string, error) {
+	settings := make(map[string]string)
+	if setFile != "" {
+		data, err := ioutil.ReadFile(setFile)
+		if err != nil {
+			return nil, err
+		}
+		var values map[string]interface{}
+		if err := yaml.Unmarshal(data, &values); err != nil {
+			return nil, fmt.Errorf("parse settings from %s err %v", setFile, err)
+		}
+		for k, v := range values {
+			switch v.(type) {
+			case map[string]interface{}, []interface{}:
+				return nil, fmt.Errorf("setting %s in %s should be a plain value", k, setFile)
+			}
+			settings[k] = fmt.Sprint(v)
+		}
+	}
+	values, err := parseKeyValues(sets, false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid setting: %v", err)
+	}
+	for k, v := range values {
+		settings[k] = v
+	}
+	if len(settings) == 0 {
+		return nil, nil
+	}
+	return settings, nil
+}
+
 func SetEnv(args []string, ioStreams cmdutil.IOStreams) error {
 	if len(args) < 1 {
 		return fmt.Errorf("you must specify environment name for vela env command")
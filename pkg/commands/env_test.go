@@ -3,11 +3,18 @@ package commands
 import (
 	"bytes"
 	"context"
+	"errors"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/crossplane/crossplane-runtime/pkg/test"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/oam-dev/kubevela/pkg/utils/env"
 
@@ -50,7 +57,7 @@ func TestENV(t *testing.T) {
 	}
 	client := test.NewMockClient()
 	// Create env1
-	err = CreateOrUpdateEnv(ctx, client, exp, []string{"env1"}, ioStream)
+	err = CreateOrUpdateEnv(ctx, client, exp, []string{"env1"}, false, ioStream)
 	assert.NoError(t, err)
 
 	// check and compare create env success
@@ -64,17 +71,73 @@ func TestENV(t *testing.T) {
 	// List all env
 	var b bytes.Buffer
 	ioStream.Out = &b
-	err = ListEnvs([]string{}, ioStream)
+	err = ListEnvs([]string{}, "", ioStream)
 	assert.NoError(t, err)
 	assert.Equal(t, "NAME   \tCURRENT\tNAMESPACE\tEMAIL\tDOMAIN\ndefault\t       \tdefault  \t     \t      \nenv1   \t*      \ttest1    \t     \t      \n", b.String())
 	b.Reset()
-	err = ListEnvs([]string{"env1"}, ioStream)
+	err = ListEnvs([]string{"env1"}, "", ioStream)
 	assert.NoError(t, err)
-	assert.Equal(t, "NAME\tCURRENT\tNAMESPACE\tEMAIL\tDOMAIN\nenv1\t       \ttest1    \t     \t      \n", b.String())
+	assert.Equal(t, "NAME\tCURRENT\tNAMESPACE\tEMAIL\tDOMAIN\nenv1\t*      \ttest1    \t     \t      \n", b.String())
+	b.Reset()
+	err = ListEnvs([]string{}, "json", ioStream)
+	assert.NoError(t, err)
+	assert.Equal(t, `[
+  {
+    "name": "default",
+    "namespace": "default",
+    "issuer": ""
+  },
+  {
+    "name": "env1",
+    "namespace": "test1",
+    "issuer": "",
+    "current": "*"
+  }
+]
+`, b.String())
+	b.Reset()
+	err = ListEnvs([]string{"env1"}, "yaml", ioStream)
+	assert.NoError(t, err)
+	assert.Equal(t, "- current: '*'\n  issuer: \"\"\n  name: env1\n  namespace: test1\n\n", b.String())
+	b.Reset()
+	err = ListEnvs([]string{}, "table", ioStream)
+	assert.Error(t, err)
+
+	// print only the namespace of the current env or the specified env
+	b.Reset()
+	assert.NoError(t, PrintEnvNamespace(nil, ioStream))
+	assert.Equal(t, "test1\n", b.String())
+	b.Reset()
+	assert.NoError(t, PrintEnvNamespace([]string{"default"}, ioStream))
+	assert.Equal(t, "default\n", b.String())
+	assert.Error(t, PrintEnvNamespace([]string{"not-exist"}, ioStream))
 	ioStream.Out = os.Stdout
 
-	// can not delete current env
-	err = DeleteEnv(ctx, []string{"env1"}, ioStream)
+	// rename current env, it's still the current one
+	err = RenameEnv([]string{"env1", "env2"}, ioStream)
+	assert.NoError(t, err)
+	curEnvName, err = env.GetCurrentEnvName()
+	assert.NoError(t, err)
+	assert.Equal(t, "env2", curEnvName)
+	gotEnv, err = GetEnv(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &types.EnvMeta{Namespace: "test1", Name: "env2"}, gotEnv)
+	_, err = env.GetEnvByName("env1")
+	assert.Error(t, err)
+
+	// can not rename to an existing env, or rename the default env
+	err = RenameEnv([]string{"env2", "default"}, ioStream)
+	assert.Error(t, err)
+	err = RenameEnv([]string{"default", "env3"}, ioStream)
+	assert.Error(t, err)
+
+	err = RenameEnv([]string{"env2", "env1"}, ioStream)
+	assert.NoError(t, err)
+
+	// can not delete current env, even with force
+	err = DeleteEnv(ctx, []string{"env1"}, false, ioStream)
+	assert.Error(t, err)
+	err = DeleteEnv(ctx, []string{"env1"}, true, ioStream)
 	assert.Error(t, err)
 
 	// set as default env
@@ -89,8 +152,17 @@ func TestENV(t *testing.T) {
 		Name:      "default",
 	}, gotEnv)
 
+	// can not delete env with applications living in it without force
+	appDir := filepath.Join(env.GetEnvDirByName("env1"), "applications")
+	assert.NoError(t, os.MkdirAll(appDir, 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(appDir, "myapp.yaml"),
+		[]byte("name: myapp\nservices:\n  frontend:\n    image: nginx\n"), 0644))
+	err = DeleteEnv(ctx, []string{"env1"}, false, ioStream)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "applications myapp still live in environment env1")
+
 	// delete env
-	err = DeleteEnv(ctx, []string{"env1"}, ioStream)
+	err = DeleteEnv(ctx, []string{"env1"}, true, ioStream)
 	assert.NoError(t, err)
 
 	// can not set as a non-exist env
@@ -101,3 +173,107 @@ func TestENV(t *testing.T) {
 	err = SetEnv([]string{"default"}, ioStream)
 	assert.NoError(t, err)
 }
+
+func TestEnvInitAdopt(t *testing.T) {
+	ctx := context.Background()
+	// later tests keep using the previous vela home
+	defer os.Setenv(system.VelaHomeEnv, os.Getenv(system.VelaHomeEnv))
+	assert.NoError(t, os.Setenv(system.VelaHomeEnv, ".test_vela_adopt"))
+	home, err := system.GetVelaHomeDir()
+	assert.NoError(t, err)
+	defer os.RemoveAll(home)
+	assert.NoError(t, system.InitDefaultEnv())
+
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "team-a", nil)
+	created := 0
+	c := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+		MockCreate: func(_ context.Context, _ runtime.Object, _ ...client.CreateOption) error {
+			created++
+			return forbidden
+		},
+	}
+	var b bytes.Buffer
+	ioStream := cmdutil.IOStreams{In: os.Stdin, Out: &b, ErrOut: &b}
+
+	// the namespace can't be created without permission
+	err = CreateOrUpdateEnv(ctx, c, &types.EnvMeta{Namespace: "team-a"}, []string{"team-a"}, false, ioStream)
+	assert.Equal(t, forbidden, err)
+	assert.Equal(t, 1, created)
+
+	// the existing namespace is adopted without creating it
+	err = CreateOrUpdateEnv(ctx, c, &types.EnvMeta{Namespace: "team-a"}, []string{"team-a"}, true, ioStream)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, created)
+	assert.Contains(t, b.String(), "Namespace team-a already exists, reused it without creating")
+	gotEnv, err := env.GetEnvByName("team-a")
+	assert.NoError(t, err)
+	assert.Equal(t, "team-a", gotEnv.Namespace)
+
+	// the namespace is still created if it doesn't exist
+	c.MockGet = test.NewMockGetFn(apierrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, "team-b"))
+	c.MockCreate = test.NewMockCreateFn(nil)
+	b.Reset()
+	err = CreateOrUpdateEnv(ctx, c, &types.EnvMeta{Namespace: "team-b"}, []string{"team-b"}, true, ioStream)
+	assert.NoError(t, err)
+	assert.NotContains(t, b.String(), "reused")
+}
+
+func TestGetEnvSettings(t *testing.T) {
+	settings, err := getEnvSettings("", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, settings)
+
+	dir, err := ioutil.TempDir("", "env-settings")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	setFile := filepath.Join(dir, "settings.yaml")
+	assert.NoError(t, ioutil.WriteFile(setFile, []byte("image: registry.example.com/app:v1\nport: 8080\n"), 0644))
+
+	settings, err = getEnvSettings(setFile, []string{"port=9090", "cpu=0.5"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"image": "registry.example.com/app:v1", "port": "9090", "cpu": "0.5"}, settings)
+
+	assert.NoError(t, ioutil.WriteFile(setFile, []byte("env:\n  - name: FOO\n"), 0644))
+	_, err = getEnvSettings(setFile, nil)
+	assert.Error(t, err)
+
+	_, err = getEnvSettings("", []string{"image"})
+	assert.Error(t, err)
+}
+
+func TestGetEnvStaleReason(t *testing.T) {
+	getErr := errors.New("connection refused")
+	cases := map[string]struct {
+		env       *types.EnvMeta
+		getErr    error
+		expReason string
+		expErr    error
+	}{
+		"namespace exists": {
+			env: &types.EnvMeta{Name: "prod", Namespace: "prod"},
+		},
+		"namespace not found": {
+			env:       &types.EnvMeta{Name: "prod", Namespace: "prod"},
+			getErr:    apierrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, "prod"),
+			expReason: "namespace prod not found",
+		},
+		"namespace of the context not found": {
+			env:       &types.EnvMeta{Name: "staging", Namespace: "staging", Context: "staging-cluster"},
+			getErr:    apierrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, "staging"),
+			expReason: "namespace staging not found",
+		},
+		"cluster unreachable": {
+			env:    &types.EnvMeta{Name: "prod", Namespace: "prod"},
+			getErr: getErr,
+			expErr: getErr,
+		},
+	}
+	for name, c := range cases {
+		// clients are cached by context, so the cluster of the env is faked by the cached client
+		clients := map[string]client.Client{c.env.Context: &test.MockClient{MockGet: test.NewMockGetFn(c.getErr)}}
+		reason, err := getEnvStaleReason(context.Background(), types.Args{}, clients, c.env)
+		assert.Equal(t, c.expErr, err, name)
+		assert.Equal(t, c.expReason, reason, name)
+	}
+}
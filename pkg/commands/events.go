@@ -0,0 +1,237 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam/discoverymapper"
+	oamutil "github.com/crossplane/oam-kubernetes-runtime/pkg/oam/util"
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/api/types"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+	oam2 "github.com/oam-dev/kubevela/pkg/oam"
+)
+
+const watchEventsInterval = 2 * time.Second
+
+// podOwnerKinds are kinds of resources between workloads and pods, events of them are included as well
+var podOwnerKinds = []schema.GroupVersionKind{
+	{Group: "apps", Version: "v1", Kind: "ReplicaSet"},
+	{Group: "", Version: "v1", Kind: "Pod"},
+}
+
+func NewEventsCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
+	ctx := context.Background()
+	cmd := &cobra.Command{
+		Use:     "events APP_NAME",
+		Short:   "Show events of an application",
+		Long:    "Show events of an application, including events of workloads, traits, their child resources and pods.",
+		Example: `vela events APP_NAME --component frontend`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("must specify name for application")
+			}
+			appName := args[0]
+			compName, err := cmd.Flags().GetString("component")
+			if err != nil {
+				return err
+			}
+			output, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return err
+			}
+			if output != "" && output != "json" {
+				return fmt.Errorf("unsupported output format %s, only json is supported", output)
+			}
+			watch, err := cmd.Flags().GetBool("watch")
+			if err != nil {
+				return err
+			}
+			env, err := GetEnv(cmd)
+			if err != nil {
+				return err
+			}
+			newClient, err := client.New(c.Config, client.Options{Scheme: c.Schema})
+			if err != nil {
+				return err
+			}
+			dm, err := discoverymapper.New(c.Config)
+			if err != nil {
+				return err
+			}
+			return printAppEvents(ctx, newClient, dm, ioStreams, appName, compName, env, output, watch)
+		},
+		Annotations: map[string]string{
+			types.TagCommandType: types.TypeApp,
+		},
+	}
+	cmd.Flags().String("component", "", "only show events of resources of the specified service")
+	cmd.Flags().StringP("output", "o", "", "output format of events, only support json")
+	cmd.Flags().BoolP("watch", "w", false, "keep watching new events after listing them")
+	cmd.SetOut(ioStreams.Out)
+	return cmd
+}
+
+func printAppEvents(ctx context.Context, c client.Client, dm discoverymapper.DiscoveryMapper, ioStreams cmdutil.IOStreams,
+	appName, compName string, env *types.EnvMeta, output string, watch bool) error {
+	printed := make(map[string]bool)
+	for first := true; ; first = false {
+		events, err := getAppEvents(ctx, c, dm, appName, compName, env)
+		if err != nil {
+			return err
+		}
+		newEvents := make([]corev1.Event, 0)
+		for _, e := range events {
+			// an event is updated with its count increased when it occurs again
+			key := fmt.Sprintf("%s/%d", e.UID, e.Count)
+			if printed[key] {
+				continue
+			}
+			printed[key] = true
+			newEvents = append(newEvents, e)
+		}
+		if first || len(newEvents) > 0 {
+			if err := printEvents(ioStreams, newEvents, output, !first); err != nil {
+				return err
+			}
+		}
+		if !watch {
+			return nil
+		}
+		time.Sleep(watchEventsInterval)
+	}
+}
+
+// getAppEvents lists events of resources of the application, or only those of the component if it's specified
+func getAppEvents(ctx context.Context, c client.Client, dm discoverymapper.DiscoveryMapper, appName, compName string,
+	env *types.EnvMeta) ([]corev1.Event, error) {
+	_, appConfig, err := getApp(ctx, c, "", appName, env)
+	if err != nil {
+		return nil, err
+	}
+	uids, err := getComponentResourceUIDs(ctx, c, dm, appConfig, compName)
+	if err != nil {
+		return nil, err
+	}
+	if compName == "" {
+		uids[appConfig.UID] = true
+	}
+
+	var eventList corev1.EventList
+	if err := c.List(ctx, &eventList, client.InNamespace(appConfig.Namespace)); err != nil {
+		return nil, err
+	}
+	var events []corev1.Event
+	for _, e := range eventList.Items {
+		if uids[e.InvolvedObject.UID] {
+			events = append(events, e)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).Before(eventTime(events[j]))
+	})
+	return events, nil
+}
+
+// getComponentResourceUIDs collects UIDs of workloads, traits, child resources and pods of the component,
+// or those of all components if it's not specified
+func getComponentResourceUIDs(ctx context.Context, c client.Client, dm discoverymapper.DiscoveryMapper,
+	appConfig *v1alpha2.ApplicationConfiguration, compName string) (map[ktypes.UID]bool, error) {
+	uids := make(map[ktypes.UID]bool)
+	found := false
+	for _, wl := range appConfig.Status.Workloads {
+		if compName != "" && wl.ComponentName != compName {
+			continue
+		}
+		found = true
+		workload, err := oam2.GetUnstructured(ctx, c, appConfig.Namespace, wl.Reference)
+		if err != nil {
+			return nil, err
+		}
+		uids[workload.GetUID()] = true
+		children, err := oamutil.FetchWorkloadChildResources(ctx, ctrl.Log.WithName("events"), c, dm, workload)
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range children {
+			uids[child.GetUID()] = true
+		}
+		for _, tr := range wl.Traits {
+			trait, err := oam2.GetUnstructured(ctx, c, appConfig.Namespace, tr.Reference)
+			if err != nil {
+				return nil, err
+			}
+			uids[trait.GetUID()] = true
+		}
+	}
+	if compName != "" && !found {
+		return nil, fmt.Errorf(ErrServiceNotFound, compName)
+	}
+
+	// pods are owned by the child resources directly or through replicaSets
+	for _, gvk := range podOwnerKinds {
+		list := unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk)
+		if err := c.List(ctx, &list, client.InNamespace(appConfig.Namespace)); err != nil {
+			return nil, err
+		}
+		for _, u := range list.Items {
+			for _, owner := range u.GetOwnerReferences() {
+				if uids[owner.UID] {
+					uids[u.GetUID()] = true
+					break
+				}
+			}
+		}
+	}
+	return uids, nil
+}
+
+func eventTime(e corev1.Event) time.Time {
+	if !e.LastTimestamp.IsZero() {
+		return e.LastTimestamp.Time
+	}
+	if !e.EventTime.IsZero() {
+		return e.EventTime.Time
+	}
+	return e.CreationTimestamp.Time
+}
+
+func printEvents(ioStreams cmdutil.IOStreams, events []corev1.Event, output string, noHeader bool) error {
+	if output == "json" {
+		b, err := json.MarshalIndent(events, "", "  ")
+		if err != nil {
+			return err
+		}
+		ioStreams.Info(string(b))
+		return nil
+	}
+	if len(events) == 0 {
+		ioStreams.Info("No events found")
+		return nil
+	}
+	table := uitable.New()
+	table.MaxColWidth = 80
+	if !noHeader {
+		table.AddRow("LAST-SEEN", "TYPE", "REASON", "OBJECT", "MESSAGE")
+	}
+	for _, e := range events {
+		table.AddRow(duration.HumanDuration(time.Since(eventTime(e))), e.Type, e.Reason,
+			fmt.Sprintf("%s/%s", e.InvolvedObject.Kind, e.InvolvedObject.Name), e.Message)
+	}
+	ioStreams.Info(table.String())
+	return nil
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+)
+
+func TestEventTime(t *testing.T) {
+	created := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	occurred := created.Add(time.Minute)
+	lastSeen := created.Add(time.Hour)
+
+	cases := map[string]struct {
+		event   corev1.Event
+		expTime time.Time
+	}{
+		"last timestamp first": {
+			event: corev1.Event{
+				ObjectMeta:    metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+				EventTime:     metav1.NewMicroTime(occurred),
+				LastTimestamp: metav1.NewTime(lastSeen),
+			},
+			expTime: lastSeen,
+		},
+		"event time of events.k8s.io": {
+			event: corev1.Event{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+				EventTime:  metav1.NewMicroTime(occurred),
+			},
+			expTime: occurred,
+		},
+		"creation timestamp as fallback": {
+			event:   corev1.Event{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)}},
+			expTime: created,
+		},
+	}
+	for name, c := range cases {
+		assert.True(t, c.expTime.Equal(eventTime(c.event)), name)
+	}
+}
+
+func TestPrintEvents(t *testing.T) {
+	event := corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "web.1"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Deployment", Name: "web"},
+		Reason:         "ScalingReplicaSet",
+		Message:        "Scaled up replica set web-7d4b to 1",
+		Type:           corev1.EventTypeNormal,
+		LastTimestamp:  metav1.NewTime(time.Now()),
+	}
+
+	cases := map[string]struct {
+		events      []corev1.Event
+		output      string
+		noHeader    bool
+		expContains []string
+		expMissing  []string
+	}{
+		"no events": {
+			events:      []corev1.Event{},
+			expContains: []string{"No events found"},
+		},
+		"table with header": {
+			events:      []corev1.Event{event},
+			expContains: []string{"LAST-SEEN", "ScalingReplicaSet", "Deployment/web", event.Message},
+		},
+		"table without header when watching": {
+			events:      []corev1.Event{event},
+			noHeader:    true,
+			expContains: []string{"Deployment/web"},
+			expMissing:  []string{"LAST-SEEN"},
+		},
+		"json": {
+			events:      []corev1.Event{event},
+			output:      "json",
+			expContains: []string{`"reason": "ScalingReplicaSet"`},
+		},
+	}
+	for name, c := range cases {
+		var b bytes.Buffer
+		assert.NoError(t, printEvents(cmdutil.IOStreams{Out: &b}, c.events, c.output, c.noHeader), name)
+		for _, s := range c.expContains {
+			assert.Contains(t, b.String(), s, name)
+		}
+		for _, s := range c.expMissing {
+			assert.NotContains(t, b.String(), s, name)
+		}
+		if c.output == "json" {
+			var got []corev1.Event
+			assert.NoError(t, json.Unmarshal(b.Bytes(), &got), name)
+			assert.Len(t, got, len(c.events), name)
+		}
+	}
+}
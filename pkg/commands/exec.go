@@ -9,13 +9,13 @@ import (
 	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
 	"github.com/oam-dev/kubevela/api/types"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/oam-dev/kubevela/pkg/application"
-	"github.com/oam-dev/kubevela/pkg/commands/util"
 	velacmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
 	cmdexec "k8s.io/kubectl/pkg/cmd/exec"
 	k8scmdutil "k8s.io/kubectl/pkg/cmd/util"
@@ -33,6 +33,10 @@ type VelaExecOptions struct {
 	Args  []string
 	Stdin bool
 	TTY   bool
+	// Component is the service to execute command in, choose one interactively if not specified
+	Component string
+	// Pod is the pod of the service to execute command in, the first ready one is chosen if not specified
+	Pod string
 
 	context.Context
 	VelaC types.Args
@@ -90,6 +94,8 @@ func NewExecCommand(c types.Args, ioStreams velacmdutil.IOStreams) *cobra.Comman
 	}
 	cmd.Flags().BoolVarP(&o.Stdin, "stdin", "i", defaultStdin, "Pass stdin to the container")
 	cmd.Flags().BoolVarP(&o.TTY, "tty", "t", defaultTTY, "Stdin is a TTY")
+	cmd.Flags().StringVar(&o.Component, "component", "", "name of the service to execute command in, choose one interactively if not specified")
+	cmd.Flags().StringVar(&o.Pod, "pod", "", "name of the pod to execute command in, the first ready pod of the service is chosen if not specified")
 	cmd.Flags().Duration(podRunningTimeoutFlag, defaultPodExecTimeout,
 		"The length of time (like 5s, 2m, or 3h, higher than zero) to wait until at least one pod is running",
 	)
@@ -127,7 +133,7 @@ func (o *VelaExecOptions) Init(ctx context.Context, c *cobra.Command, argsIn []s
 }
 
 func (o *VelaExecOptions) Complete() error {
-	compName, err := util.AskToChooseOneService(o.App.GetComponents())
+	compName, err := chooseComponent(o.App, o.Component)
 	if err != nil {
 		return err
 	}
@@ -155,19 +161,56 @@ func (o *VelaExecOptions) getPodName(compName string) (string, error) {
 		}).String(),
 	})
 	if err != nil {
-		return "", nil
+		return "", err
 	}
 	if podList != nil && len(podList.Items) == 0 {
 		return "", fmt.Errorf("cannot get pods")
 	}
-	for _, p := range podList.Items {
+	if o.Pod != "" {
+		for _, p := range podList.Items {
+			if p.Name == o.Pod {
+				return p.Name, nil
+			}
+		}
+		return "", fmt.Errorf("pod %s not found in service %s", o.Pod, compName)
+	}
+	return choosePod(podList.Items, compName), nil
+}
+
+// choosePod prefers ready pods, and pods with name prefixed by the component name
+func choosePod(pods []corev1.Pod, compName string) string {
+	var candidate *corev1.Pod
+	for i, p := range pods {
+		if !isPodReady(p) {
+			continue
+		}
 		if strings.HasPrefix(p.Name, compName+"-") {
-			return p.Name, nil
+			return p.Name
+		}
+		if candidate == nil {
+			candidate = &pods[i]
+		}
+	}
+	if candidate != nil {
+		return candidate.Name
+	}
+	for _, p := range pods {
+		if strings.HasPrefix(p.Name, compName+"-") {
+			return p.Name
 		}
 	}
 	// if no pod with name matched prefix as component name
 	// just return the first one
-	return podList.Items[0].Name, nil
+	return pods[0].Name
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
 }
 
 func (o *VelaExecOptions) Run() error {
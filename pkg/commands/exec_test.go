@@ -57,4 +57,58 @@ func TestExecCommand(t *testing.T) {
 	o.App = fakeApp
 	err = o.Complete()
 	assert.NoError(t, err)
+
+	o.Pod = "notExistPod"
+	err = o.Complete()
+	assert.Error(t, err)
+}
+
+func TestChoosePod(t *testing.T) {
+	ready := corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}}
+	notReady := corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}}}
+	cases := map[string]struct {
+		pods   []corev1.Pod
+		expect string
+	}{
+		"ready pod is preferred": {
+			pods: []corev1.Pod{
+				{ObjectMeta: v1.ObjectMeta{Name: "web-1"}, Status: notReady},
+				{ObjectMeta: v1.ObjectMeta{Name: "web-2"}, Status: ready},
+			},
+			expect: "web-2",
+		},
+		"ready pod prefixed by the component name is preferred": {
+			pods: []corev1.Pod{
+				{ObjectMeta: v1.ObjectMeta{Name: "other"}, Status: ready},
+				{ObjectMeta: v1.ObjectMeta{Name: "web-1"}, Status: ready},
+			},
+			expect: "web-1",
+		},
+		"ready pod is preferred over name": {
+			pods: []corev1.Pod{
+				{ObjectMeta: v1.ObjectMeta{Name: "web-1"}, Status: notReady},
+				{ObjectMeta: v1.ObjectMeta{Name: "other"}, Status: ready},
+			},
+			expect: "other",
+		},
+		"no ready pod": {
+			pods: []corev1.Pod{
+				{ObjectMeta: v1.ObjectMeta{Name: "other"}},
+				{ObjectMeta: v1.ObjectMeta{Name: "web-1"}, Status: notReady},
+			},
+			expect: "web-1",
+		},
+		"fall back to the first pod": {
+			pods: []corev1.Pod{
+				{ObjectMeta: v1.ObjectMeta{Name: "other-1"}},
+				{ObjectMeta: v1.ObjectMeta{Name: "other-2"}},
+			},
+			expect: "other-1",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, choosePod(tc.pods, "web"))
+		})
+	}
 }
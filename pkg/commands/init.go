@@ -144,7 +144,7 @@ func (o *appInitOptions) CheckEnv() error {
 			return fmt.Errorf("read email err %v", err)
 		}
 	}
-	if _, err := env.CreateOrUpdateEnv(context.Background(), o.client, o.Env.Name, o.Env); err != nil {
+	if _, err := env.CreateOrUpdateEnv(context.Background(), o.client, o.Env.Name, o.Env, false); err != nil {
 		return err
 	}
 	return nil
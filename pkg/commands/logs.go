@@ -1,9 +1,12 @@
 package commands
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"regexp"
 	"text/template"
 	"time"
@@ -17,6 +20,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/wercker/stern/stern"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 )
@@ -52,14 +57,32 @@ func NewLogsCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
 		types.TagCommandType: types.TypeApp,
 	}
 	cmd.Flags().StringVarP(&largs.Output, "output", "o", "default", "output format for logs, support: [default, raw, json]")
+	cmd.Flags().StringVarP(&largs.Component, "component", "c", "", "name of the service to show logs of, choose one interactively if not specified")
+	cmd.Flags().BoolVarP(&largs.Follow, "follow", "f", false, "keep streaming logs of new and existing pods")
+	cmd.Flags().DurationVar(&largs.Since, "since", 48*time.Hour, "only show logs newer than a relative duration like 5s, 2m, or 3h")
+	cmd.Flags().Int64Var(&largs.Tail, "tail", -1, "lines of recent logs to show of each container, all logs are shown if it's negative")
 	return cmd
 }
 
 type Args struct {
-	Output string
-	Env    *types.EnvMeta
-	C      types.Args
-	App    *application.Application
+	Output    string
+	Env       *types.EnvMeta
+	C         types.Args
+	App       *application.Application
+	Component string
+	Follow    bool
+	Since     time.Duration
+	Tail      int64
+}
+
+// logColors are colors to tell logs of different pods apart when they're not streamed by stern
+var logColors = []*color.Color{
+	color.New(color.FgHiCyan),
+	color.New(color.FgHiGreen),
+	color.New(color.FgHiMagenta),
+	color.New(color.FgHiYellow),
+	color.New(color.FgHiBlue),
+	color.New(color.FgHiRed),
 }
 
 // Run refer to the implementation at https://github.com/oam-dev/stern/blob/master/stern/main.go
@@ -69,7 +92,7 @@ func (l *Args) Run(ctx context.Context, ioStreams cmdutil.IOStreams) error {
 	if err != nil {
 		return err
 	}
-	compName, err := util.AskToChooseOneService(l.App.GetComponents())
+	compName, err := chooseComponent(l.App, l.Component)
 	if err != nil {
 		return err
 	}
@@ -81,23 +104,6 @@ func (l *Args) Run(ctx context.Context, ioStreams cmdutil.IOStreams) error {
 	}
 	container := regexp.MustCompile(".*")
 	namespace := l.Env.Namespace
-	added, removed, err := stern.Watch(ctx, clientSet.CoreV1().Pods(namespace), pod, container, nil, stern.RUNNING, labelSelector)
-	if err != nil {
-		return err
-	}
-	tails := make(map[string]*stern.Tail)
-	logC := make(chan string, 1024)
-
-	go func() {
-		for {
-			select {
-			case str := <-logC:
-				ioStreams.Infonln(str)
-			case <-ctx.Done():
-				return
-			}
-		}
-	}()
 
 	var t string
 	switch l.Output {
@@ -129,21 +135,41 @@ func (l *Args) Run(ctx context.Context, ioStreams cmdutil.IOStreams) error {
 		return errors.Wrap(err, "unable to parse template")
 	}
 
+	if !l.Follow {
+		return l.printLogs(ctx, clientSet, namespace, pod, template, ioStreams)
+	}
+
+	added, removed, err := stern.Watch(ctx, clientSet.CoreV1().Pods(namespace), pod, container, nil, stern.RUNNING, labelSelector)
+	if err != nil {
+		return err
+	}
+	tails := make(map[string]*stern.Tail)
+	logC := make(chan string, 1024)
+
+	go func() {
+		for {
+			select {
+			case str := <-logC:
+				ioStreams.Infonln(str)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
 	go func() {
 		for p := range added {
 			id := p.GetID()
 			if tails[id] != nil {
 				continue
 			}
-			//48h
-			dur, _ := time.ParseDuration("48h")
 			tail := stern.NewTail(p.Namespace, p.Pod, p.Container, template, &stern.TailOptions{
 				Timestamps:   true,
-				SinceSeconds: int64(dur.Seconds()),
+				SinceSeconds: int64(l.Since.Seconds()),
 				Exclude:      nil,
 				Include:      nil,
 				Namespace:    false,
-				TailLines:    nil, //default for all logs
+				TailLines:    l.tailLines(),
 			})
 			tails[id] = tail
 
@@ -166,3 +192,86 @@ func (l *Args) Run(ctx context.Context, ioStreams cmdutil.IOStreams) error {
 
 	return nil
 }
+
+// chooseComponent returns the specified service of the application, or asks to choose one if it's not specified
+func chooseComponent(app *application.Application, compName string) (string, error) {
+	if compName == "" {
+		return util.AskToChooseOneService(app.GetComponents())
+	}
+	for _, comp := range app.GetComponents() {
+		if comp == compName {
+			return comp, nil
+		}
+	}
+	return "", fmt.Errorf(ErrServiceNotFound, compName)
+}
+
+func (l *Args) tailLines() *int64 {
+	if l.Tail < 0 {
+		return nil
+	}
+	return &l.Tail
+}
+
+// printLogs prints existing logs of containers of pods of the service once, without following them
+func (l *Args) printLogs(ctx context.Context, clientSet kubernetes.Interface, namespace string, pod *regexp.Regexp,
+	tmpl *template.Template, ioStreams cmdutil.IOStreams) error {
+	podList, err := clientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	sinceSeconds := int64(l.Since.Seconds())
+	var i int
+	for _, p := range podList.Items {
+		if !pod.MatchString(p.Name) {
+			continue
+		}
+		for _, c := range p.Spec.Containers {
+			log := stern.Log{
+				Namespace:      namespace,
+				PodName:        p.Name,
+				ContainerName:  c.Name,
+				PodColor:       logColors[i%len(logColors)],
+				ContainerColor: logColors[(i+1)%len(logColors)],
+			}
+			i++
+			stream, err := clientSet.CoreV1().Pods(namespace).GetLogs(p.Name, &corev1.PodLogOptions{
+				Container:    c.Name,
+				Timestamps:   true,
+				SinceSeconds: &sinceSeconds,
+				TailLines:    l.tailLines(),
+			}).Stream(ctx)
+			if err != nil {
+				ioStreams.Errorf("fail to get logs of container %s of pod %s: %v\n", c.Name, p.Name, err)
+				continue
+			}
+			err = printLogStream(stream, tmpl, log, ioStreams)
+			_ = stream.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func printLogStream(stream io.Reader, tmpl *template.Template, log stern.Log, ioStreams cmdutil.IOStreams) error {
+	reader := bufio.NewReader(stream)
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			log.Message = line
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, log); err != nil {
+				return err
+			}
+			ioStreams.Infonln(buf.String())
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wercker/stern/stern"
+
+	"github.com/oam-dev/kubevela/pkg/appfile"
+	"github.com/oam-dev/kubevela/pkg/application"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+)
+
+func TestPrintLogStream(t *testing.T) {
+	tmpl := template.Must(template.New("log").Parse("{{.PodName}} {{.ContainerName}} {{.Message}}"))
+	var b bytes.Buffer
+	ioStreams := cmdutil.IOStreams{Out: &b}
+	log := stern.Log{Namespace: "default", PodName: "web-abc", ContainerName: "nginx"}
+	err := printLogStream(strings.NewReader("line 1\nline 2\nno newline"), tmpl, log, ioStreams)
+	assert.NoError(t, err)
+	assert.Equal(t, "web-abc nginx line 1\nweb-abc nginx line 2\nweb-abc nginx no newline", b.String())
+}
+
+func TestChooseComponent(t *testing.T) {
+	app := &application.Application{AppFile: appfile.NewAppFile()}
+	app.Services = map[string]appfile.Service{"web": {}}
+	// the only service is chosen automatically
+	comp, err := chooseComponent(app, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "web", comp)
+
+	app.Services["worker"] = appfile.Service{}
+	comp, err = chooseComponent(app, "worker")
+	assert.NoError(t, err)
+	assert.Equal(t, "worker", comp)
+
+	_, err = chooseComponent(app, "db")
+	assert.Error(t, err)
+}
@@ -2,18 +2,17 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"strings"
 
-	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
-
-	gocmp "github.com/google/go-cmp/cmp"
+	"github.com/ghodss/yaml"
 	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	runtimeoam "github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
 	"github.com/oam-dev/kubevela/api/types"
-	"github.com/oam-dev/kubevela/pkg/application"
 	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
 	"github.com/oam-dev/kubevela/pkg/oam"
 	"github.com/oam-dev/kubevela/pkg/server/apis"
@@ -26,8 +25,8 @@ func NewListCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
 		Aliases:               []string{"list"},
 		DisableFlagsInUseLine: true,
 		Short:                 "List services",
-		Long:                  "List services of all applications",
-		Example:               `vela ls`,
+		Long:                  "List services of all applications in the namespace of current env, or in the namespace specified",
+		Example:               `vela ls -A`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			env, err := GetEnv(cmd)
 			if err != nil {
@@ -41,99 +40,84 @@ func NewListCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			printComponentList(ctx, newClient, appName, env, ioStreams)
-			return nil
+			output, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return err
+			}
+			if output != "" && output != "json" && output != "yaml" {
+				return fmt.Errorf("unsupported output format %s, only json and yaml are supported", output)
+			}
+			namespace, err := cmd.Flags().GetString("namespace")
+			if err != nil {
+				return err
+			}
+			allNamespaces, err := cmd.Flags().GetBool("all-namespaces")
+			if err != nil {
+				return err
+			}
+			if allNamespaces && namespace != "" {
+				return errors.New("--namespace and --all-namespaces can't be specified together")
+			}
+			if !allNamespaces && namespace == "" {
+				namespace = env.Namespace
+			}
+			return printComponentList(ctx, newClient, appName, namespace, env, output, ioStreams)
 		},
 		Annotations: map[string]string{
 			types.TagCommandType: types.TypeApp,
 		},
 	}
 	cmd.PersistentFlags().StringP(App, "", "", "specify the name of application")
+	cmd.Flags().StringP("output", "o", "", "output format of services, support json and yaml")
+	cmd.Flags().StringP("namespace", "n", "", "list services in the namespace instead of the one of current env")
+	cmd.Flags().BoolP("all-namespaces", "A", false, "list services in all namespaces")
 	return cmd
 }
 
-func printComponentList(ctx context.Context, c client.Client, appName string, env *types.EnvMeta, ioStreams cmdutil.IOStreams) {
-	deployedComponentList, err := oam.ListComponents(ctx, c, oam.Option{
-		AppName:   appName,
-		Namespace: env.Namespace,
-	})
+// printComponentList prints services in the namespace, or in all namespaces along with the NAMESPACE column
+// if namespace is empty
+func printComponentList(ctx context.Context, c client.Client, appName, namespace string, env *types.EnvMeta, output string,
+	ioStreams cmdutil.IOStreams) error {
+	all, err := oam.ListServicesInNamespace(ctx, c, appName, namespace, env, ioStreams)
 	if err != nil {
 		ioStreams.Infof("listing services: %s\n", err)
-		return
+		return nil
 	}
-	all := mergeStagingComponents(deployedComponentList, env, ioStreams)
-	table := uitable.New()
-	table.AddRow("SERVICE", "APP", "TYPE", "TRAITS", "STATUS", "CREATED-TIME")
-	for _, a := range all {
-		traitAlias := strings.Join(a.TraitNames, ",")
-		table.AddRow(a.Name, a.App, a.WorkloadName, traitAlias, a.Status, a.CreatedTime)
+	if output != "" {
+		return printServiceList(ioStreams, oam.ToServiceListItems(all), output)
 	}
-	ioStreams.Info(table.String())
+	ioStreams.Info(serviceTable(all, namespace == "").String())
+	return nil
 }
 
-func mergeStagingComponents(deployed []apis.ComponentMeta, env *types.EnvMeta, ioStreams cmdutil.IOStreams) []apis.ComponentMeta {
-	localApps, err := application.List(env.Name)
-	if err != nil {
-		ioStreams.Error("list application err", err)
-		return deployed
+func serviceTable(comps []apis.ComponentMeta, withNamespace bool) *uitable.Table {
+	table := uitable.New()
+	header := []interface{}{"SERVICE", "APP", "TYPE", "TRAITS", "STATUS", "CREATED-TIME"}
+	if withNamespace {
+		header = append([]interface{}{"NAMESPACE"}, header...)
 	}
-	var all []apis.ComponentMeta
-	for _, app := range localApps {
-		comps, appConfig, _, err := app.OAM(env, ioStreams, true)
-		if err != nil {
-			ioStreams.Errorf("convert app %s err %v\n", app.Name, err)
-			continue
-		}
-		for _, c := range comps {
-			traits, err := app.GetTraitNames(c.Name)
-			if err != nil {
-				ioStreams.Errorf("get traits from app %s %s err %v\n", app.Name, c.Name, err)
-				continue
-			}
-			compMeta, exist := GetCompMeta(deployed, app.Name, c.Name)
-			if !exist {
-				all = append(all, apis.ComponentMeta{
-					Name:         c.Name,
-					App:          app.Name,
-					WorkloadName: c.Labels[runtimeoam.WorkloadTypeLabel],
-					TraitNames:   traits,
-					Status:       types.StatusStaging,
-					CreatedTime:  app.CreateTime.String(),
-				})
-				continue
-			}
-			compMeta.TraitNames = traits
-			compMeta.WorkloadName = app.AppFile.Services[c.Name].GetType()
-			cspec := c.Spec.DeepCopy()
-			cspec.Workload.Raw, _ = cspec.Workload.MarshalJSON()
-			cspec.Workload.Object = nil
-			aspec := appConfig.Spec.DeepCopy()
-			for i, v := range aspec.Components {
-				for j, t := range v.Traits {
-					t.Trait.Raw, _ = t.Trait.MarshalJSON()
-					t.Trait.Object = nil
-					v.Traits[j] = t
-				}
-				aspec.Components[i] = v
-				if compMeta.AppConfig.Spec.Components[i].Traits == nil && len(v.Traits) == 0 {
-					compMeta.AppConfig.Spec.Components[i].Traits = make([]v1alpha2.ComponentTrait, 0)
-				}
-			}
-
-			if !gocmp.Equal(compMeta.Component.Spec, *cspec) || !gocmp.Equal(compMeta.AppConfig.Spec, *aspec) {
-				compMeta.Status = types.StatusStaging
-			}
-			all = append(all, compMeta)
+	table.AddRow(header...)
+	for _, a := range comps {
+		row := []interface{}{a.Name, a.App, a.WorkloadName, strings.Join(a.TraitNames, ","), a.Status, a.CreatedTime}
+		if withNamespace {
+			row = append([]interface{}{a.Namespace}, row...)
 		}
+		table.AddRow(row...)
 	}
-	return all
+	return table
 }
 
-func GetCompMeta(deployed []apis.ComponentMeta, appName, compName string) (apis.ComponentMeta, bool) {
-	for _, v := range deployed {
-		if v.Name == compName && v.App == appName {
-			return v, true
-		}
+func printServiceList(ioStreams cmdutil.IOStreams, items []apis.ServiceListItem, output string) error {
+	var b []byte
+	var err error
+	if output == "json" {
+		b, err = json.MarshalIndent(items, "", "  ")
+	} else {
+		b, err = yaml.Marshal(items)
+	}
+	if err != nil {
+		return err
 	}
-	return apis.ComponentMeta{}, false
+	ioStreams.Info(string(b))
+	return nil
 }
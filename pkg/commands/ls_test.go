@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/oam-dev/kubevela/api/types"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/server/apis"
+)
+
+func TestPrintServiceList(t *testing.T) {
+	items := oam.ToServiceListItems([]apis.ComponentMeta{
+		{
+			Name:         "frontend",
+			App:          "myapp",
+			WorkloadName: "webservice",
+			TraitNames:   []string{"route", "scaler"},
+			Status:       types.StatusDeployed,
+			CreatedTime:  "2020-11-16 10:00:00 +0800 CST",
+		},
+		{
+			Name:         "backend",
+			App:          "myapp",
+			WorkloadName: "worker",
+			Status:       types.StatusStaging,
+		},
+	})
+
+	var b bytes.Buffer
+	ioStreams := cmdutil.IOStreams{Out: &b}
+	assert.NoError(t, printServiceList(ioStreams, items, "json"))
+	assert.Equal(t, `[
+  {
+    "name": "frontend",
+    "app": "myapp",
+    "type": "webservice",
+    "traits": [
+      "route",
+      "scaler"
+    ],
+    "status": "Deployed",
+    "createdTime": "2020-11-16 10:00:00 +0800 CST"
+  },
+  {
+    "name": "backend",
+    "app": "myapp",
+    "type": "worker",
+    "traits": [],
+    "status": "Staging",
+    "createdTime": ""
+  }
+]
+`, b.String())
+
+	b.Reset()
+	assert.NoError(t, printServiceList(ioStreams, items[1:], "yaml"))
+	assert.Equal(t, `- app: myapp
+  createdTime: ""
+  name: backend
+  status: Staging
+  traits: []
+  type: worker
+
+`, b.String())
+
+	b.Reset()
+	assert.NoError(t, printServiceList(ioStreams, oam.ToServiceListItems(nil), "json"))
+	assert.Equal(t, "[]\n", b.String())
+}
+
+func TestServiceTable(t *testing.T) {
+	comps := []apis.ComponentMeta{
+		{Name: "frontend", App: "myapp", WorkloadName: "webservice", TraitNames: []string{"route"}, Status: types.StatusDeployed, Namespace: "default"},
+		{Name: "worker", App: "jobs", WorkloadName: "worker", Status: types.StatusDeployed, Namespace: "team-a"},
+	}
+	table := serviceTable(comps, false)
+	assert.Equal(t, 3, len(table.Rows))
+	assert.Equal(t, "SERVICE", table.Rows[0].Cells[0].Data)
+	assert.Equal(t, "frontend", table.Rows[1].Cells[0].Data)
+
+	table = serviceTable(comps, true)
+	assert.Equal(t, "NAMESPACE", table.Rows[0].Cells[0].Data)
+	assert.Equal(t, "team-a", table.Rows[2].Cells[0].Data)
+	assert.Equal(t, "worker", table.Rows[2].Cells[1].Data)
+}
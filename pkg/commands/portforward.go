@@ -27,7 +27,6 @@ import (
 	"k8s.io/client-go/transport/spdy"
 
 	"github.com/oam-dev/kubevela/pkg/application"
-	"github.com/oam-dev/kubevela/pkg/commands/util"
 	velacmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
 	cmdpf "k8s.io/kubectl/pkg/cmd/portforward"
 	k8scmdutil "k8s.io/kubectl/pkg/cmd/util"
@@ -48,6 +47,7 @@ type VelaPortForwardOptions struct {
 	ClientSet            kubernetes.Interface
 	Client               client.Client
 	routeTrait           bool
+	component            string
 }
 
 func NewPortForwardCommand(c types.Args, ioStreams velacmdutil.IOStreams) *cobra.Command {
@@ -93,6 +93,7 @@ func NewPortForwardCommand(c types.Args, ioStreams velacmdutil.IOStreams) *cobra
 		"The length of time (like 5s, 2m, or 3h, higher than zero) to wait until at least one pod is running",
 	)
 	cmd.Flags().BoolVar(&o.routeTrait, "route", false, "forward ports from route trait service")
+	cmd.Flags().StringVarP(&o.component, "component", "c", "", "name of the service to forward ports to, choose one interactively if not specified")
 	return cmd
 }
 
@@ -143,7 +144,7 @@ func GetRouteServiceName(appconfig *v1alpha2.ApplicationConfiguration, svcName s
 }
 
 func (o *VelaPortForwardOptions) Complete() error {
-	svcName, err := util.AskToChooseOneService(o.App.GetComponents())
+	svcName, err := chooseComponent(o.App, o.component)
 	if err != nil {
 		return err
 	}
@@ -239,9 +240,9 @@ func (o *VelaPortForwardOptions) getPodName(svcName string) (string, error) {
 func (o *VelaPortForwardOptions) Run() error {
 	go func() {
 		<-o.kcPortForwardOptions.ReadyChannel
-		o.ioStreams.Info("\nForward successfully! Opening browser ...")
 		local, _ := splitPort(o.Args[1])
 		var url = "http://127.0.0.1:" + local
+		o.ioStreams.Infof("\nForward successfully! Forwarding from %s, press Ctrl-C to stop. Opening browser ...\n", url)
 		if err := OpenBrowser(url); err != nil {
 			o.ioStreams.Errorf("\nFailed to open browser: %v", err)
 		}
@@ -0,0 +1,219 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam/discoverymapper"
+	oamutil "github.com/crossplane/oam-kubernetes-runtime/pkg/oam/util"
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/api/types"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+	oam2 "github.com/oam-dev/kubevela/pkg/oam"
+)
+
+// ownedResourceKinds are kinds of resources which could be created by traits or workloads of an application,
+// they are included if they're owned by any resource of the application
+var ownedResourceKinds = []schema.GroupVersionKind{
+	{Group: "", Version: "v1", Kind: "Service"},
+	{Group: "", Version: "v1", Kind: "ConfigMap"},
+	{Group: "networking.k8s.io", Version: "v1beta1", Kind: "Ingress"},
+	{Group: "keda.sh", Version: "v1alpha1", Kind: "ScaledObject"},
+	{Group: "keda.sh", Version: "v1alpha1", Kind: "TriggerAuthentication"},
+	{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"},
+}
+
+func NewGetResourcesCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
+	ctx := context.Background()
+	cmd := &cobra.Command{
+		Use:     "get-resources APP_NAME",
+		Short:   "Dump all live resources of an application",
+		Long:    "Dump all live resources of an application, including workloads, traits and resources owned by them, managedFields and status are stripped.",
+		Example: `vela get-resources APP_NAME -o yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("must specify name for application")
+			}
+			appName := args[0]
+			output, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return err
+			}
+			if output != "yaml" && output != "json" {
+				return fmt.Errorf("unsupported output format %s, only yaml and json are supported", output)
+			}
+			env, err := GetEnv(cmd)
+			if err != nil {
+				return err
+			}
+			newClient, err := client.New(c.Config, client.Options{Scheme: c.Schema})
+			if err != nil {
+				return err
+			}
+			dm, err := discoverymapper.New(c.Config)
+			if err != nil {
+				return err
+			}
+			resources, err := getAppResources(ctx, newClient, dm, appName, env)
+			if err != nil {
+				return err
+			}
+			return printResources(ioStreams, resources, output)
+		},
+		Annotations: map[string]string{
+			types.TagCommandType: types.TypeApp,
+		},
+	}
+	cmd.Flags().StringP("output", "o", "yaml", "output format of resources, support yaml and json")
+	cmd.SetOut(ioStreams.Out)
+	return cmd
+}
+
+// getAppResources fetches the appConfig, components, workloads with their child resources, traits,
+// and resources owned by any of them
+func getAppResources(ctx context.Context, c client.Client, dm discoverymapper.DiscoveryMapper, appName string, env *types.EnvMeta) ([]*unstructured.Unstructured, error) {
+	_, appConfig, err := getApp(ctx, c, "", appName, env)
+	if err != nil {
+		return nil, err
+	}
+	var resources []*unstructured.Unstructured
+	seen := make(map[ktypes.UID]bool)
+	add := func(u *unstructured.Unstructured) {
+		if seen[u.GetUID()] {
+			return
+		}
+		seen[u.GetUID()] = true
+		resources = append(resources, u)
+	}
+
+	acObj, err := toUnstructured(appConfig, v1alpha2.SchemeGroupVersion.WithKind(kindAppConfig))
+	if err != nil {
+		return nil, err
+	}
+	add(acObj)
+	for _, comp := range appConfig.Spec.Components {
+		compObj, err := oam2.GetUnstructured(ctx, c, appConfig.Namespace, runtimev1alpha1.TypedReference{
+			APIVersion: v1alpha2.SchemeGroupVersion.String(),
+			Kind:       v1alpha2.ComponentKind,
+			Name:       comp.ComponentName,
+		})
+		if err != nil {
+			return nil, err
+		}
+		add(compObj)
+	}
+	for _, wl := range appConfig.Status.Workloads {
+		workload, err := oam2.GetUnstructured(ctx, c, appConfig.Namespace, wl.Reference)
+		if err != nil {
+			return nil, err
+		}
+		add(workload)
+		children, err := oamutil.FetchWorkloadChildResources(ctx, ctrl.Log.WithName("get-resources"), c, dm, workload)
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range children {
+			add(child)
+		}
+		for _, tr := range wl.Traits {
+			trait, err := oam2.GetUnstructured(ctx, c, appConfig.Namespace, tr.Reference)
+			if err != nil {
+				return nil, err
+			}
+			add(trait)
+		}
+	}
+
+	// collect resources owned by the collected ones until nothing new is found
+	var candidates []*unstructured.Unstructured
+	for _, gvk := range ownedResourceKinds {
+		list := unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk)
+		if err := c.List(ctx, &list, client.InNamespace(appConfig.Namespace)); err != nil {
+			// skip kinds not installed in the cluster
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return nil, err
+		}
+		for i := range list.Items {
+			candidates = append(candidates, &list.Items[i])
+		}
+	}
+	for found := true; found; {
+		found = false
+		for _, u := range candidates {
+			if seen[u.GetUID()] {
+				continue
+			}
+			for _, owner := range u.GetOwnerReferences() {
+				if seen[owner.UID] {
+					add(u)
+					found = true
+					break
+				}
+			}
+		}
+	}
+
+	for _, u := range resources {
+		unstructured.RemoveNestedField(u.Object, "metadata", "managedFields")
+		unstructured.RemoveNestedField(u.Object, "status")
+	}
+	return resources, nil
+}
+
+func toUnstructured(obj interface{}, gvk schema.GroupVersionKind) (*unstructured.Unstructured, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{}
+	if err = json.Unmarshal(data, &u.Object); err != nil {
+		return nil, err
+	}
+	// typed objects got from client don't have TypeMeta filled
+	u.SetGroupVersionKind(gvk)
+	return u, nil
+}
+
+func printResources(ioStreams cmdutil.IOStreams, resources []*unstructured.Unstructured, output string) error {
+	if output == "json" {
+		items := make([]interface{}, 0, len(resources))
+		for _, u := range resources {
+			items = append(items, u.Object)
+		}
+		b, err := json.MarshalIndent(map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "List",
+			"items":      items,
+		}, "", "  ")
+		if err != nil {
+			return err
+		}
+		ioStreams.Info(string(b))
+		return nil
+	}
+	var docs []string
+	for _, u := range resources {
+		b, err := yaml.Marshal(u.Object)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, string(b))
+	}
+	ioStreams.Info(strings.Join(docs, "---\n"))
+	return nil
+}
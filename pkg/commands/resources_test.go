@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+)
+
+func TestPrintResources(t *testing.T) {
+	resource := func(apiVersion, kind, name string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion(apiVersion)
+		u.SetKind(kind)
+		u.SetName(name)
+		return u
+	}
+	deploy := resource("apps/v1", "Deployment", "web")
+	svc := resource("v1", "Service", "web")
+
+	cases := map[string]struct {
+		resources []*unstructured.Unstructured
+		output    string
+		expOutput string
+	}{
+		"yaml documents": {
+			resources: []*unstructured.Unstructured{deploy, svc},
+			output:    "yaml",
+			expOutput: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+
+`,
+		},
+		"json list": {
+			resources: []*unstructured.Unstructured{deploy},
+			output:    "json",
+			expOutput: `{
+  "apiVersion": "v1",
+  "items": [
+    {
+      "apiVersion": "apps/v1",
+      "kind": "Deployment",
+      "metadata": {
+        "name": "web"
+      }
+    }
+  ],
+  "kind": "List"
+}
+`,
+		},
+		"empty json list": {
+			output: "json",
+			expOutput: `{
+  "apiVersion": "v1",
+  "items": [],
+  "kind": "List"
+}
+`,
+		},
+	}
+	for name, c := range cases {
+		var b bytes.Buffer
+		err := printResources(cmdutil.IOStreams{Out: &b}, c.resources, c.output)
+		assert.NoError(t, err, name)
+		assert.Equal(t, c.expOutput, b.String(), name)
+	}
+}
+
+func TestToUnstructured(t *testing.T) {
+	appConfig := &v1alpha2.ApplicationConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}}
+	u, err := toUnstructured(appConfig, v1alpha2.SchemeGroupVersion.WithKind(kindAppConfig))
+	assert.NoError(t, err)
+	// typed objects got from client don't have TypeMeta, which is filled by the given kind
+	assert.Equal(t, "core.oam.dev/v1alpha2", u.GetAPIVersion())
+	assert.Equal(t, kindAppConfig, u.GetKind())
+	assert.Equal(t, "app", u.GetName())
+	assert.Equal(t, "default", u.GetNamespace())
+}
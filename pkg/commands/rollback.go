@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/api/types"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+)
+
+func NewRollbackCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
+	ctx := context.Background()
+	cmd := &cobra.Command{
+		Use:   "rollback APP_NAME",
+		Short: "Rollback services of an application to a stored revision",
+		Long: "Rollback services of an application to a stored revision, the revisions are listed if --to is not specified. " +
+			"Only services in the cluster are rolled back, the local appfile is kept as it is.",
+		Example: `vela rollback APP_NAME --to 1`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errors.New("must specify name for application")
+			}
+			appName := args[0]
+			revision, err := cmd.Flags().GetInt64("to")
+			if err != nil {
+				return err
+			}
+			if revision < 0 {
+				return errors.New("--to must be a positive revision")
+			}
+			svcName, err := cmd.Flags().GetString("svc")
+			if err != nil {
+				return err
+			}
+			env, err := GetEnv(cmd)
+			if err != nil {
+				return err
+			}
+			newClient, err := client.New(c.Config, client.Options{Scheme: c.Schema})
+			if err != nil {
+				return err
+			}
+			_, appConfig, err := getApp(ctx, newClient, "", appName, env)
+			if err != nil {
+				return err
+			}
+			if revision == 0 {
+				return printComponentRevisions(ctx, newClient, ioStreams, appConfig, svcName)
+			}
+			return rollbackApp(ctx, newClient, ioStreams, appConfig, svcName, revision)
+		},
+		Annotations: map[string]string{
+			types.TagCommandType: types.TypeApp,
+		},
+	}
+	cmd.Flags().Int64("to", 0, "the revision of services to rollback to")
+	cmd.Flags().StringP("svc", "s", "", "only rollback the specified service")
+	cmd.SetOut(ioStreams.Out)
+	return cmd
+}
+
+// targetComponents returns the components of the application, or only svcName if it's specified
+func targetComponents(appConfig *v1alpha2.ApplicationConfiguration, svcName string) ([]string, error) {
+	var comps []string
+	for _, acComp := range appConfig.Spec.Components {
+		if svcName != "" && acComp.ComponentName != svcName {
+			continue
+		}
+		comps = append(comps, acComp.ComponentName)
+	}
+	if svcName != "" && len(comps) == 0 {
+		return nil, fmt.Errorf(ErrServiceNotFound, svcName)
+	}
+	return comps, nil
+}
+
+// printComponentRevisions lists the stored revisions of each service, the revision running is marked by *
+func printComponentRevisions(ctx context.Context, c client.Client, ioStreams cmdutil.IOStreams,
+	appConfig *v1alpha2.ApplicationConfiguration, svcName string) error {
+	comps, err := targetComponents(appConfig, svcName)
+	if err != nil {
+		return err
+	}
+	table := uitable.New()
+	table.AddRow("SERVICE", "REVISION", "CURRENT", "CREATED-TIME")
+	for _, compName := range comps {
+		revisions, err := listComponentRevisions(ctx, c, appConfig.Namespace, compName)
+		if err != nil {
+			return err
+		}
+		wlStatus, _ := getWorkloadStatusFromAppConfig(appConfig, compName)
+		for _, r := range revisions {
+			current := ""
+			if r.Name == wlStatus.ComponentRevisionName {
+				current = "*"
+			}
+			table.AddRow(compName, r.Revision, current, r.CreationTimestamp.String())
+		}
+	}
+	ioStreams.Info(table.String())
+	return nil
+}
+
+// rollbackApp updates the spec of each service to the one stored in the revision, all revisions are checked
+// before updating any service so the application isn't rolled back partially
+func rollbackApp(ctx context.Context, c client.Client, ioStreams cmdutil.IOStreams,
+	appConfig *v1alpha2.ApplicationConfiguration, svcName string, revision int64) error {
+	comps, err := targetComponents(appConfig, svcName)
+	if err != nil {
+		return err
+	}
+	stored := make(map[string]*v1alpha2.Component)
+	for _, compName := range comps {
+		if stored[compName], err = getComponentRevision(ctx, c, appConfig.Namespace, compName, revision); err != nil {
+			return err
+		}
+	}
+	for _, compName := range comps {
+		var live v1alpha2.Component
+		if err := c.Get(ctx, client.ObjectKey{Namespace: appConfig.Namespace, Name: compName}, &live); err != nil {
+			return err
+		}
+		live.Spec = stored[compName].Spec
+		if err := c.Update(ctx, &live); err != nil {
+			return fmt.Errorf("rollback service %s err %v", compName, err)
+		}
+		ioStreams.Infof("Rolled back service %s of app %s to revision %d\n", compName, appConfig.Name, revision)
+	}
+	return nil
+}
+
+// listComponentRevisions lists the ControllerRevisions of the component ordered by revision
+func listComponentRevisions(ctx context.Context, c client.Client, namespace, compName string) ([]appsv1.ControllerRevision, error) {
+	var revisions appsv1.ControllerRevisionList
+	if err := c.List(ctx, &revisions, client.InNamespace(namespace),
+		client.MatchingLabels{types.LabelControllerRevisionComponent: compName}); err != nil {
+		return nil, err
+	}
+	sort.Slice(revisions.Items, func(i, j int) bool {
+		return revisions.Items[i].Revision < revisions.Items[j].Revision
+	})
+	return revisions.Items, nil
+}
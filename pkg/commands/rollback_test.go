@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core"
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/api/types"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+)
+
+func TestRollbackApp(t *testing.T) {
+	ctx := context.Background()
+	s := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(s))
+	assert.NoError(t, core.AddToScheme(s))
+
+	newComp := func(image string) v1alpha2.Component {
+		return v1alpha2.Component{
+			ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "default"},
+			Spec: v1alpha2.ComponentSpec{Workload: runtime.RawExtension{
+				Raw: []byte(`{"apiVersion":"apps/v1","kind":"Deployment","spec":{"image":"` + image + `"}}`)}},
+		}
+	}
+	newRevision := func(revision int64, comp v1alpha2.Component) *appsv1.ControllerRevision {
+		data, err := json.Marshal(comp)
+		assert.NoError(t, err)
+		return &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-v%d", comp.Name, revision), Namespace: "default",
+				Labels: map[string]string{types.LabelControllerRevisionComponent: comp.Name}},
+			Revision: revision,
+			Data:     runtime.RawExtension{Raw: data},
+		}
+	}
+	live := newComp("nginx:1.10")
+	c := fake.NewFakeClientWithScheme(s, &live, newRevision(2, live), newRevision(1, newComp("nginx:1.9")))
+	appConfig := &v1alpha2.ApplicationConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "myapp", Namespace: "default"},
+		Spec: v1alpha2.ApplicationConfigurationSpec{Components: []v1alpha2.ApplicationConfigurationComponent{
+			{ComponentName: "frontend"},
+		}},
+	}
+
+	revisions, err := listComponentRevisions(ctx, c, "default", "frontend")
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, int64(1), revisions[0].Revision)
+
+	var b bytes.Buffer
+	ioStreams := cmdutil.IOStreams{Out: &b}
+	assert.EqualError(t, rollbackApp(ctx, c, ioStreams, appConfig, "", 3), "revision 3 of service frontend not found")
+	assert.EqualError(t, rollbackApp(ctx, c, ioStreams, appConfig, "backend", 1), "service backend not found in app")
+
+	assert.NoError(t, rollbackApp(ctx, c, ioStreams, appConfig, "", 1))
+	assert.Equal(t, "Rolled back service frontend of app myapp to revision 1\n", b.String())
+	var got v1alpha2.Component
+	assert.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "frontend"}, &got))
+	assert.Contains(t, string(got.Spec.Workload.Raw), "nginx:1.9")
+}
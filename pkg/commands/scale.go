@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/api/types"
+	"github.com/oam-dev/kubevela/pkg/application"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+	"github.com/oam-dev/kubevela/pkg/oam"
+)
+
+// scalerTraitName is the name of the manual scaler trait, which owns the replicas of the workload once attached
+const scalerTraitName = "scaler"
+
+// NewCompScaleCommand changes the replicas of a service
+func NewCompScaleCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "scale SERVICE",
+		DisableFlagsInUseLine: true,
+		Short:                 "Change the replicas of a service",
+		Long: "Change the replicas of a service, the scaler trait of the service is updated if it's attached, " +
+			"otherwise the workload of the service is patched directly.",
+		Example: "vela svc scale frontend --replicas 3",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svcName, err := GetWorkloadNameFromArgs(args)
+			if err != nil {
+				return err
+			}
+			if !cmd.Flags().Changed("replicas") {
+				return errors.New("must specify --replicas")
+			}
+			replicas, err := cmd.Flags().GetInt64("replicas")
+			if err != nil {
+				return err
+			}
+			if replicas < 0 {
+				return fmt.Errorf("--replicas must be non-negative, got %d", replicas)
+			}
+			env, err := GetEnv(cmd)
+			if err != nil {
+				return err
+			}
+			newClient, err := client.New(c.Config, client.Options{Scheme: c.Schema})
+			if err != nil {
+				return err
+			}
+			appName, err := cmd.Flags().GetString(App)
+			if err != nil {
+				return err
+			}
+			return scaleService(context.Background(), newClient, ioStreams, env, appName, svcName, replicas)
+		},
+		Annotations: map[string]string{
+			types.TagCommandType: types.TypeApp,
+		},
+	}
+	cmd.SetOut(ioStreams.Out)
+	cmd.Flags().Int64("replicas", -1, "the number of replicas to scale the service to")
+	return cmd
+}
+
+func scaleService(ctx context.Context, c client.Client, ioStreams cmdutil.IOStreams, env *types.EnvMeta, appName, svcName string, replicas int64) error {
+	var app *application.Application
+	var err error
+	if appName != "" {
+		app, err = application.Load(env.Name, appName)
+	} else {
+		app, err = application.MatchAppByComp(env.Name, svcName)
+	}
+	if err != nil {
+		return err
+	}
+	if _, ok := app.Services[svcName]; !ok {
+		return fmt.Errorf(ErrServiceNotFound, svcName)
+	}
+
+	traits, err := app.GetTraits(svcName)
+	if err != nil {
+		return err
+	}
+	if _, ok := traits[scalerTraitName]; ok {
+		// the scaler trait would override the replicas of the workload, so it's the one to update
+		if err := app.SetTrait(svcName, scalerTraitName, map[string]interface{}{"replicas": replicas}); err != nil {
+			return err
+		}
+		if err := app.Save(env.Name); err != nil {
+			return err
+		}
+		if _, err := oam.TraitOperationRun(ctx, c, env, app, false, ioStreams); err != nil {
+			return err
+		}
+		ioStreams.Infof("Succeeded to scale service %s of app %s to %d replicas by the %s trait\n", svcName, app.Name, replicas, scalerTraitName)
+		return nil
+	}
+
+	appConfig, err := application.GetAppConfig(ctx, c, app, env)
+	if err != nil {
+		return err
+	}
+	wlStatus, ok := getWorkloadStatusFromAppConfig(appConfig, svcName)
+	if !ok {
+		return fmt.Errorf(ErrFmtNotInitialized, svcName)
+	}
+	if err := scaleWorkload(ctx, c, appConfig.Namespace, wlStatus.Reference, replicas); err != nil {
+		return err
+	}
+	ioStreams.Infof("Succeeded to scale service %s of app %s to %d replicas\n", svcName, app.Name, replicas)
+	return nil
+}
+
+// scaleWorkload patches the replicas of the workload, it fails if the workload doesn't have replicas in its spec
+func scaleWorkload(ctx context.Context, c client.Client, namespace string, ref runtimev1alpha1.TypedReference, replicas int64) error {
+	workload, err := oam.GetUnstructured(ctx, c, namespace, ref)
+	if err != nil {
+		return err
+	}
+	if _, found, _ := unstructured.NestedInt64(workload.Object, "spec", "replicas"); !found {
+		return fmt.Errorf("%s %s doesn't support scaling, attach the %s trait instead", ref.Kind, ref.Name, scalerTraitName)
+	}
+	patch := []byte(fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas))
+	if err := c.Patch(ctx, workload, client.RawPatch(ktypes.MergePatchType, patch)); err != nil {
+		return fmt.Errorf("failed to scale %s %s: %v", ref.Kind, ref.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestScaleWorkload(t *testing.T) {
+	ctx := context.Background()
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32Ptr(1)},
+	}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "single", Namespace: "default"}}
+	c := fake.NewFakeClientWithScheme(scheme.Scheme, deploy, pod)
+
+	ref := runtimev1alpha1.TypedReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "frontend"}
+	assert.NoError(t, scaleWorkload(ctx, c, "default", ref, 3))
+	var got appsv1.Deployment
+	assert.NoError(t, c.Get(ctx, client.ObjectKey{Name: "frontend", Namespace: "default"}, &got))
+	assert.Equal(t, int32(3), *got.Spec.Replicas)
+
+	ref = runtimev1alpha1.TypedReference{APIVersion: "v1", Kind: "Pod", Name: "single"}
+	assert.EqualError(t, scaleWorkload(ctx, c, "default", ref, 3),
+		"Pod single doesn't support scaling, attach the scaler trait instead")
+}
@@ -3,9 +3,13 @@ package commands
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/plugins"
 
+	"github.com/ghodss/yaml"
 	"github.com/gosuri/uitable"
 	"github.com/oam-dev/kubevela/api/types"
 	"github.com/oam-dev/kubevela/pkg/application"
@@ -17,7 +21,7 @@ func NewAppShowCommand(ioStreams cmdutil.IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "show APP_NAME",
 		Short:   "Show details of an application",
-		Long:    "Show details of an application",
+		Long:    "Show details of an application, including the workload type, settings and traits of each service.",
 		Example: `vela show APP_NAME`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			argsLength := len(args)
@@ -32,18 +36,26 @@ func NewAppShowCommand(ioStreams cmdutil.IOStreams) *cobra.Command {
 				return err
 			}
 
-			return showApplication(cmd, env, appName)
+			output, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return err
+			}
+			if output != "" && output != "yaml" {
+				return fmt.Errorf("unsupported output format %s, only yaml is supported", output)
+			}
+			return showApplication(cmd, env, appName, output)
 		},
 		Annotations: map[string]string{
 			types.TagCommandType: types.TypeApp,
 		},
 	}
 	cmd.Flags().StringP("svc", "s", "", "service name")
+	cmd.Flags().StringP("output", "o", "", "output the application spec in the format, only support yaml")
 	cmd.SetOut(ioStreams.Out)
 	return cmd
 }
 
-func showApplication(cmd *cobra.Command, env *types.EnvMeta, appName string) error {
+func showApplication(cmd *cobra.Command, env *types.EnvMeta, appName, output string) error {
 	app, err := application.Load(env.Name, appName)
 	if err != nil {
 		return err
@@ -52,6 +64,9 @@ func showApplication(cmd *cobra.Command, env *types.EnvMeta, appName string) err
 	if err != nil {
 		return err
 	}
+	if output != "" {
+		return showApplicationSpec(cmd, app, targetServices)
+	}
 
 	cmd.Printf("About:\n\n")
 	table := uitable.New()
@@ -65,62 +80,94 @@ func showApplication(cmd *cobra.Command, env *types.EnvMeta, appName string) err
 	cmd.Printf("  Namespace:\t%s\n", env.Namespace)
 	cmd.Println()
 
-	table = uitable.New()
 	cmd.Printf("Services:\n\n")
-
 	for _, svcName := range targetServices {
-		if err := showComponent(cmd, env, svcName, appName); err != nil {
+		if err := showComponent(cmd, app, svcName); err != nil {
 			return err
 		}
 	}
-	cmd.Println(table.String())
-	cmd.Println()
 	return nil
 }
 
-func showComponent(cmd *cobra.Command, env *types.EnvMeta, compName, appName string) error {
-	var app *application.Application
-	var err error
-	if appName != "" {
-		app, err = application.Load(env.Name, appName)
-	} else {
-		app, err = application.MatchAppByComp(env.Name, compName)
+// showApplicationSpec prints the appfile of the application reconstructed from the services, timestamps are dropped
+func showApplicationSpec(cmd *cobra.Command, app *application.Application, services []string) error {
+	spec := map[string]interface{}{
+		"name": app.Name,
+	}
+	svcs := make(map[string]interface{})
+	for _, svcName := range services {
+		svcs[svcName] = app.Services[svcName]
+	}
+	spec["services"] = svcs
+	if len(app.Secrets) > 0 {
+		spec["secrets"] = app.Secrets
 	}
+	b, err := yaml.Marshal(spec)
 	if err != nil {
 		return err
 	}
+	cmd.Print(string(b))
+	return nil
+}
 
-	for cname := range app.Services {
-		if cname != compName {
-			continue
-		}
-		wtype, data := app.GetWorkload(compName)
-		table := uitable.New()
-		table.AddRow("  - Name:", compName)
-		table.AddRow("    WorkloadType:", wtype)
-		cmd.Printf(table.String())
-		cmd.Printf("\n    Arguments:\n")
-		table = uitable.New()
-		for k, v := range data {
-			table.AddRow(fmt.Sprintf("      %s:        ", k), v)
-		}
-		cmd.Printf("%s", table.String())
-		traits, err := app.GetTraits(compName)
-		if err != nil {
-			cmd.PrintErr(err)
-			continue
-		}
-		cmd.Println()
-		cmd.Printf("      Traits:\n")
-		for k, v := range traits {
-			cmd.Printf("        - %s:\n", k)
-			table = uitable.New()
-			for kk, vv := range v {
-				table.AddRow(fmt.Sprintf("            %s:", kk), vv)
-			}
-			cmd.Printf("%s\n", table.String())
-		}
-		cmd.Println()
+// showComponent prints the workload type along with its definition, the settings and the traits of the service in a tree
+func showComponent(cmd *cobra.Command, app *application.Application, compName string) error {
+	if _, ok := app.Services[compName]; !ok {
+		return fmt.Errorf(ErrServiceNotFound, compName)
+	}
+	wtype, data := app.GetWorkload(compName)
+	cmd.Printf("  - Name: %s\n", compName)
+	cmd.Printf("    Type: %s\n", wtype)
+	if workload, err := plugins.GetInstalledCapabilityWithCapAlias(types.TypeWorkload, wtype); err == nil && workload.CrdName != "" {
+		cmd.Printf("    Definition: %s\n", workload.CrdName)
 	}
+	cmd.Printf("    Settings:\n")
+	cmd.Print(renderSettings(data, "      "))
+
+	traits, err := app.GetTraits(compName)
+	if err != nil {
+		return err
+	}
+	cmd.Printf("    Traits:\n")
+	if len(traits) == 0 {
+		cmd.Printf("      <none>\n")
+	}
+	for _, name := range sortedKeys(traits) {
+		cmd.Printf("      - %s:\n", name)
+		cmd.Print(renderSettings(traits[name], "          "))
+	}
+	cmd.Println()
 	return nil
 }
+
+// renderSettings renders the settings sorted by key, one per line with the indent
+func renderSettings(settings map[string]interface{}, indent string) string {
+	if len(settings) == 0 {
+		return ""
+	}
+	table := uitable.New()
+	keys := make([]string, 0, len(settings))
+	for k := range settings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		table.AddRow(fmt.Sprintf("%s%s:", indent, k), settings[k])
+	}
+	// uitable pads every column to the same width, which leaves trailing spaces after the shorter values
+	var b strings.Builder
+	for _, line := range strings.Split(table.String(), "\n") {
+		b.WriteString(strings.TrimRight(line, " "))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func sortedKeys(m map[string]map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
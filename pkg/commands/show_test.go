@@ -0,0 +1,21 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderSettings(t *testing.T) {
+	assert.Equal(t, "", renderSettings(nil, "  "))
+
+	got := renderSettings(map[string]interface{}{"port": 80, "image": "nginx:1.9.4"}, "  ")
+	lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	assert.Len(t, lines, 2)
+	// settings are sorted by key
+	assert.True(t, strings.HasPrefix(lines[0], "  image:"))
+	assert.True(t, strings.HasSuffix(lines[0], "nginx:1.9.4"))
+	assert.True(t, strings.HasPrefix(lines[1], "  port:"))
+	assert.True(t, strings.HasSuffix(lines[1], "80"))
+}
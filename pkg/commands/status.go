@@ -2,9 +2,11 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -24,6 +26,7 @@ import (
 	"github.com/oam-dev/kubevela/pkg/application"
 	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
 	oam2 "github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/server/apis"
 )
 
 // HealthStatus represents health status strings.
@@ -51,6 +54,7 @@ type ScopeHealthCondition = v1alpha2.ScopeHealthCondition
 
 var (
 	kindHealthScope = reflect.TypeOf(v1alpha2.HealthScope{}).Name()
+	kindAppConfig   = reflect.TypeOf(v1alpha2.ApplicationConfiguration{}).Name()
 )
 
 // CompStatus represents the status of a component during "vela init"
@@ -100,6 +104,7 @@ const (
 	initTimeout           time.Duration = 30 * time.Second
 	deployTimeout         time.Duration = 10 * time.Second
 	healthCheckBufferTime time.Duration = 120 * time.Second
+	watchInterval         time.Duration = 2 * time.Second
 )
 
 func NewAppStatusCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
@@ -125,28 +130,198 @@ func NewAppStatusCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Comma
 			if err != nil {
 				return err
 			}
-			return printAppStatus(ctx, newClient, ioStreams, appName, env, cmd)
+			showConditions, err := cmd.Flags().GetBool("conditions")
+			if err != nil {
+				return err
+			}
+			output, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return err
+			}
+			since, err := cmd.Flags().GetDuration("since")
+			if err != nil {
+				return err
+			}
+			if since < 0 {
+				return errors.New("--since must be a positive duration")
+			}
+			watch, err := cmd.Flags().GetBool("watch")
+			if err != nil {
+				return err
+			}
+			if watch {
+				if showConditions || since > 0 {
+					return errors.New("--watch can't be used with --conditions or --since")
+				}
+				return watchAppHealth(ctx, newClient, ioStreams, appName, env, cmd.Flag("svc").Value.String())
+			}
+			if showConditions {
+				return printAppConditions(ctx, newClient, ioStreams, appName, env, output, since)
+			}
+			if output != "" {
+				return errors.New("--output is only supported with --conditions")
+			}
+			return printAppStatus(ctx, newClient, ioStreams, appName, env, cmd, since)
 		},
 		Annotations: map[string]string{
 			types.TagCommandType: types.TypeApp,
 		},
 	}
 	cmd.Flags().StringP("svc", "s", "", "service name")
+	cmd.Flags().Bool("conditions", false, "print raw conditions of the application and its services")
+	cmd.Flags().StringP("output", "o", "", "output format of conditions, only support json")
+	cmd.Flags().Duration("since", 0, "only show services whose conditions changed within the duration, like 10m")
+	cmd.Flags().BoolP("watch", "w", false, "keep refreshing the health of services until all of them are healthy")
 	cmd.SetOut(ioStreams.Out)
 	return cmd
 }
 
-func printAppStatus(ctx context.Context, c client.Client, ioStreams cmdutil.IOStreams, appName string, env *types.EnvMeta, cmd *cobra.Command) error {
-	app, err := application.Load(env.Name, appName)
+// ResourceConditions holds the raw conditions of a resource
+type ResourceConditions struct {
+	Service    string                      `json:"service,omitempty"`
+	Kind       string                      `json:"kind"`
+	Name       string                      `json:"name"`
+	Conditions []runtimev1alpha1.Condition `json:"conditions"`
+}
+
+func printAppConditions(ctx context.Context, c client.Client, ioStreams cmdutil.IOStreams, appName string, env *types.EnvMeta, output string, since time.Duration) error {
+	_, appConfig, err := getApp(ctx, c, "", appName, env)
 	if err != nil {
 		return err
 	}
-	namespace := env.Name
+	conditions, err := getAppConditions(ctx, c, appConfig)
+	if err != nil {
+		return err
+	}
+	if since > 0 {
+		conditions = filterConditionsSince(conditions, time.Now().Add(-since))
+	}
+
+	switch output {
+	case "json":
+		b, err := json.MarshalIndent(conditions, "", "  ")
+		if err != nil {
+			return err
+		}
+		ioStreams.Info(string(b))
+	case "":
+		table := uitable.New()
+		table.MaxColWidth = 60
+		table.AddRow("SERVICE", "KIND", "NAME", "TYPE", "STATUS", "REASON", "MESSAGE", "LAST-TRANSITION")
+		for _, rc := range conditions {
+			for _, cond := range rc.Conditions {
+				table.AddRow(rc.Service, rc.Kind, rc.Name, cond.Type, cond.Status, cond.Reason, cond.Message,
+					cond.LastTransitionTime.Format(time.RFC3339))
+			}
+		}
+		ioStreams.Info(table.String())
+	default:
+		return fmt.Errorf("unsupported output format %s, only json is supported", output)
+	}
+	return nil
+}
+
+// getAppConditions collects raw conditions of the appConfig, workloads and traits of the application
+func getAppConditions(ctx context.Context, c client.Client, appConfig *v1alpha2.ApplicationConfiguration) ([]ResourceConditions, error) {
+	conditions := []ResourceConditions{{
+		Kind:       kindAppConfig,
+		Name:       appConfig.Name,
+		Conditions: appConfig.Status.Conditions,
+	}}
+	for _, wl := range appConfig.Status.Workloads {
+		refs := []runtimev1alpha1.TypedReference{wl.Reference}
+		for _, tr := range wl.Traits {
+			refs = append(refs, tr.Reference)
+		}
+		for _, ref := range refs {
+			u, err := oam2.GetUnstructured(ctx, c, appConfig.Namespace, ref)
+			if err != nil {
+				return nil, err
+			}
+			conds, err := oam2.GetConditionsFromObject(u)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, ResourceConditions{
+				Service:    wl.ComponentName,
+				Kind:       ref.Kind,
+				Name:       ref.Name,
+				Conditions: conds,
+			})
+		}
+	}
+	return conditions, nil
+}
+
+// filterConditionsSince keeps conditions which transitioned after the time, resources without such conditions are dropped
+func filterConditionsSince(conditions []ResourceConditions, after time.Time) []ResourceConditions {
+	var filtered []ResourceConditions
+	for _, rc := range conditions {
+		var conds []runtimev1alpha1.Condition
+		for _, cond := range rc.Conditions {
+			if cond.LastTransitionTime.Time.After(after) {
+				conds = append(conds, cond)
+			}
+		}
+		if len(conds) == 0 {
+			continue
+		}
+		rc.Conditions = conds
+		filtered = append(filtered, rc)
+	}
+	return filtered
+}
+
+// recentlyChangedServices returns services having conditions of workloads or traits transitioned after the time
+func recentlyChangedServices(ctx context.Context, c client.Client, appConfig *v1alpha2.ApplicationConfiguration, after time.Time) (map[string]bool, error) {
+	conditions, err := getAppConditions(ctx, c, appConfig)
+	if err != nil {
+		return nil, err
+	}
+	changed := make(map[string]bool)
+	for _, rc := range filterConditionsSince(conditions, after) {
+		if rc.Service != "" {
+			changed[rc.Service] = true
+		}
+	}
+	return changed, nil
+}
 
-	targetServices, err := oam2.GetServicesWhenDescribingApplication(cmd, app)
+func printAppStatus(ctx context.Context, c client.Client, ioStreams cmdutil.IOStreams, appName string, env *types.EnvMeta, cmd *cobra.Command, since time.Duration) error {
+	app, err := application.Load(env.Name, appName)
 	if err != nil {
 		return err
 	}
+	namespace := env.Name
+
+	var targetServices []string
+	if since > 0 && cmd.Flag("svc").Value.String() == "" {
+		// don't ask to choose one service, all recently changed services will be shown
+		targetServices = app.GetComponents()
+		sort.Strings(targetServices)
+	} else {
+		targetServices, err = oam2.GetServicesWhenDescribingApplication(cmd, app)
+		if err != nil {
+			return err
+		}
+	}
+	if since > 0 {
+		_, appConfig, err := getApp(ctx, c, "", appName, env)
+		if err != nil {
+			return err
+		}
+		changed, err := recentlyChangedServices(ctx, c, appConfig, time.Now().Add(-since))
+		if err != nil {
+			return err
+		}
+		var services []string
+		for _, svcName := range targetServices {
+			if changed[svcName] {
+				services = append(services, svcName)
+			}
+		}
+		targetServices = services
+	}
 
 	cmd.Printf("About:\n\n")
 	table := uitable.New()
@@ -157,6 +332,20 @@ func printAppStatus(ctx context.Context, c client.Client, ioStreams cmdutil.IOSt
 	cmd.Printf("%s\n\n", table.String())
 
 	cmd.Printf("Services:\n\n")
+	if since > 0 && len(targetServices) == 0 {
+		cmd.Printf("  No services changed in the last %s\n", since)
+		return nil
+	}
+
+	_, appConfig, err := getApp(ctx, c, "", appName, env)
+	if err != nil {
+		return err
+	}
+	health, err := oam2.GetServicesHealth(ctx, c, appConfig, targetServices)
+	if err != nil {
+		return err
+	}
+	cmd.Printf("%s\n\n", renderServicesHealth(health))
 
 	for _, svcName := range targetServices {
 		if err := printComponentStatus(ctx, c, ioStreams, svcName, appName, env); err != nil {
@@ -167,6 +356,52 @@ func printAppStatus(ctx context.Context, c client.Client, ioStreams cmdutil.IOSt
 	return nil
 }
 
+// watchAppHealth redraws the health of services every watchInterval until all of them are healthy,
+// all services of the application are watched if svcName is empty
+func watchAppHealth(ctx context.Context, c client.Client, ioStreams cmdutil.IOStreams, appName string, env *types.EnvMeta, svcName string) error {
+	app, err := application.Load(env.Name, appName)
+	if err != nil {
+		return err
+	}
+	services := app.GetComponents()
+	if svcName != "" {
+		if _, ok := app.Services[svcName]; !ok {
+			return fmt.Errorf(ErrServiceNotFound, svcName)
+		}
+		services = []string{svcName}
+	}
+	sort.Strings(services)
+	for {
+		_, appConfig, err := getApp(ctx, c, "", appName, env)
+		if err != nil {
+			return err
+		}
+		health, err := oam2.GetServicesHealth(ctx, c, appConfig, services)
+		if err != nil {
+			return err
+		}
+		// clear the screen and move the cursor to the top left before redrawing
+		ioStreams.Infonln("\033[H\033[2J")
+		ioStreams.Infof("Every %s: vela status %s -w\t%s\n\n", watchInterval, appName, time.Now().Format(time.RFC3339))
+		ioStreams.Info(renderServicesHealth(health))
+		if oam2.AllServicesHealthy(health) {
+			ioStreams.Info(green.Sprintf("\n%sAll services are healthy!", emojiSucceed))
+			return nil
+		}
+		time.Sleep(watchInterval)
+	}
+}
+
+func renderServicesHealth(health []apis.ServiceHealth) string {
+	table := uitable.New()
+	table.MaxColWidth = 80
+	table.AddRow("  SERVICE", "HEALTH", "REASON")
+	for _, h := range health {
+		table.AddRow("  "+h.Service, h.Health, h.Reason)
+	}
+	return table.String()
+}
+
 func printComponentStatus(ctx context.Context, c client.Client, ioStreams cmdutil.IOStreams, compName, appName string, env *types.EnvMeta) error {
 	app, appConfig, err := getApp(ctx, c, compName, appName, env)
 	if err != nil {
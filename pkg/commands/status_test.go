@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFilterConditionsSince(t *testing.T) {
+	now := time.Now()
+	old := runtimev1alpha1.Condition{Type: runtimev1alpha1.TypeSynced, LastTransitionTime: metav1.NewTime(now.Add(-time.Hour))}
+	recent := runtimev1alpha1.Condition{Type: runtimev1alpha1.TypeReady, LastTransitionTime: metav1.NewTime(now.Add(-time.Minute))}
+	conditions := []ResourceConditions{
+		{Service: "frontend", Kind: "Deployment", Name: "frontend", Conditions: []runtimev1alpha1.Condition{old, recent}},
+		{Service: "backend", Kind: "Deployment", Name: "backend", Conditions: []runtimev1alpha1.Condition{old}},
+	}
+
+	got := filterConditionsSince(conditions, now.Add(-10*time.Minute))
+	assert.Equal(t, []ResourceConditions{
+		{Service: "frontend", Kind: "Deployment", Name: "frontend", Conditions: []runtimev1alpha1.Condition{recent}},
+	}, got)
+
+	got = filterConditionsSince(conditions, now.Add(-2*time.Hour))
+	assert.Equal(t, conditions, got)
+
+	got = filterConditionsSince(conditions, now)
+	assert.Empty(t, got)
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/api/types"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+	"github.com/oam-dev/kubevela/pkg/oam"
+)
+
+// NewSuspendCommand suspends reconciling of an application
+func NewSuspendCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "suspend APP_NAME",
+		DisableFlagsInUseLine: true,
+		Short:                 "Suspend reconciling of an application",
+		Long:                  "Suspend reconciling of an application, all KubeVela controllers will skip it until resumed",
+		Annotations: map[string]string{
+			types.TagCommandType: types.TypeApp,
+		},
+		Example: "vela suspend frontend",
+	}
+	cmd.SetOut(ioStreams.Out)
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return setAppPaused(cmd, c, ioStreams, args, true)
+	}
+	return cmd
+}
+
+// NewResumeCommand resumes reconciling of a suspended application
+func NewResumeCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "resume APP_NAME",
+		DisableFlagsInUseLine: true,
+		Short:                 "Resume reconciling of a suspended application",
+		Long:                  "Resume reconciling of a suspended application",
+		Annotations: map[string]string{
+			types.TagCommandType: types.TypeApp,
+		},
+		Example: "vela resume frontend",
+	}
+	cmd.SetOut(ioStreams.Out)
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return setAppPaused(cmd, c, ioStreams, args, false)
+	}
+	return cmd
+}
+
+func setAppPaused(cmd *cobra.Command, c types.Args, ioStreams cmdutil.IOStreams, args []string, paused bool) error {
+	if len(args) < 1 {
+		return errors.New("must specify name for the app")
+	}
+	appName := args[0]
+	env, err := GetEnv(cmd)
+	if err != nil {
+		return err
+	}
+	newClient, err := client.New(c.Config, client.Options{Scheme: c.Schema})
+	if err != nil {
+		return err
+	}
+	if err := oam.SetApplicationPaused(context.Background(), newClient, appName, env.Namespace, paused); err != nil {
+		return err
+	}
+	if paused {
+		ioStreams.Infof("Application \"%s\" suspended\n", appName)
+	} else {
+		ioStreams.Infof("Application \"%s\" resumed\n", appName)
+	}
+	return nil
+}
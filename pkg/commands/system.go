@@ -6,15 +6,19 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/openservicemesh/osm/pkg/cli"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	kedav1alpha1 "github.com/wonderflow/keda-api/api/v1alpha1"
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/strvals"
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/oam-dev/kubevela/api/types"
@@ -32,6 +36,18 @@ const (
 	Error
 )
 
+// bundleDefinitionsDir is where built-in capability definitions are put in vela core chart
+const bundleDefinitionsDir = "templates/defwithtemplate/"
+
+// kedaChart is the KEDA chart compatible with the autoscale trait, which is the same as the one in vela-config of vela core chart
+var kedaChart = types.Chart{
+	Repo:      "kedacore",
+	URL:       "https://kedacore.github.io/charts",
+	Name:      types.DefaultKEDAChartName,
+	Namespace: types.DefaultKEDANS,
+	Version:   "2.0.0-rc3",
+}
+
 type initCmd struct {
 	namespace string
 	ioStreams cmdutil.IOStreams
@@ -40,6 +56,12 @@ type initCmd struct {
 	chartArgs chartArgs
 	waitReady string
 	c         types.Args
+	dryRun    bool
+	outputDir string
+	version   string
+	chartRepo string
+	bundle    string
+	withKEDA  bool
 }
 
 type chartArgs struct {
@@ -61,7 +83,7 @@ func SystemCommandGroup(c types.Args, ioStream cmdutil.IOStreams) *cobra.Command
 			types.TagCommandType: types.TypeSystem,
 		},
 	}
-	cmd.AddCommand(NewAdminInfoCommand(ioStream))
+	cmd.AddCommand(NewAdminInfoCommand(ioStream), NewSystemUpdateCommand(c, ioStream))
 	return cmd
 }
 
@@ -101,12 +123,27 @@ func NewInstallCommand(c types.Args, chartContent string, ioStreams cmdutil.IOSt
 		Short: "Install Vela Core with built-in capabilities",
 		Long:  "Install Vela Core with built-in capabilities",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			i.namespace = types.DefaultOAMNS
+			if i.bundle != "" {
+				if i.chartPath != "" || i.chartRepo != "" {
+					return errors.New("--bundle can't be used along with --vela-chart-path or --chart-repo")
+				}
+				if err := ValidateOfflineBundle(i.bundle); err != nil {
+					return err
+				}
+				i.chartPath = i.bundle
+			}
+			if i.dryRun {
+				return i.renderDryRun(ioStreams, chartContent)
+			}
+			if i.outputDir != "" {
+				return errors.New("--output-dir can only be used along with --dry-run")
+			}
 			newClient, err := client.New(c.Config, client.Options{Scheme: c.Schema})
 			if err != nil {
 				return err
 			}
 			i.client = newClient
-			i.namespace = types.DefaultOAMNS
 			i.c = c
 			return i.run(ioStreams, chartContent)
 		},
@@ -121,6 +158,12 @@ func NewInstallCommand(c types.Args, chartContent string, ioStreams cmdutil.IOSt
 	flag.StringVarP(&i.chartArgs.imageRepo, "image-repo", "", "oamdev/vela-core", "vela core image repo, this will align to chart value image.repo")
 	flag.StringVarP(&i.chartArgs.imageTag, "image-tag", "", "latest", "vela core image repo, this will align to chart value image.tag")
 	flag.StringVarP(&i.waitReady, "wait", "w", "0s", "wait until vela-core is ready to serve, default will not wait")
+	flag.StringVarP(&i.version, "version", "", "", "version of vela core chart to install, default to the built-in one")
+	flag.StringVarP(&i.chartRepo, "chart-repo", "", "", "url of the chart repository to download vela core chart from if the specified version is not built in")
+	flag.StringVarP(&i.bundle, "bundle", "", "", "path to a local directory or tarball of vela core chart along with built-in capabilities, nothing will be downloaded")
+	flag.BoolVar(&i.dryRun, "dry-run", false, "render manifests of vela core and built-in capabilities without installing them")
+	flag.StringVarP(&i.outputDir, "output-dir", "", "", "write the rendered manifests into this directory instead of printing them, only works with --dry-run")
+	flag.BoolVar(&i.withKEDA, "with-keda", false, "install KEDA along with vela core, which the autoscale trait depends on")
 
 	return cmd
 }
@@ -151,7 +194,12 @@ func (i *initCmd) run(ioStreams cmdutil.IOStreams, chartSource string) error {
 			i.ioStreams.Errorf("resolve values for vela-core chart err %v, will install with default values", err)
 			vals = make(map[string]interface{})
 		}
-		if err := InstallOamRuntime(i.chartPath, chartSource, vals, ioStreams); err != nil {
+		if err := InstallOamRuntime(i.chartRef(chartSource), vals, ioStreams); err != nil {
+			return err
+		}
+	}
+	if i.withKEDA {
+		if err := InstallKEDA(ioStreams); err != nil {
 			return err
 		}
 	}
@@ -161,6 +209,9 @@ func (i *initCmd) run(ioStreams cmdutil.IOStreams, chartSource string) error {
 	if err := RefreshDefinitions(context.Background(), i.c, ioStreams, false); err != nil {
 		return err
 	}
+	if !i.withKEDA {
+		i.warnIfKEDAMissing(context.Background())
+	}
 	ioStreams.Info("- Finished successfully.")
 
 	if waitDuration > 0 {
@@ -172,6 +223,45 @@ func (i *initCmd) run(ioStreams cmdutil.IOStreams, chartSource string) error {
 	return nil
 }
 
+// InstallKEDA installs the KEDA chart, which the autoscale trait depends on to scale by triggers other than cpu and memory
+func InstallKEDA(ioStreams cmdutil.IOStreams) error {
+	ioStreams.Info("- Installing KEDA Chart:")
+	if helm.IsHelmReleaseRunning(kedaChart.Name, kedaChart.Name, kedaChart.Namespace, ioStreams) {
+		ioStreams.Info("KEDA already exists.")
+		return nil
+	}
+	if err := helm.InstallHelmChart(ioStreams, kedaChart); err != nil {
+		ioStreams.Errorf("Failed to install KEDA chart %s with error: %+v\n", kedaChart.Version, err)
+		return err
+	}
+	return nil
+}
+
+// warnIfKEDAMissing warns rather than fails if the autoscale trait is synced but KEDA is absent, as autoscalers
+// fall back to HPA, which only supports cpu, memory and storage triggers
+func (i *initCmd) warnIfKEDAMissing(ctx context.Context) {
+	caps, err := plugins.LoadAllInstalledCapability()
+	if err != nil || !hasAutoscalerCapability(caps) {
+		return
+	}
+	err = i.client.List(ctx, &kedav1alpha1.ScaledObjectList{}, client.Limit(1))
+	if err == nil || !apimeta.IsNoMatchError(err) {
+		return
+	}
+	i.ioStreams.Info("WARN: KEDA is not installed, autoscale trait only supports cpu, memory and storage triggers " +
+		"without it, try `vela install --with-keda` to install it")
+}
+
+// hasAutoscalerCapability checks whether the trait backed by Autoscaler is among the capabilities
+func hasAutoscalerCapability(caps []types.Capability) bool {
+	for _, c := range caps {
+		if c.Type == types.TypeTrait && c.CrdInfo != nil && c.CrdInfo.Kind == kindAutoscaler {
+			return true
+		}
+	}
+	return false
+}
+
 // MUST wait to install capability succeed
 func CheckCapabilityReady(ctx context.Context, c types.Args, timeout time.Duration) error {
 	if timeout < 2*time.Minute {
@@ -217,21 +307,49 @@ func (i *initCmd) resolveValues() (map[string]interface{}, error) {
 	return finalValues, nil
 }
 
-func InstallOamRuntime(chartPath, chartSource string, vals map[string]interface{}, ioStreams cmdutil.IOStreams) error {
-	var err error
-	var chartRequested *chart.Chart
-	if chartPath != "" {
-		ioStreams.Infof("Use customized chart at: %s", chartPath)
-		chartRequested, err = loader.Load(chartPath)
-	} else {
-		chartRequested, err = cli.LoadChart(chartSource)
-		if chartRequested != nil {
-			m, l := chartRequested.Metadata, len(chartRequested.Raw)
-			ioStreams.Infof("install chart %s, version %s, desc : %s, contains %d file\n", m.Name, m.Version, m.Description, l)
-		}
+// renderDryRun renders the vela core chart, which contains the built-in capabilities, without touching the cluster
+func (i *initCmd) renderDryRun(ioStreams cmdutil.IOStreams, chartSource string) error {
+	vals, err := i.resolveValues()
+	if err != nil {
+		return err
+	}
+	manifest, err := RenderOamRuntime(i.chartRef(chartSource), vals, ioStreams)
+	if err != nil {
+		return err
+	}
+	if i.outputDir == "" {
+		ioStreams.Info("(dry-run) would install Vela Core Chart with the following manifests:")
+		ioStreams.Info(manifest)
+		return nil
+	}
+	if err := os.MkdirAll(i.outputDir, 0750); err != nil {
+		return err
+	}
+	file := filepath.Join(i.outputDir, types.DefaultOAMReleaseName+".yaml")
+	if err := ioutil.WriteFile(file, []byte(manifest), 0600); err != nil {
+		return err
+	}
+	ioStreams.Infof("(dry-run) would install Vela Core Chart, manifests are written to %s\n", file)
+	return nil
+}
+
+// RenderOamRuntime renders manifests of the vela core chart locally, CRDs included, just like `helm template`
+func RenderOamRuntime(ref OamRuntimeChartRef, vals map[string]interface{}, ioStreams cmdutil.IOStreams) (string, error) {
+	chartRequested, err := loadOamRuntimeChart(ref, ioStreams)
+	if err != nil {
+		return "", err
 	}
+	release, err := helm.NewHelmTemplate(types.DefaultOAMNS, types.DefaultOAMReleaseName).Run(chartRequested, vals)
+	if err != nil {
+		return "", fmt.Errorf("error rendering chart: %s", err)
+	}
+	return release.Manifest, nil
+}
+
+func InstallOamRuntime(ref OamRuntimeChartRef, vals map[string]interface{}, ioStreams cmdutil.IOStreams) error {
+	chartRequested, err := loadOamRuntimeChart(ref, ioStreams)
 	if err != nil {
-		return fmt.Errorf("error loading chart for installation: %s", err)
+		return err
 	}
 	installClient, err := helm.NewHelmInstall("", types.DefaultOAMNS, types.DefaultOAMReleaseName)
 	if err != nil {
@@ -248,18 +366,124 @@ func InstallOamRuntime(chartPath, chartSource string, vals map[string]interface{
 	return nil
 }
 
+// ValidateOfflineBundle checks the offline bundle is vela core chart, which contains CRDs of the runtime and built-in
+// capability definitions, so that it's found out before anything is installed
+func ValidateOfflineBundle(bundle string) error {
+	if _, err := os.Stat(bundle); err != nil {
+		return fmt.Errorf("bundle %s is not accessible: %v", bundle, err)
+	}
+	chartBundle, err := loader.Load(bundle)
+	if err != nil {
+		return fmt.Errorf("bundle %s should be a chart directory or tarball: %v", bundle, err)
+	}
+	if chartBundle.Name() != types.DefaultOAMRuntimeChartName {
+		return fmt.Errorf("bundle %s should contain chart %s rather than %s", bundle, types.DefaultOAMRuntimeChartName, chartBundle.Name())
+	}
+	if len(chartBundle.CRDs()) == 0 {
+		return fmt.Errorf("bundle %s has no CRDs, they should be put in directory crds", bundle)
+	}
+	for _, t := range chartBundle.Templates {
+		if strings.HasPrefix(t.Name, bundleDefinitionsDir) {
+			return nil
+		}
+	}
+	return fmt.Errorf("bundle %s has no built-in capability definitions, they should be put in directory %s", bundle, bundleDefinitionsDir)
+}
+
+// OamRuntimeChartRef locates the vela core chart to install
+type OamRuntimeChartRef struct {
+	// Path is a local chart directory or archive to override the built-in chart
+	Path string
+	// Source is the built-in chart, which is a base64-encoded, gzipped tarball
+	Source string
+	// Version pins the chart version, any version is accepted if it's empty
+	Version string
+	// Repo is the url of the chart repository to download the chart from if Version is not built in
+	Repo string
+}
+
+func (i *initCmd) chartRef(chartSource string) OamRuntimeChartRef {
+	return OamRuntimeChartRef{Path: i.chartPath, Source: chartSource, Version: i.version, Repo: i.chartRepo}
+}
+
+func loadOamRuntimeChart(ref OamRuntimeChartRef, ioStreams cmdutil.IOStreams) (*chart.Chart, error) {
+	var err error
+	var chartRequested *chart.Chart
+	switch {
+	case ref.Path != "":
+		ioStreams.Infof("Use customized chart at: %s", ref.Path)
+		chartRequested, err = loader.Load(ref.Path)
+	case ref.Repo != "" && ref.Version != "":
+		chartRequested, err = downloadOamRuntimeChart(ref.Repo, ref.Version)
+	default:
+		chartRequested, err = cli.LoadChart(ref.Source)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error loading chart for installation: %s", err)
+	}
+	m, l := chartRequested.Metadata, len(chartRequested.Raw)
+	if ref.Version != "" && m.Version != ref.Version {
+		return nil, fmt.Errorf("vela core chart version %s is not available, got version %s, "+
+			"specify a chart repository by --chart-repo or a local chart by --vela-chart-path", ref.Version, m.Version)
+	}
+	ioStreams.Infof("install chart %s, version %s, desc : %s, contains %d file\n", m.Name, m.Version, m.Description, l)
+	return chartRequested, nil
+}
+
+// downloadOamRuntimeChart downloads vela core chart of the version from the chart repository
+func downloadOamRuntimeChart(repoURL, version string) (*chart.Chart, error) {
+	if !helm.IsHelmRepositoryExist(types.DefaultOAMReleaseName, repoURL) {
+		if err := helm.AddHelmRepository(types.DefaultOAMReleaseName, repoURL,
+			"", "", "", "", "", false, ioutil.Discard); err != nil {
+			return nil, err
+		}
+	}
+	installClient, err := helm.NewHelmInstall(version, types.DefaultOAMNS, types.DefaultOAMReleaseName)
+	if err != nil {
+		return nil, err
+	}
+	chartRequested, err := helm.GetChart(installClient, types.DefaultOAMReleaseName+"/"+types.DefaultOAMRuntimeChartName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "vela core chart version %s is not available in %s", version, repoURL)
+	}
+	return chartRequested, nil
+}
+
 func GetOAMReleaseVersion(ns string) (string, error) {
-	results, err := helm.GetHelmRelease(ns)
+	c, err := getOAMReleaseChart(ns)
 	if err != nil {
 		return "", err
 	}
+	return c.AppVersion(), nil
+}
+
+// getOAMReleaseChart gets the vela core chart installed in cluster, whose version is what `vela install --version` pins
+func getOAMReleaseChart(ns string) (*chart.Chart, error) {
+	results, err := helm.GetHelmRelease(ns)
+	if err != nil {
+		return nil, err
+	}
 
 	for _, result := range results {
 		if result.Chart.ChartFullPath() == types.DefaultOAMRuntimeChartName {
+			return result.Chart, nil
+		}
+	}
+	return nil, errors.New("oam-kubernetes-runtime not found in your kubernetes cluster, try `vela install` to install")
+}
+
+func GetKEDAReleaseVersion(ns string) (string, error) {
+	results, err := helm.GetHelmRelease(ns)
+	if err != nil {
+		return "", err
+	}
+
+	for _, result := range results {
+		if result.Chart.Name() == types.DefaultKEDAChartName {
 			return result.Chart.AppVersion(), nil
 		}
 	}
-	return "", errors.New("oam-kubernetes-runtime not found in your kubernetes cluster, try `vela install` to install")
+	return "", errors.New("keda not found in your kubernetes cluster")
 }
 
 func PrintTrackVelaRuntimeStatus(ctx context.Context, c client.Client, ioStreams cmdutil.IOStreams, trackTimeout time.Duration) (bool, error) {
@@ -0,0 +1,224 @@
+package commands
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/oam-dev/kubevela/api/types"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+)
+
+func TestValidateOfflineBundle(t *testing.T) {
+	chartYaml := "apiVersion: v2\nname: vela-core\nversion: 0.1.0\n"
+	crd := "apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nmetadata:\n  name: autoscalers.standard.oam.dev\n"
+	definition := "apiVersion: core.oam.dev/v1alpha2\nkind: WorkloadDefinition\nmetadata:\n  name: webservice\n"
+	cases := map[string]struct {
+		files   map[string]string
+		wantErr string
+	}{
+		"valid bundle": {
+			files: map[string]string{
+				"Chart.yaml":                         chartYaml,
+				"crds/autoscalers.yaml":              crd,
+				"templates/defwithtemplate/web.yaml": definition,
+			},
+		},
+		"not a chart": {
+			files: map[string]string{
+				"crds/autoscalers.yaml": crd,
+			},
+			wantErr: "should be a chart directory or tarball",
+		},
+		"another chart": {
+			files: map[string]string{
+				"Chart.yaml":            "apiVersion: v2\nname: keda\nversion: 0.1.0\n",
+				"crds/autoscalers.yaml": crd,
+			},
+			wantErr: "should contain chart vela-core rather than keda",
+		},
+		"no CRDs": {
+			files: map[string]string{
+				"Chart.yaml":                         chartYaml,
+				"templates/defwithtemplate/web.yaml": definition,
+			},
+			wantErr: "has no CRDs",
+		},
+		"no definitions": {
+			files: map[string]string{
+				"Chart.yaml":            chartYaml,
+				"crds/autoscalers.yaml": crd,
+			},
+			wantErr: "has no built-in capability definitions",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "bundle")
+			assert.NoError(t, err)
+			defer os.RemoveAll(dir)
+			for file, content := range tc.files {
+				path := filepath.Join(dir, file)
+				assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+				assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+			}
+			err = ValidateOfflineBundle(dir)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+
+	err := ValidateOfflineBundle(filepath.Join(os.TempDir(), "not-exist-bundle"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "is not accessible")
+}
+
+func TestHasAutoscalerCapability(t *testing.T) {
+	autoscale := types.Capability{Name: "autoscale", Type: types.TypeTrait,
+		CrdInfo: &types.CrdInfo{APIVersion: "standard.oam.dev/v1alpha1", Kind: "Autoscaler"}}
+	scaler := types.Capability{Name: "scaler", Type: types.TypeTrait,
+		CrdInfo: &types.CrdInfo{APIVersion: "core.oam.dev/v1alpha2", Kind: "ManualScalerTrait"}}
+	webservice := types.Capability{Name: "webservice", Type: types.TypeWorkload}
+
+	assert.True(t, hasAutoscalerCapability([]types.Capability{webservice, scaler, autoscale}))
+	assert.False(t, hasAutoscalerCapability([]types.Capability{webservice, scaler}))
+	assert.False(t, hasAutoscalerCapability(nil))
+}
+
+// writeTestChart writes the files of a chart into a temporary directory
+func writeTestChart(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "chart")
+	assert.NoError(t, err)
+	for file, content := range files {
+		path := filepath.Join(dir, file)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	}
+	return dir
+}
+
+func TestRenderDryRun(t *testing.T) {
+	chartDir := writeTestChart(t, map[string]string{
+		"Chart.yaml":            "apiVersion: v2\nname: vela-core\nversion: 0.1.0\n",
+		"crds/autoscalers.yaml": "apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nmetadata:\n  name: autoscalers.standard.oam.dev\n",
+		"templates/deploy.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: vela-core\ndata:\n  image: {{ .Values.image.repository }}:{{ .Values.image.tag }}\n",
+	})
+	defer os.RemoveAll(chartDir)
+
+	cases := map[string]struct {
+		outputDir   bool
+		expContains []string
+	}{
+		"print manifests": {
+			expContains: []string{
+				"(dry-run) would install Vela Core Chart with the following manifests:",
+				"image: oamdev/vela-core:v1",
+				"name: autoscalers.standard.oam.dev",
+			},
+		},
+		"write manifests into the output dir": {
+			outputDir:   true,
+			expContains: []string{"(dry-run) would install Vela Core Chart, manifests are written to"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			i := &initCmd{
+				chartPath: chartDir,
+				chartArgs: chartArgs{imageRepo: "oamdev/vela-core", imageTag: "v1", imagePullPolicy: "IfNotPresent"},
+			}
+			if tc.outputDir {
+				dir, err := ioutil.TempDir("", "dry-run")
+				assert.NoError(t, err)
+				defer os.RemoveAll(dir)
+				i.outputDir = filepath.Join(dir, "manifests")
+			}
+			var b bytes.Buffer
+			assert.NoError(t, i.renderDryRun(cmdutil.IOStreams{Out: &b}, ""))
+			for _, s := range tc.expContains {
+				assert.Contains(t, b.String(), s)
+			}
+			if !tc.outputDir {
+				return
+			}
+			manifest, err := ioutil.ReadFile(filepath.Join(i.outputDir, types.DefaultOAMReleaseName+".yaml"))
+			assert.NoError(t, err)
+			assert.Contains(t, string(manifest), "image: oamdev/vela-core:v1")
+			assert.Contains(t, string(manifest), "name: autoscalers.standard.oam.dev")
+		})
+	}
+}
+
+func TestLoadOamRuntimeChart(t *testing.T) {
+	chartDir := writeTestChart(t, map[string]string{
+		"Chart.yaml": "apiVersion: v2\nname: vela-core\nversion: 0.2.0\n",
+	})
+	defer os.RemoveAll(chartDir)
+
+	cases := map[string]struct {
+		ref        OamRuntimeChartRef
+		expVersion string
+		expErr     string
+	}{
+		"any version of the local chart": {
+			ref:        OamRuntimeChartRef{Path: chartDir},
+			expVersion: "0.2.0",
+		},
+		"pinned version matches the local chart": {
+			ref:        OamRuntimeChartRef{Path: chartDir, Version: "0.2.0"},
+			expVersion: "0.2.0",
+		},
+		"pinned version not available": {
+			ref:    OamRuntimeChartRef{Path: chartDir, Version: "0.3.0"},
+			expErr: "vela core chart version 0.3.0 is not available, got version 0.2.0",
+		},
+		"local chart not found": {
+			ref:    OamRuntimeChartRef{Path: filepath.Join(chartDir, "not-exist")},
+			expErr: "error loading chart for installation",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var b bytes.Buffer
+			chrt, err := loadOamRuntimeChart(tc.ref, cmdutil.IOStreams{Out: &b})
+			if tc.expErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expVersion, chrt.Metadata.Version)
+			assert.Contains(t, b.String(), "version "+tc.expVersion)
+		})
+	}
+}
+
+func TestChartRef(t *testing.T) {
+	cases := map[string]struct {
+		cmd    initCmd
+		expRef OamRuntimeChartRef
+	}{
+		"built-in chart": {
+			expRef: OamRuntimeChartRef{Source: "built-in"},
+		},
+		"pinned version from the chart repository": {
+			cmd:    initCmd{version: "0.3.0", chartRepo: "https://charts.example.com"},
+			expRef: OamRuntimeChartRef{Source: "built-in", Version: "0.3.0", Repo: "https://charts.example.com"},
+		},
+		"local chart": {
+			cmd:    initCmd{chartPath: "./charts/vela-core"},
+			expRef: OamRuntimeChartRef{Path: "./charts/vela-core", Source: "built-in"},
+		},
+	}
+	for name, tc := range cases {
+		assert.Equal(t, tc.expRef, tc.cmd.chartRef("built-in"), name)
+	}
+}
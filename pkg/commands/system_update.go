@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam/discoverymapper"
+	"github.com/fatih/color"
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/api/types"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/plugins"
+)
+
+// DefaultRegistryName is the capability center name of the registry specified by url in `vela system update`
+const DefaultRegistryName = "registry"
+
+func NewSystemUpdateCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update [NAME...]",
+		Short: "Sync capability definitions from a registry into the cluster",
+		Long: "Sync capability definitions from a registry into the cluster, the registry could be the name of a capability center " +
+			"or an url. Definitions installed from the registry before but no longer existing in it are removed. " +
+			"Only the named definitions are synced if any name is specified.",
+		Example: `vela system update --from-registry https://github.com/oam-dev/catalog/tree/master/registry
+vela system update route --type trait --from-registry https://github.com/oam-dev/catalog/tree/master/registry`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			registry, err := cmd.Flags().GetString("from-registry")
+			if err != nil {
+				return err
+			}
+			if registry == "" {
+				return errors.New("must specify the registry by --from-registry")
+			}
+			capType, err := cmd.Flags().GetString("type")
+			if err != nil {
+				return err
+			}
+			filter := oam.CapabilityFilter{Names: args, Type: types.CapType(capType)}
+			if filter.Type != "" && filter.Type != types.TypeWorkload && filter.Type != types.TypeTrait {
+				return fmt.Errorf("unsupported type %s, only workload and trait are supported", capType)
+			}
+			token, err := cmd.Flags().GetString("token")
+			if err != nil {
+				return err
+			}
+			newClient, err := client.New(c.Config, client.Options{Scheme: c.Schema})
+			if err != nil {
+				return err
+			}
+			mapper, err := discoverymapper.New(c.Config)
+			if err != nil {
+				return err
+			}
+			dryRun, err := cmd.Flags().GetBool("dry-run")
+			if err != nil {
+				return err
+			}
+			centerName, err := syncRegistry(registry, token, dryRun)
+			if err != nil {
+				return err
+			}
+			report, err := oam.UpdateCapabilitiesFromCenter(context.Background(), newClient, mapper, centerName, filter, dryRun, ioStreams)
+			if err != nil {
+				return err
+			}
+			printUpdateReport(ioStreams, report, dryRun)
+			return nil
+		},
+		Annotations: map[string]string{
+			types.TagCommandType: types.TypeSystem,
+		},
+	}
+	cmd.Flags().String("from-registry", "", "name of a capability center or url of the registry to sync capability definitions from")
+	cmd.Flags().StringP("token", "t", "", "Github Repo token")
+	cmd.Flags().String("type", "", "only sync definitions of the type, workload or trait")
+	cmd.Flags().Bool("dry-run", false, "only report how definitions would change, without applying them into the cluster")
+	return cmd
+}
+
+// syncRegistry syncs definitions from the capability center with the name, or from the url as the default registry,
+// the url isn't saved as a capability center if dryRun is true
+func syncRegistry(registry, token string, dryRun bool) (string, error) {
+	repos, err := plugins.LoadRepos()
+	if err != nil {
+		return "", err
+	}
+	for _, r := range repos {
+		if r.Name == registry {
+			if token == "" {
+				token = r.Token
+			}
+			return r.Name, oam.SyncCapabilityFromCenter(r.Name, r.Address, token)
+		}
+	}
+	if dryRun {
+		return DefaultRegistryName, oam.SyncCapabilityFromCenter(DefaultRegistryName, registry, token)
+	}
+	return DefaultRegistryName, oam.AddCapabilityCenter(DefaultRegistryName, registry, token)
+}
+
+// printUpdateReport prints the status of each definition, which is ADDED, UPDATED, UNCHANGED or REMOVED
+func printUpdateReport(ioStreams cmdutil.IOStreams, report oam.CapabilityUpdateReport, dryRun bool) {
+	summary := fmt.Sprintf("Add(%s) Update(%s) Delete(%s) Unchanged(%d)",
+		green.Sprint(len(report.Added)),
+		yellow.Sprint(len(report.Updated)),
+		red.Sprint(len(report.Removed)),
+		len(report.Unchanged))
+	if dryRun {
+		ioStreams.Infof("(dry-run) capabilities would be updated %s\n\n", summary)
+	} else {
+		ioStreams.Infof("Update capabilities successfully %s%s\n\n", emojiSucceed, summary)
+	}
+	table := uitable.New()
+	table.AddRow("NAME", "CATEGORY", "STATUS", "DESCRIPTION")
+	for _, row := range []struct {
+		status string
+		color  *color.Color
+		caps   []types.Capability
+	}{
+		{"ADDED", green, report.Added},
+		{"UPDATED", yellow, report.Updated},
+		{"REMOVED", red, report.Removed},
+		{"UNCHANGED", white, report.Unchanged},
+	} {
+		for _, cap := range row.caps {
+			table.AddRow(cap.Name, cap.Type, row.color.Sprint(row.status), cap.Description)
+		}
+	}
+	ioStreams.Info(table.String())
+}
@@ -80,6 +80,10 @@ func AddTraitCommands(parentCmd *cobra.Command, c types.Args, ioStreams cmdutil.
 		pluginCmd.Flags().StringP(Service, "", "", "specify one service belonging to the application")
 		pluginCmd.Flags().BoolP(Staging, "s", false, "only save changes locally without real update application")
 		pluginCmd.Flags().BoolP(TraitDetach, "", false, "detach trait from service")
+		if name == "autoscale" {
+			oam.AddAutoscaleCronFlags(pluginCmd.Flags())
+			pluginCmd.AddCommand(NewAutoscaleAuthCommand(c, ioStreams))
+		}
 
 		parentCmd.AddCommand(pluginCmd)
 	}
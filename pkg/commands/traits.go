@@ -2,14 +2,21 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
 	"strings"
 
-	"github.com/oam-dev/kubevela/api/types"
-	"github.com/oam-dev/kubevela/pkg/oam"
-
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
 	"github.com/gosuri/uitable"
-	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/api/types"
+	"github.com/oam-dev/kubevela/pkg/application"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+	"github.com/oam-dev/kubevela/pkg/oam"
 )
 
 func NewTraitsCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
@@ -56,3 +63,166 @@ func printTraitList(workloadName *string, ioStreams cmdutil.IOStreams) error {
 	ioStreams.Info(table.String())
 	return nil
 }
+
+// NewTraitCommand manages traits
+func NewTraitCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "trait",
+		DisableFlagsInUseLine: true,
+		Short:                 "Manage traits",
+		Long:                  "Manage traits",
+		Annotations: map[string]string{
+			types.TagCommandType: types.TypeCap,
+		},
+	}
+	cmd.SetOut(ioStreams.Out)
+	cmd.AddCommand(
+		NewTraitListCommand(c, ioStreams),
+		NewTraitDetachCommand(c, ioStreams),
+	)
+	return cmd
+}
+
+// NewTraitListCommand lists the TraitDefinitions installed in the cluster
+func NewTraitListCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
+	ctx := context.Background()
+	cmd := &cobra.Command{
+		Use:                   "ls",
+		Aliases:               []string{"list"},
+		DisableFlagsInUseLine: true,
+		Short:                 "List traits installed in the cluster",
+		Long:                  "List traits installed in the cluster, the alias is the name to attach or detach the trait.",
+		Example:               `vela trait ls`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return err
+			}
+			if output != "" && output != "json" {
+				return fmt.Errorf("unsupported output format %s, only json is supported", output)
+			}
+			newClient, err := client.New(c.Config, client.Options{Scheme: c.Schema})
+			if err != nil {
+				return err
+			}
+			var traitDefs v1alpha2.TraitDefinitionList
+			if err := newClient.List(ctx, &traitDefs, client.InNamespace(types.DefaultOAMNS)); err != nil {
+				return fmt.Errorf("list TraitDefinition err: %s", err)
+			}
+			return printInstalledTraits(toTraitListItems(traitDefs.Items), output, ioStreams)
+		},
+		Annotations: map[string]string{
+			types.TagCommandType: types.TypeCap,
+		},
+	}
+	cmd.SetOut(ioStreams.Out)
+	cmd.Flags().StringP("output", "o", "", "output format of traits, only support json")
+	return cmd
+}
+
+// NewTraitDetachCommand detaches a trait from a service of an application
+func NewTraitDetachCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
+	ctx := context.Background()
+	cmd := &cobra.Command{
+		Use:                   "detach TRAIT_ALIAS APP_NAME",
+		DisableFlagsInUseLine: true,
+		Short:                 "Detach a trait from a service of an application",
+		Long:                  "Detach a trait from a service of an application, and apply the change",
+		Example:               `vela trait detach scaler frontend --svc frontend`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return errors.New("please specify the trait alias and the name of the app")
+			}
+			o := &commandOptions{IOStreams: ioStreams, traitType: args[0], Detach: true}
+			newClient, err := client.New(c.Config, client.Options{Scheme: c.Schema})
+			if err != nil {
+				return err
+			}
+			o.Client = newClient
+			o.Env, err = GetEnv(cmd)
+			if err != nil {
+				return err
+			}
+			if err := o.Prepare(cmd, args[1:]); err != nil {
+				return err
+			}
+			if o.app, err = application.Load(o.Env.Name, o.appName); err != nil {
+				return err
+			}
+			traits, err := o.app.GetTraits(o.workloadName)
+			if err != nil {
+				return err
+			}
+			if _, ok := traits[o.traitType]; !ok {
+				return fmt.Errorf("trait %s is not attached to service %s of app %s", o.traitType, o.workloadName, o.appName)
+			}
+			if err := o.app.RemoveTrait(o.workloadName, o.traitType); err != nil {
+				return err
+			}
+			if err := o.app.Save(o.Env.Name); err != nil {
+				return err
+			}
+			ioStreams.Infof("Removing %s for app %s\n", o.traitType, o.appName)
+			if _, err := oam.TraitOperationRun(ctx, o.Client, o.Env, o.app, false, ioStreams); err != nil {
+				return err
+			}
+			ioStreams.Info("Succeeded!")
+			return nil
+		},
+		Annotations: map[string]string{
+			types.TagCommandType: types.TypeCap,
+		},
+	}
+	cmd.SetOut(ioStreams.Out)
+	cmd.Flags().StringP(Service, "", "", "specify one service belonging to the application")
+	return cmd
+}
+
+// TraitListItem is the schema of a trait printed by `vela trait ls -o json`
+type TraitListItem struct {
+	Name        string   `json:"name"`
+	Alias       string   `json:"alias"`
+	AppliesTo   []string `json:"appliesTo"`
+	Description string   `json:"description"`
+}
+
+// toTraitListItems converts TraitDefinitions into items sorted by alias, the name is the trait CRD the definition
+// refers to, while the alias is the name of the definition which users attach traits by
+func toTraitListItems(traitDefs []v1alpha2.TraitDefinition) []TraitListItem {
+	items := []TraitListItem{}
+	for _, td := range traitDefs {
+		appliesTo := td.Spec.AppliesToWorkloads
+		if appliesTo == nil {
+			appliesTo = []string{}
+		}
+		items = append(items, TraitListItem{
+			Name:        td.Spec.Reference.Name,
+			Alias:       td.Name,
+			AppliesTo:   appliesTo,
+			Description: td.Annotations[types.AnnDescription],
+		})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Alias < items[j].Alias
+	})
+	return items
+}
+
+func printInstalledTraits(items []TraitListItem, output string, ioStreams cmdutil.IOStreams) error {
+	if output == "json" {
+		b, err := json.MarshalIndent(items, "", "  ")
+		if err != nil {
+			return err
+		}
+		ioStreams.Info(string(b))
+		return nil
+	}
+	table := uitable.New()
+	table.MaxColWidth = 60
+	table.AddRow("NAME", "ALIAS", "APPLIES-TO", "DESCRIPTION")
+	for _, item := range items {
+		table.AddRow(item.Name, item.Alias, strings.Join(item.AppliesTo, ", "), item.Description)
+	}
+	ioStreams.Info(table.String())
+	return nil
+}
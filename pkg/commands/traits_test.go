@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/gosuri/uitable"
 
@@ -90,3 +92,52 @@ func Test_printTraitList(t *testing.T) {
 		assert.NoError(t, printTraitList(&nn, iostream))
 	}
 }
+
+func TestPrintInstalledTraits(t *testing.T) {
+	traitDefs := []v1alpha2.TraitDefinition{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "scaler", Annotations: map[string]string{types.AnnDescription: "Manually scale the app"}},
+			Spec: v1alpha2.TraitDefinitionSpec{
+				Reference:          v1alpha2.DefinitionReference{Name: "manualscalertraits.core.oam.dev"},
+				AppliesToWorkloads: []string{"webservice", "worker"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "route"},
+			Spec: v1alpha2.TraitDefinitionSpec{
+				Reference: v1alpha2.DefinitionReference{Name: "routes.standard.oam.dev"},
+			},
+		},
+	}
+	items := toTraitListItems(traitDefs)
+	assert.Equal(t, []TraitListItem{
+		{Name: "routes.standard.oam.dev", Alias: "route", AppliesTo: []string{}},
+		{Name: "manualscalertraits.core.oam.dev", Alias: "scaler", AppliesTo: []string{"webservice", "worker"},
+			Description: "Manually scale the app"},
+	}, items)
+
+	b := bytes.Buffer{}
+	assert.NoError(t, printInstalledTraits(items, "json", cmdutil.IOStreams{Out: &b}))
+	assert.Equal(t, `[
+  {
+    "name": "routes.standard.oam.dev",
+    "alias": "route",
+    "appliesTo": [],
+    "description": ""
+  },
+  {
+    "name": "manualscalertraits.core.oam.dev",
+    "alias": "scaler",
+    "appliesTo": [
+      "webservice",
+      "worker"
+    ],
+    "description": "Manually scale the app"
+  }
+]
+`, b.String())
+
+	b.Reset()
+	assert.NoError(t, printInstalledTraits(toTraitListItems(nil), "json", cmdutil.IOStreams{Out: &b}))
+	assert.Equal(t, "[]\n", b.String())
+}
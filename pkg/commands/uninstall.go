@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/api/types"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
+	"github.com/oam-dev/kubevela/pkg/utils/helm"
+)
+
+type uninstallCmd struct {
+	client    client.Client
+	ioStreams cmdutil.IOStreams
+	force     bool
+	assumeYes bool
+}
+
+func NewUninstallCommand(c types.Args, ioStreams cmdutil.IOStreams) *cobra.Command {
+	u := &uninstallCmd{ioStreams: ioStreams}
+	cmd := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Uninstall Vela Core along with built-in capabilities",
+		Long: "Uninstall Vela Core along with built-in capabilities, it refuses to delete capabilities still used by " +
+			"applications unless --force is specified.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			newClient, err := client.New(c.Config, client.Options{Scheme: c.Schema})
+			if err != nil {
+				return err
+			}
+			u.client = newClient
+			return u.run(context.Background())
+		},
+		Annotations: map[string]string{
+			types.TagCommandType: types.TypeStart,
+		},
+	}
+	cmd.Flags().BoolVarP(&u.force, "force", "f", false, "uninstall even if capabilities are still used by applications")
+	cmd.Flags().BoolVarP(&u.assumeYes, "yes", "y", false, "uninstall without confirmation")
+	return cmd
+}
+
+func (u *uninstallCmd) run(ctx context.Context) error {
+	var workloadDefs v1alpha2.WorkloadDefinitionList
+	if err := u.client.List(ctx, &workloadDefs, client.InNamespace(types.DefaultOAMNS)); err != nil {
+		return fmt.Errorf("list WorkloadDefinition err: %s", err)
+	}
+	var traitDefs v1alpha2.TraitDefinitionList
+	if err := u.client.List(ctx, &traitDefs, client.InNamespace(types.DefaultOAMNS)); err != nil {
+		return fmt.Errorf("list TraitDefinition err: %s", err)
+	}
+
+	var comps v1alpha2.ComponentList
+	if err := u.client.List(ctx, &comps); err != nil {
+		return err
+	}
+	var appConfigs v1alpha2.ApplicationConfigurationList
+	if err := u.client.List(ctx, &appConfigs); err != nil {
+		return err
+	}
+	workloadRefs, traitRefs := getCapabilityReferences(comps.Items, appConfigs.Items)
+	var inUse []string
+	for _, wd := range workloadDefs.Items {
+		if apps := workloadRefs[wd.Name]; len(apps) > 0 {
+			inUse = append(inUse, fmt.Sprintf("workload %s is used by %s", wd.Name, strings.Join(apps, ", ")))
+		}
+	}
+	for _, td := range traitDefs.Items {
+		if apps := traitRefs[td.Name]; len(apps) > 0 {
+			inUse = append(inUse, fmt.Sprintf("trait %s is used by %s", td.Name, strings.Join(apps, ", ")))
+		}
+	}
+	if len(inUse) > 0 {
+		if !u.force {
+			return fmt.Errorf("capabilities are still used by applications, delete them first or use --force:\n  %s",
+				strings.Join(inUse, "\n  "))
+		}
+		u.ioStreams.Infof("WARN: uninstall capabilities still in use:\n  %s\n", strings.Join(inUse, "\n  "))
+	}
+
+	if !u.assumeYes {
+		confirmed, err := cmdutil.AskToConfirm("Uninstall Vela Core along with built-in capabilities?")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return nil
+		}
+	}
+
+	u.ioStreams.Info("- Uninstalling builtin capabilities:")
+	for i := range workloadDefs.Items {
+		if err := u.client.Delete(ctx, &workloadDefs.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		u.ioStreams.Infof("deleted workload %s\n", workloadDefs.Items[i].Name)
+	}
+	for i := range traitDefs.Items {
+		if err := u.client.Delete(ctx, &traitDefs.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		u.ioStreams.Infof("deleted trait %s\n", traitDefs.Items[i].Name)
+	}
+
+	u.ioStreams.Info("- Uninstalling Vela Core Chart:")
+	if err := helm.Uninstall(u.ioStreams, types.DefaultOAMRuntimeChartName, types.DefaultOAMNS, types.DefaultOAMReleaseName); err != nil {
+		return err
+	}
+	u.ioStreams.Info("- Finished successfully.")
+	return nil
+}
+
+// getCapabilityReferences maps the workload and trait types to the applications using them,
+// the types are found by labels vela sets on the components and traits when deploying applications
+func getCapabilityReferences(comps []v1alpha2.Component,
+	appConfigs []v1alpha2.ApplicationConfiguration) (map[string][]string, map[string][]string) {
+	compWorkloadTypes := make(map[string]string)
+	for _, comp := range comps {
+		compWorkloadTypes[comp.Namespace+"/"+comp.Name] = comp.Labels[oam.WorkloadTypeLabel]
+	}
+	workloadRefs := make(map[string][]string)
+	traitRefs := make(map[string][]string)
+	for _, ac := range appConfigs {
+		app := ac.Namespace + "/" + ac.Name
+		for _, c := range ac.Spec.Components {
+			if workloadType := compWorkloadTypes[ac.Namespace+"/"+c.ComponentName]; workloadType != "" {
+				workloadRefs[workloadType] = appendIfMissing(workloadRefs[workloadType], app)
+			}
+			for _, t := range c.Traits {
+				var tr unstructured.Unstructured
+				if err := tr.UnmarshalJSON(t.Trait.Raw); err != nil {
+					continue
+				}
+				if traitType := tr.GetLabels()[oam.TraitTypeLabel]; traitType != "" {
+					traitRefs[traitType] = appendIfMissing(traitRefs[traitType], app)
+				}
+			}
+		}
+	}
+	for _, refs := range []map[string][]string{workloadRefs, traitRefs} {
+		for _, apps := range refs {
+			sort.Strings(apps)
+		}
+	}
+	return workloadRefs, traitRefs
+}
+
+func appendIfMissing(list []string, s string) []string {
+	for _, e := range list {
+		if e == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
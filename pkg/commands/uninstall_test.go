@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetCapabilityReferences(t *testing.T) {
+	comps := []v1alpha2.Component{
+		{ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "default",
+			Labels: map[string]string{oam.WorkloadTypeLabel: "webservice"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "default",
+			Labels: map[string]string{oam.WorkloadTypeLabel: "worker"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "test",
+			Labels: map[string]string{oam.WorkloadTypeLabel: "webservice"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "raw", Namespace: "test"}},
+	}
+	scaleTrait := runtime.RawExtension{Raw: []byte(`{"apiVersion":"core.oam.dev/v1alpha2","kind":"ManualScalerTrait",` +
+		`"metadata":{"labels":{"trait.oam.dev/type":"scaler"}}}`)}
+	appConfigs := []v1alpha2.ApplicationConfiguration{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "myapp", Namespace: "default"},
+			Spec: v1alpha2.ApplicationConfigurationSpec{Components: []v1alpha2.ApplicationConfigurationComponent{
+				{ComponentName: "frontend", Traits: []v1alpha2.ComponentTrait{{Trait: scaleTrait}}},
+				{ComponentName: "backend", Traits: []v1alpha2.ComponentTrait{{Trait: scaleTrait}}},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "myapp", Namespace: "test"},
+			Spec: v1alpha2.ApplicationConfigurationSpec{Components: []v1alpha2.ApplicationConfigurationComponent{
+				{ComponentName: "frontend", Traits: []v1alpha2.ComponentTrait{
+					{Trait: runtime.RawExtension{Raw: []byte(`{"kind":"Route"}`)}},
+				}},
+				{ComponentName: "raw"},
+			}},
+		},
+	}
+	workloadRefs, traitRefs := getCapabilityReferences(comps, appConfigs)
+	assert.Equal(t, map[string][]string{
+		"webservice": {"default/myapp", "test/myapp"},
+		"worker":     {"default/myapp"},
+	}, workloadRefs)
+	assert.Equal(t, map[string][]string{
+		"scaler": {"default/myapp"},
+	}, traitRefs)
+}
@@ -17,6 +17,21 @@ const (
 	DefaultErrorExitCode = 1
 )
 
+// ExitError is returned by commands which exit with a specific code on the error
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error which causes the exit
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
 func Print(msg string) {
 	if klog.V(2) {
 		klog.FatalDepth(2, msg)
@@ -90,3 +105,15 @@ func AskToChooseOneService(svcNames []string) (string, error) {
 	}
 	return svcName, nil
 }
+
+// AskToConfirm will ask users to confirm an action, it returns false if users decline it
+func AskToConfirm(message string) (bool, error) {
+	prompt := &survey.Confirm{
+		Message: message,
+	}
+	confirmed := false
+	if err := survey.AskOne(prompt, &confirmed); err != nil {
+		return false, fmt.Errorf("confirming err %v", err)
+	}
+	return confirmed, nil
+}
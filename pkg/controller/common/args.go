@@ -0,0 +1,40 @@
+package common
+
+// Args is the args for all vela controllers
+type Args struct {
+	// AutoscalerTargetKinds is the list of workload kinds that autoscaler could choose as scale target,
+	// the former kind in the list has higher priority
+	AutoscalerTargetKinds []string
+	// AutoscalerOwnerRefKinds is the list of child resource kinds that autoscaler will set itself as an owner of,
+	// resources of other kinds are left untouched
+	AutoscalerOwnerRefKinds []string
+	// AutoscalerManageOwnerRefs indicates whether autoscaler sets itself as an owner of the child resources of
+	// AutoscalerOwnerRefKinds, disable it if owner references are managed by other mechanisms
+	AutoscalerManageOwnerRefs bool
+	// AutoscalerDiscoverScalable indicates whether autoscaler discovers the scale subresource of workloads,
+	// so that kinds without it are skipped and any child resource exposing it could be chosen as scale target
+	AutoscalerDiscoverScalable bool
+	// AutoscalerRequireKEDA indicates whether autoscaler fails to start if the KEDA ScaledObject CRD is missing,
+	// otherwise it starts in the HPA fallback mode, which only serves cpu, memory and storage triggers until KEDA is installed
+	AutoscalerRequireKEDA bool
+	// AutoscalerPropagatedKeys is the list of label and annotation keys of the autoscaler and its parent AppConfig
+	// which are copied onto the ScaledObject, a key ending with `*` matches the keys with its prefix
+	AutoscalerPropagatedKeys []string
+	// AutoscalerExcludedKeys is the list of label and annotation keys which are never copied onto the ScaledObject,
+	// it takes precedence over AutoscalerPropagatedKeys, so that system keys are kept out of the tagging policy
+	AutoscalerExcludedKeys []string
+}
+
+// DefaultAutoscalerTargetKinds is the default workload kinds that autoscaler could scale
+var DefaultAutoscalerTargetKinds = []string{"Rollout", "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet"}
+
+// DefaultAutoscalerOwnerRefKinds is the default child resource kinds that autoscaler will set owner reference to
+var DefaultAutoscalerOwnerRefKinds = []string{"Deployment", "StatefulSet"}
+
+// DefaultAutoscalerPropagatedKeys propagates all labels and annotations to the ScaledObject except the excluded ones
+var DefaultAutoscalerPropagatedKeys = []string{"*"}
+
+// DefaultAutoscalerExcludedKeys are the system labels and annotations which don't describe the ScaledObject, like the
+// last applied configuration of kubectl and the OAM labels of the trait
+var DefaultAutoscalerExcludedKeys = []string{"kubectl.kubernetes.io/*", "kubernetes.io/*", "trait.oam.dev/*",
+	"workload.oam.dev/*", "app.oam.dev/resourceType", "app.oam.dev/revision"}
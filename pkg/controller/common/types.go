@@ -2,10 +2,24 @@ package common
 
 import (
 	"reflect"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/oam-dev/kubevela/api/types"
 )
 
 var ServiceKind = reflect.TypeOf(v1.Service{}).Name()
 
 var ServiceAPIVersion = v1.SchemeGroupVersion.String()
+
+// PausedWaitResult is the time to wait before checking again whether a paused application is resumed, since resuming
+// only changes the annotations of the AppConfig, which doesn't trigger the reconcile of its workloads and traits
+var PausedWaitResult = reconcile.Result{RequeueAfter: 30 * time.Second}
+
+// IsPaused checks whether the object is marked as paused by `vela suspend`
+func IsPaused(obj metav1.Object) bool {
+	return obj.GetAnnotations()[types.AnnPause] == "true"
+}
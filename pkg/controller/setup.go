@@ -19,6 +19,7 @@ package controller
 import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	"github.com/oam-dev/kubevela/pkg/controller/common"
 	"github.com/oam-dev/kubevela/pkg/controller/core.oam.dev/applicationdeployment"
 	autoscalers "github.com/oam-dev/kubevela/pkg/controller/v1alpha1/autoscaler"
 	"github.com/oam-dev/kubevela/pkg/controller/v1alpha1/metrics"
@@ -27,12 +28,12 @@ import (
 )
 
 // Setup workload controllers.
-func Setup(mgr ctrl.Manager) error {
-	for _, setup := range []func(ctrl.Manager) error{
+func Setup(mgr ctrl.Manager, args common.Args) error {
+	for _, setup := range []func(ctrl.Manager, common.Args) error{
 		metrics.Setup, podspecworkload.Setup, routes.Setup,
 		applicationdeployment.Setup, autoscalers.Setup,
 	} {
-		if err := setup(mgr); err != nil {
+		if err := setup(mgr, args); err != nil {
 			return err
 		}
 	}
@@ -19,6 +19,8 @@ package autoscalers
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
 	"time"
 
 	cpv1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
@@ -30,9 +32,19 @@ import (
 	"github.com/oam-dev/kubevela/api/v1alpha1"
 	"github.com/oam-dev/kubevela/pkg/controller/common"
 	"github.com/pkg/errors"
+	kedav1alpha1 "github.com/wonderflow/keda-api/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -44,46 +56,157 @@ const (
 	SpecWarningReplicasRequired                    = "spec.triggers.condition.replicas: Required value"
 	SpecWarningDurationTimeNotInRightFormat        = "spec.triggers.condition.duration: not in the right format"
 	SpecWarningSumOfStartAndDurationMoreThan24Hour = "the sum of the start hour and the duration hour has to be less than 24 hours."
+	SpecWarningScaleToZeroWithResourceTrigger      = "spec.%s: scale-to-zero requires removing resource triggers, %s trigger prevents scaling to zero"
+	SpecWarningDurationMoreThan24Hour              = "spec.triggers.condition.duration: has to be less than 24 hours"
+	SpecWarningCronWindowsOverlapped               = "spec.triggers.windows: time windows overlap, the max replicas of them takes effect"
+	SpecWarningTimezoneInvalid                     = "spec.triggers.condition.timezone: not a valid IANA time zone"
+	SpecWarningServerAddressRequired               = "spec.triggers.condition.serverAddress: Required value"
+	SpecWarningQueryRequired                       = "spec.triggers.condition.query: Required value"
+	SpecWarningTopicRequired                       = "spec.triggers.condition.topic: Required value"
+	SpecWarningConsumerGroupRequired               = "spec.triggers.condition.consumerGroup: Required value"
+	SpecWarningHostRequired                        = "spec.triggers.condition.host: Required value, or provide it by authenticationRef"
+	SpecWarningQueueNameRequired                   = "spec.triggers.condition.queueName: Required value"
+	SpecWarningValueRequired                       = "spec.triggers.condition.value: Required value"
+	SpecWarningRabbitMQModeUnsupported             = "spec.triggers.condition.mode: Unsupported value"
+	SpecWarningScalerAddressRequired               = "spec.triggers.condition.scalerAddress: Required value"
+	SpecWarningUtilizationInvalid                  = "spec.triggers.condition.value: utilization has to be an integer percentage"
+	SpecWarningAverageValueInvalid                 = "spec.triggers.condition.value: average value has to be a quantity like 512Mi"
+	SpecWarningMetricTargetTypeUnsupported         = "spec.triggers.condition.type: unsupported target type %s of %s trigger"
+	SpecWarningTriggerTypeUnsupported              = "spec.triggers.type: Unsupported value: %q: supported values: %v"
+	SpecWarningNegativeReplicas                    = "spec.%s: Invalid value: %d: must be greater than or equal to 0"
+	SpecWarningNegativeSeconds                     = "spec.%s: Invalid value: %d: seconds must be greater than or equal to 0"
+	SpecWarningMinReplicasGreaterThanMax           = "minReplicaCount must be <= maxReplicaCount, got minReplicas %d and maxReplicas %d"
+	SpecWarningAuthRefRequired                     = "spec.triggers.authRef: Required value: either name or secretName must be set"
+	SpecWarningSecretKeysRequired                  = "spec.triggers.authRef.secretKeys: Required value when secretName is set"
+	SpecWarningTriggersRequired                    = "spec.triggers: Required value: at least one trigger is needed"
+	SpecWarningIdleReplicasNotLessThanMin          = "spec.idleReplicas: Invalid value: %d: must be less than minReplicas %d"
+	SpecWarningIdleReplicasNotPassedThrough        = "spec.idleReplicas: not passed through to the ScaledObject until keda-api supports idleReplicaCount"
 )
 
-// ReconcileWaitResult is the time to wait between reconciliation.
+const (
+	ErrApplyInitialReplicas = "cannot apply initial replicas to the target workload"
+	ErrInvalidSpec          = "invalid autoscaler spec"
+	ErrPatchOwnerReference  = "cannot set the autoscaler as owner of the child resource"
+	ErrKEDANotInstalled     = "KEDA is not installed, the ScaledObject CRD is missing"
+	ErrSelectTargetWorkload = "cannot select the target workload by the target selector"
+	ErrKEDARequired         = "%s trigger requires KEDA, only cpu, memory and storage triggers could fall back to HPA"
+	ErrTargetNotScalable    = "the target workload doesn't expose the scale subresource"
+	// ErrHPANotControlled is reported instead of taking over an existing HPA which isn't created by the autoscaler
+	ErrHPANotControlled = "HPA %s already exists and is not controlled by the autoscaler"
+	// ErrTriggerSecretNotFound is recorded as a warning, the trigger fails to authenticate until the Secret is created
+	ErrTriggerSecretNotFound = "the Secret referenced by the trigger is not found"
+	// ErrKEDARequiredAtStartup fails the controller to start if KEDA is required but not installed
+	ErrKEDARequiredAtStartup = "KEDA is required by --autoscaler-require-keda, but CRD %s is missing"
+)
+
+const (
+	ReasonScaledObjectCreated = "ScaledObject created"
+	ReasonScaledObjectUpdated = "ScaledObject updated"
+	ReasonHPACreated          = "HPA created"
+	ReasonHPAUpdated          = "HPA updated"
+)
+
+// selectorTargetKinds are the kinds of workloads which could be selected by the target selector
+var selectorTargetKinds = []string{"Deployment", "StatefulSet"}
+
+// ReconcileWaitResult is the time to wait between reconciliation, failures are requeued by failureBackoff instead.
 var ReconcileWaitResult = reconcile.Result{RequeueAfter: 30 * time.Second}
 
+// KEDAMissingWaitResult is the time to wait before checking again whether KEDA is installed.
+var KEDAMissingWaitResult = reconcile.Result{RequeueAfter: 5 * time.Minute}
+
 // AutoscalerReconciler reconciles a Autoscaler object
 type AutoscalerReconciler struct {
 	client.Client
 
-	dm     discoverymapper.DiscoveryMapper
-	Log    logr.Logger
-	Scheme *runtime.Scheme
-	record event.Recorder
+	dm              discoverymapper.DiscoveryMapper
+	Log             logr.Logger
+	Scheme          *runtime.Scheme
+	record          event.Recorder
+	targetKinds     []string
+	ownerRefKinds   []string
+	manageOwnerRefs bool
+	backoff         failureBackoff
+	// scale discovers the scale subresource of target workloads, it's nil if the discovery is disabled
+	scale *scaleDiscovery
+	// propagation selects the labels and annotations copied onto the ScaledObject
+	propagation metadataPropagation
+	// watchScaledObjects watches the ScaledObjects owned by autoscalers to revert their drift, which is only possible
+	// if KEDA is installed when the controller starts
+	watchScaledObjects bool
+	keda               kedaDetection
 }
 
 // +kubebuilder:rbac:groups=standard.oam.dev,resources=autoscalers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=standard.oam.dev,resources=autoscalers/status,verbs=get;update;patch
-func (r *AutoscalerReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+// +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=get;list;watch;create;update;patch;delete
+func (r *AutoscalerReconciler) Reconcile(req ctrl.Request) (result ctrl.Result, err error) {
 	log := r.Log.WithValues("autoscaler", req.NamespacedName)
 	log.Info("Reconciling Autoscaler...")
 	ctx := context.Background()
 	var scaler v1alpha1.Autoscaler
+	var failure error
+	start := time.Now()
+	defer func() {
+		failed := err
+		if failed == nil {
+			failed = failure
+		}
+		observeReconcile(req.Namespace, scaler.Spec.Triggers, time.Since(start), failed)
+	}()
+	// fail records the error to the conditions and requeues the autoscaler after the backoff, the error itself isn't
+	// returned, since controller-runtime ignores RequeueAfter along with an error and requeues by its rate limiter
+	fail := func(cause error) (ctrl.Result, error) {
+		failure = cause
+		if err := r.patchCondition(ctx, &scaler, cpv1alpha1.ReconcileError(cause)); err != nil {
+			return ctrl.Result{}, err
+		}
+		return r.backoff.next(req.NamespacedName), nil
+	}
 	if err := r.Get(ctx, req.NamespacedName, &scaler); err != nil {
 		log.Error(err, "Failed to get trait", "traitName", scaler.Name)
-		return ReconcileWaitResult, client.IgnoreNotFound(err)
+		if apierrors.IsNotFound(err) {
+			r.backoff.reset(req.NamespacedName)
+			managedScaledObjects.untrack(req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
 	}
 	log.Info("Retrieved trait Autoscaler", "APIVersion", scaler.APIVersion, "Kind", scaler.Kind)
 
+	if scaler.DeletionTimestamp != nil {
+		managedScaledObjects.untrack(req.NamespacedName)
+		return r.cleanupScaledResources(ctx, &scaler, log)
+	}
+	if err := r.ensureFinalizer(ctx, &scaler); err != nil {
+		log.Error(err, "Failed to add finalizer", "Autoscaler", scaler.Name)
+		return fail(err)
+	}
+
 	// find the resource object to record the event to, default is the parent appConfig.
 	eventObj, err := util.LocateParentAppConfig(ctx, r.Client, &scaler)
 	if err != nil {
 		log.Error(err, "Failed to find the parent resource", "Autoscaler", scaler.Name)
-		return util.ReconcileWaitResult, util.PatchCondition(ctx, r, &scaler,
-			cpv1alpha1.ReconcileError(fmt.Errorf(util.ErrLocateAppConfig)))
+		return fail(fmt.Errorf(util.ErrLocateAppConfig))
 	}
 	if eventObj == nil {
 		// fallback to workload itself
 		log.Info("There is no parent resource", "Autoscaler", scaler.Name)
 		eventObj = &scaler
 	}
+	if common.IsPaused(eventObj) {
+		log.Info("The application is paused, skip reconciling", "Autoscaler", scaler.Name)
+		// resuming doesn't change the generation of the autoscaler, so it has to be checked again later
+		return common.PausedWaitResult, nil
+	}
+
+	if err := ValidateSpec(scaler.Spec); err != nil {
+		log.Error(err, "Invalid autoscaler spec", "Autoscaler", scaler.Name)
+		r.record.Event(eventObj, event.Warning(ErrInvalidSpec, err))
+		// counted as a failure, but not requeued by the backoff, since it's reconciled again once the spec is changed
+		failure = err
+		return ctrl.Result{}, r.patchCondition(ctx, &scaler, cpv1alpha1.ReconcileError(err))
+	}
 
 	// Fetch the instance to which the trait refers to
 	workload, err := oamutil.FetchWorkload(ctx, r, log, &scaler)
@@ -91,9 +214,7 @@ func (r *AutoscalerReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 		log.Error(err, "Error while fetching the workload", "workload reference",
 			scaler.GetWorkloadReference())
 		r.record.Event(&scaler, event.Warning(common.ErrLocatingWorkload, err))
-		return oamutil.ReconcileWaitResult,
-			oamutil.PatchCondition(ctx, r, &scaler,
-				cpv1alpha1.ReconcileError(errors.Wrap(err, common.ErrLocatingWorkload)))
+		return fail(errors.Wrap(err, common.ErrLocatingWorkload))
 	}
 
 	// Fetch the child resources list from the corresponding workload
@@ -101,52 +222,332 @@ func (r *AutoscalerReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 	if err != nil {
 		log.Error(err, "Error while fetching the workload child resources", "workload", workload.UnstructuredContent())
 		r.record.Event(eventObj, event.Warning(util.ErrFetchChildResources, err))
-		return util.ReconcileWaitResult, util.PatchCondition(ctx, r, &scaler,
-			cpv1alpha1.ReconcileError(fmt.Errorf(util.ErrFetchChildResources)))
+		return fail(fmt.Errorf(util.ErrFetchChildResources))
 	}
 	resources = append(resources, workload)
 
-	targetWorkloadSetFlag := false
-	for _, res := range resources {
-		// Keda only support these four built-in workload now.
-		if res.GetKind() == "Deployment" || res.GetKind() == "StatefulSet" || res.GetKind() == "DaemonSet" || res.GetKind() == "ReplicaSet" {
-			scaler.Spec.TargetWorkload = v1alpha1.TargetWorkload{
-				APIVersion: res.GetAPIVersion(),
-				Kind:       res.GetKind(),
-				Name:       res.GetName(),
+	// the target selected by labels takes precedence over the priority of kinds
+	var target *unstructured.Unstructured
+	if scaler.Spec.TargetSelector != nil {
+		target, err = selectTargetWorkload(resources, scaler.Spec.TargetSelector)
+		if err != nil {
+			log.Error(err, "Failed to select the target workload", "Autoscaler", scaler.Name)
+			r.record.Event(eventObj, event.Warning(ErrSelectTargetWorkload, err))
+			return fail(errors.Wrap(err, ErrSelectTargetWorkload))
+		}
+	} else if target, err = r.resolveTargetWorkload(resources); err != nil {
+		log.Error(err, "Failed to discover the scale subresource of the child resources", "Autoscaler", scaler.Name)
+		return fail(err)
+	}
+	scaler.Spec.TargetWorkload = v1alpha1.TargetWorkload{
+		APIVersion: target.GetAPIVersion(),
+		Kind:       target.GetKind(),
+		Name:       target.GetName(),
+	}
+	scalable, err := r.isScalable(target)
+	if err != nil {
+		log.Error(err, "Failed to discover the scale subresource of the target workload", "Autoscaler", scaler.Name)
+		return fail(err)
+	}
+	if !scalable {
+		// KEDA and HPA fail to scale it, the ScaledObject or HPA is still applied in case the scale subresource is added later
+		r.record.Event(eventObj, event.Warning(ErrTargetNotScalable,
+			fmt.Errorf("%s: %s %s", ErrTargetNotScalable, target.GetKind(), target.GetName())))
+	}
+
+	if err := r.patchOwnerReferences(ctx, &scaler, resources, log); err != nil {
+		r.record.Event(eventObj, event.Warning(ErrPatchOwnerReference, err))
+		return fail(errors.Wrap(err, ErrPatchOwnerReference))
+	}
+
+	if err := r.applyInitialReplicas(ctx, &scaler, resources, log); err != nil {
+		r.record.Event(eventObj, event.Warning(ErrApplyInitialReplicas, err))
+		return fail(errors.Wrap(err, ErrApplyInitialReplicas))
+	}
+
+	installed, err := r.isKEDAInstalled()
+	if err != nil {
+		log.Error(err, "Failed to discover KEDA ScaledObject CRD")
+		return fail(err)
+	}
+
+	namespace := req.NamespacedName.Namespace
+	targetWorkload := scaler.Spec.TargetWorkload
+	var reason, boundMessage, scaledObjectName string
+	if installed {
+		if reason, err = r.scaleByKEDA(scaler, eventObj, namespace, log); err != nil {
+			if meta.IsNoMatchError(err) {
+				r.forgetKEDAInstalled()
 			}
-			targetWorkloadSetFlag = true
-			break
+			return fail(err)
 		}
+		managedScaledObjects.track(req.NamespacedName, scaler.Spec.Triggers)
+		// the ScaledObject is named after the autoscaler
+		scaledObjectName = scaler.Name
+		boundMessage = withTriggersNote(fmt.Sprintf("ScaledObject %s is bound to %s %s", scaler.Name,
+			targetWorkload.Kind, targetWorkload.Name), scaler.Spec.Triggers)
+	} else {
+		// fall back to a native HPA for resource triggers,
+		// otherwise back off quietly until KEDA is installed, instead of failing every reconcile loudly
+		spec, err := prepareHPASpec(scaler)
+		if err != nil {
+			missingErr := errors.Wrap(err, ErrKEDANotInstalled)
+			if scaler.GetCondition(cpv1alpha1.TypeSynced).Message != missingErr.Error() {
+				log.Info("KEDA is not installed, waiting for it", "Autoscaler", scaler.Name)
+				r.record.Event(eventObj, event.Warning(ErrKEDANotInstalled, missingErr))
+			}
+			return KEDAMissingWaitResult, r.patchCondition(ctx, &scaler, cpv1alpha1.ReconcileError(missingErr))
+		}
+		if reason, err = r.scaleByHPA(scaler, spec, namespace, log); err != nil {
+			return fail(err)
+		}
+		managedScaledObjects.untrack(req.NamespacedName)
+		boundMessage = withTriggersNote(fmt.Sprintf("HPA %s is bound to %s %s", scaler.Name, targetWorkload.Kind,
+			targetWorkload.Name), scaler.Spec.Triggers)
+	}
+	if reason != "" {
+		r.record.Event(&scaler, event.Normal(event.Reason(reason), boundMessage))
+	}
+
+	r.backoff.reset(req.NamespacedName)
+	return ctrl.Result{}, r.patchBoundStatus(ctx, &scaler, scaledObjectName, cpv1alpha1.ReconcileSuccess(),
+		boundCondition(boundMessage))
+}
+
+// withTriggersNote notes how multiple triggers work together in the bound message, both KEDA and HPA compute the
+// replicas of each trigger independently and scale the workload to the max of them
+func withTriggersNote(message string, triggers []v1alpha1.Trigger) string {
+	if len(triggers) < 2 {
+		return message
 	}
+	return fmt.Sprintf("%s, scaled to the max replicas computed across %d triggers", message, len(triggers))
+}
+
+// boundCondition is the Ready condition of the autoscaler once the ScaledObject or the HPA is created or updated
+func boundCondition(message string) cpv1alpha1.Condition {
+	c := cpv1alpha1.Available()
+	c.Message = message
+	return c
+}
+
+// patchCondition sets the conditions and the phase computed from them to the status of the autoscaler
+func (r *AutoscalerReconciler) patchCondition(ctx context.Context, scaler *v1alpha1.Autoscaler,
+	condition ...cpv1alpha1.Condition) error {
+	origin := scaler.DeepCopy()
+	scaler.SetConditions(condition...)
+	scaler.Status.Phase = computePhase(scaler.Status)
+	return r.patchStatusIfChanged(ctx, origin, scaler)
+}
+
+// patchBoundStatus records the bound ScaledObject, which is empty if it falls back to HPA, and the resolved target
+// workload to the status along with the conditions, it's a patch so that other fields of the status are kept
+func (r *AutoscalerReconciler) patchBoundStatus(ctx context.Context, scaler *v1alpha1.Autoscaler, scaledObjectName string,
+	condition ...cpv1alpha1.Condition) error {
+	origin := scaler.DeepCopy()
+	scaler.Status.ScaledObjectName = scaledObjectName
+	scaler.Status.ScaledObjectNamespace = ""
+	if scaledObjectName != "" {
+		scaler.Status.ScaledObjectNamespace = scaler.Namespace
+	}
+	targetWorkload := scaler.Spec.TargetWorkload
+	scaler.Status.TargetWorkload = &targetWorkload
+	scaler.SetConditions(condition...)
+	scaler.Status.Phase = computePhase(scaler.Status)
+	return r.patchStatusIfChanged(ctx, origin, scaler)
+}
+
+// patchStatusIfChanged patches the status of the autoscaler only if it differs from the origin, the conditions keep
+// their last transition time if they're not changed, so a repeated reconcile doesn't touch the autoscaler at all
+func (r *AutoscalerReconciler) patchStatusIfChanged(ctx context.Context, origin, scaler *v1alpha1.Autoscaler) error {
+	if equality.Semantic.DeepEqual(origin.Status, scaler.Status) {
+		return nil
+	}
+	return r.Status().Patch(ctx, scaler, client.MergeFrom(origin))
+}
 
-	// if no child resource found, set the workload as target workload
-	if !targetWorkloadSetFlag {
-		scaler.Spec.TargetWorkload = v1alpha1.TargetWorkload{
-			APIVersion: workload.GetAPIVersion(),
-			Kind:       workload.GetKind(),
-			Name:       workload.GetName(),
+// patchOwnerReferences sets the autoscaler as a non-controller owner of the child resources,
+// only the kinds in the owner reference allowlist are patched, and nothing is patched if it's disabled
+func (r *AutoscalerReconciler) patchOwnerReferences(ctx context.Context, scaler *v1alpha1.Autoscaler,
+	resources []*unstructured.Unstructured, log logr.Logger) error {
+	if !r.manageOwnerRefs {
+		return nil
+	}
+	ownerRef := metav1.OwnerReference{
+		APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		Kind:       reflect.TypeOf(v1alpha1.Autoscaler{}).Name(),
+		Name:       scaler.GetName(),
+		UID:        scaler.GetUID(),
+	}
+	for _, res := range resources {
+		if !containsKind(r.ownerRefKinds, res.GetKind()) {
+			continue
+		}
+		owners, changed := mergeOwnerReferences(res.GetOwnerReferences(), ownerRef)
+		if !changed {
+			continue
+		}
+		patch := client.MergeFrom(res.DeepCopy())
+		res.SetOwnerReferences(owners)
+		if err := r.Patch(ctx, res, patch); err != nil {
+			log.Error(err, "Failed to patch owner reference", "kind", res.GetKind(), "name", res.GetName())
+			return err
 		}
 	}
+	return nil
+}
 
-	namespace := req.NamespacedName.Namespace
-	if err := r.scaleByKEDA(scaler, namespace, log); err != nil {
-		return ReconcileWaitResult, err
+// mergeOwnerReferences adds the owner reference to the existing ones, or replaces the one of the same owner UID if
+// it differs, it returns false if the owner reference is already there, so that nothing needs to be patched
+func mergeOwnerReferences(existing []metav1.OwnerReference,
+	ref metav1.OwnerReference) ([]metav1.OwnerReference, bool) {
+	for i, owner := range existing {
+		if owner.UID != ref.UID {
+			continue
+		}
+		if equality.Semantic.DeepEqual(owner, ref) {
+			return existing, false
+		}
+		merged := append([]metav1.OwnerReference{}, existing...)
+		merged[i] = ref
+		return merged, true
+	}
+	return append(existing, ref), true
+}
+
+// selectTargetWorkload selects the target workload among the resources by the label selector,
+// it's an error if none or more than one of Deployments and StatefulSets match
+func selectTargetWorkload(resources []*unstructured.Unstructured,
+	selector *metav1.LabelSelector) (*unstructured.Unstructured, error) {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	var matched []*unstructured.Unstructured
+	var names []string
+	for _, res := range resources {
+		if !containsKind(selectorTargetKinds, res.GetKind()) || !s.Matches(labels.Set(res.GetLabels())) {
+			continue
+		}
+		matched = append(matched, res)
+		names = append(names, res.GetKind()+"/"+res.GetName())
+	}
+	switch len(matched) {
+	case 0:
+		return nil, fmt.Errorf("no Deployment or StatefulSet matches the target selector %s", s)
+	case 1:
+		return matched[0], nil
+	default:
+		return nil, fmt.Errorf("%d workloads match the target selector %s: %s", len(matched), s, strings.Join(names, ", "))
+	}
+}
+
+// resolveTargetWorkload chooses the scale target among the workload and its child resources, the workload is the last
+// one of the resources. The target is chosen by the priority of the configured kinds, skipping those without the scale
+// subresource like DaemonSet, then any resource exposing the scale subresource is chosen so that CRD-based workloads
+// could be scaled, and the workload itself is the target at last.
+func (r *AutoscalerReconciler) resolveTargetWorkload(resources []*unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	for _, kind := range r.targetKinds {
+		for _, res := range resources {
+			if res.GetKind() != kind {
+				continue
+			}
+			scalable, err := r.isScalable(res)
+			if err != nil {
+				return nil, err
+			}
+			if scalable {
+				return res, nil
+			}
+		}
+	}
+	if r.scale != nil {
+		for _, res := range resources {
+			scalable, err := r.isScalable(res)
+			if err != nil {
+				return nil, err
+			}
+			if scalable {
+				return res, nil
+			}
+		}
 	}
+	return resources[len(resources)-1], nil
+}
 
-	return ctrl.Result{}, nil
+// isScalable tells whether the resource exposes the scale subresource,
+// all resources are taken as scalable if the discovery is disabled
+func (r *AutoscalerReconciler) isScalable(res *unstructured.Unstructured) (bool, error) {
+	if r.scale == nil {
+		return true, nil
+	}
+	return r.scale.isScalable(res.GroupVersionKind())
+}
+
+func containsKind(kinds []string, kind string) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// applyInitialReplicas sets InitialReplicas to the target workload only once, when the autoscaler is first created.
+// It's only marked applied in the status once the target workload is scaled, so that it's retried otherwise.
+func (r *AutoscalerReconciler) applyInitialReplicas(ctx context.Context, scaler *v1alpha1.Autoscaler,
+	resources []*unstructured.Unstructured, log logr.Logger) error {
+	if scaler.Spec.InitialReplicas == nil || scaler.Status.InitialScaleApplied {
+		return nil
+	}
+	targetWorkload := scaler.Spec.TargetWorkload
+	applied := false
+	for _, res := range resources {
+		if res.GetKind() != targetWorkload.Kind || res.GetName() != targetWorkload.Name {
+			continue
+		}
+		// DaemonSet doesn't have replicas
+		if res.GetKind() != "DaemonSet" {
+			patch := client.MergeFrom(res.DeepCopy())
+			if err := unstructured.SetNestedField(res.Object, int64(*scaler.Spec.InitialReplicas), "spec", "replicas"); err != nil {
+				return err
+			}
+			if err := r.Patch(ctx, res, patch); err != nil {
+				log.Error(err, "Failed to set initial replicas", "targetWorkload", targetWorkload.Name)
+				return err
+			}
+			log.Info("Initial replicas applied", "targetWorkload", targetWorkload.Name,
+				"replicas", *scaler.Spec.InitialReplicas)
+		}
+		applied = true
+		break
+	}
+	if !applied {
+		log.Info("Target workload not found, initial replicas not applied", "targetWorkload", targetWorkload.Name)
+		return nil
+	}
+	origin := scaler.DeepCopy()
+	scaler.Status.InitialScaleApplied = true
+	return errors.Wrap(r.Status().Patch(ctx, scaler, client.MergeFrom(origin)), common.ErrUpdateStatus)
 }
 
 func (r *AutoscalerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	registerMetrics()
 	r.record = event.NewAPIRecorder(mgr.GetEventRecorderFor("Autoscaler")).
 		WithAnnotations("controller", "Autoscaler")
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.Autoscaler{}).
-		Complete(r)
+	// only spec changes are reconciled, the status patched by the reconcile itself and the periodic resync are skipped,
+	// the deletion still passes as it bumps the generation of the autoscaler with the finalizer. Autoscalers of paused
+	// applications requeue themselves, since resuming the AppConfig doesn't pass the predicate.
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.Autoscaler{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	if r.watchScaledObjects {
+		// the status updated by KEDA is skipped as well, a ScaledObject modified or deleted by others is reconciled
+		b = b.Owns(&kedav1alpha1.ScaledObject{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	}
+	return b.Complete(r)
 }
 
 // Setup adds a controller that reconciles MetricsTrait.
-func Setup(mgr ctrl.Manager) error {
+func Setup(mgr ctrl.Manager, args common.Args) error {
 	dm, err := discoverymapper.New(mgr.GetConfig())
 	if err != nil {
 		return err
@@ -157,5 +558,28 @@ func Setup(mgr ctrl.Manager) error {
 		Scheme: mgr.GetScheme(),
 		dm:     dm,
 	}
+	r.targetKinds = args.AutoscalerTargetKinds
+	if len(r.targetKinds) == 0 {
+		r.targetKinds = common.DefaultAutoscalerTargetKinds
+	}
+	r.ownerRefKinds = args.AutoscalerOwnerRefKinds
+	if len(r.ownerRefKinds) == 0 {
+		r.ownerRefKinds = common.DefaultAutoscalerOwnerRefKinds
+	}
+	r.manageOwnerRefs = args.AutoscalerManageOwnerRefs
+	r.propagation = metadataPropagation{
+		include: args.AutoscalerPropagatedKeys,
+		exclude: args.AutoscalerExcludedKeys,
+	}
+	if args.AutoscalerDiscoverScalable {
+		dc, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+		if err != nil {
+			return err
+		}
+		r.scale = newScaleDiscovery(dc)
+	}
+	if err := r.checkRequiredCRDs(args.AutoscalerRequireKEDA); err != nil {
+		return err
+	}
 	return r.SetupWithManager(mgr)
 }
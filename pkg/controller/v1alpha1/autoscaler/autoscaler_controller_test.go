@@ -0,0 +1,509 @@
+package autoscalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	cpv1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	velatypes "github.com/oam-dev/kubevela/api/types"
+	"github.com/oam-dev/kubevela/api/v1alpha1"
+	"github.com/oam-dev/kubevela/pkg/controller/common"
+)
+
+func TestPatchOwnerReferences(t *testing.T) {
+	scaler := &v1alpha1.Autoscaler{}
+	scaler.SetName("scaler")
+	scaler.SetUID("scaler-uid")
+
+	ownerRef := metav1.OwnerReference{
+		APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		Kind:       "Autoscaler",
+		Name:       "scaler",
+		UID:        "scaler-uid",
+	}
+
+	cases := map[string]struct {
+		manageOwnerRefs bool
+		ownerRefKinds   []string
+		existingOwners  []metav1.OwnerReference
+		expPatched      []string
+	}{
+		"owner references are managed": {
+			manageOwnerRefs: true,
+			ownerRefKinds:   common.DefaultAutoscalerOwnerRefKinds,
+			expPatched:      []string{"Deployment"},
+		},
+		"owner references are not managed": {
+			manageOwnerRefs: false,
+			ownerRefKinds:   common.DefaultAutoscalerOwnerRefKinds,
+		},
+		"owner reference already exists": {
+			manageOwnerRefs: true,
+			ownerRefKinds:   common.DefaultAutoscalerOwnerRefKinds,
+			existingOwners:  []metav1.OwnerReference{ownerRef},
+		},
+		"kinds out of the allowlist are skipped": {
+			manageOwnerRefs: true,
+			ownerRefKinds:   []string{"StatefulSet"},
+		},
+		"all kinds in the allowlist are patched": {
+			manageOwnerRefs: true,
+			ownerRefKinds:   []string{"Service", "Deployment"},
+			expPatched:      []string{"Deployment", "Service"},
+		},
+	}
+	for name, c := range cases {
+		var patched []string
+		r := AutoscalerReconciler{
+			Client: &test.MockClient{
+				MockPatch: func(_ context.Context, obj runtime.Object, _ client.Patch, _ ...client.PatchOption) error {
+					patched = append(patched, obj.GetObjectKind().GroupVersionKind().Kind)
+					return nil
+				},
+			},
+			ownerRefKinds:   c.ownerRefKinds,
+			manageOwnerRefs: c.manageOwnerRefs,
+		}
+		deploy := &unstructured.Unstructured{}
+		deploy.SetAPIVersion("apps/v1")
+		deploy.SetKind("Deployment")
+		deploy.SetName("web")
+		deploy.SetOwnerReferences(c.existingOwners)
+		svc := &unstructured.Unstructured{}
+		svc.SetAPIVersion("v1")
+		svc.SetKind("Service")
+		svc.SetName("web")
+
+		err := r.patchOwnerReferences(context.Background(), scaler, []*unstructured.Unstructured{deploy, svc},
+			ctrl.Log.WithName("test"))
+		assert.NoError(t, err, name)
+		assert.Equal(t, c.expPatched, patched, name)
+		if len(c.expPatched) == 0 {
+			assert.Equal(t, c.existingOwners, deploy.GetOwnerReferences(), name)
+			assert.Empty(t, svc.GetOwnerReferences(), name)
+		}
+	}
+}
+
+func TestPatchBoundStatus(t *testing.T) {
+	cases := map[string]struct {
+		scaledObjectName string
+		expNamespace     string
+	}{
+		"bound to ScaledObject": {
+			scaledObjectName: "scaler",
+			expNamespace:     "default",
+		},
+		"fall back to HPA": {},
+	}
+	for name, c := range cases {
+		var patched map[string]interface{}
+		r := AutoscalerReconciler{
+			Client: &test.MockClient{
+				MockStatusPatch: func(_ context.Context, obj runtime.Object, patch client.Patch, _ ...client.PatchOption) error {
+					data, err := patch.Data(obj)
+					if err != nil {
+						return err
+					}
+					return json.Unmarshal(data, &patched)
+				},
+			},
+		}
+		scaler := &v1alpha1.Autoscaler{Spec: v1alpha1.AutoscalerSpec{
+			TargetWorkload: v1alpha1.TargetWorkload{APIVersion: "apps/v1", Kind: "Deployment", Name: "web"},
+		}}
+		scaler.SetName("scaler")
+		scaler.SetNamespace("default")
+		scaler.Status.InitialScaleApplied = true
+
+		err := r.patchBoundStatus(context.Background(), scaler, c.scaledObjectName, cpv1alpha1.ReconcileSuccess())
+		assert.NoError(t, err, name)
+		assert.Equal(t, c.scaledObjectName, scaler.Status.ScaledObjectName, name)
+		assert.Equal(t, c.expNamespace, scaler.Status.ScaledObjectNamespace, name)
+		assert.Equal(t, &scaler.Spec.TargetWorkload, scaler.Status.TargetWorkload, name)
+		assert.Equal(t, v1alpha1.AutoscalerPhaseReady, scaler.Status.Phase, name)
+
+		// only the changed fields are patched, so the other fields of the status are kept
+		status := patched["status"].(map[string]interface{})
+		assert.NotContains(t, status, "initialScaleApplied", name)
+		assert.Contains(t, status, "targetWorkload", name)
+	}
+}
+
+func TestApplyInitialReplicas(t *testing.T) {
+	deploy := func() *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("apps/v1")
+		u.SetKind("Deployment")
+		u.SetName("web")
+		return u
+	}
+	daemonSet := &unstructured.Unstructured{}
+	daemonSet.SetAPIVersion("apps/v1")
+	daemonSet.SetKind("DaemonSet")
+	daemonSet.SetName("web")
+	patchErr := errors.New("patch failed")
+
+	cases := map[string]struct {
+		initialReplicas *int32
+		applied         bool
+		targetKind      string
+		resources       []*unstructured.Unstructured
+		patchErr        error
+		statusPatchErr  error
+		expReplicas     interface{}
+		expStatusPatch  bool
+		expApplied      bool
+		expErr          bool
+	}{
+		"no initial replicas": {
+			resources: []*unstructured.Unstructured{deploy()},
+		},
+		"already applied": {
+			initialReplicas: pointer.Int32Ptr(3),
+			applied:         true,
+			resources:       []*unstructured.Unstructured{deploy()},
+			expApplied:      true,
+		},
+		"target workload scaled": {
+			initialReplicas: pointer.Int32Ptr(3),
+			resources:       []*unstructured.Unstructured{deploy()},
+			expReplicas:     float64(3),
+			expStatusPatch:  true,
+			expApplied:      true,
+		},
+		"not applied if the target workload failed to scale": {
+			initialReplicas: pointer.Int32Ptr(3),
+			resources:       []*unstructured.Unstructured{deploy()},
+			patchErr:        patchErr,
+			expReplicas:     float64(3),
+			expErr:          true,
+		},
+		"not applied if the target workload is not found": {
+			initialReplicas: pointer.Int32Ptr(3),
+		},
+		"status failed to patch": {
+			initialReplicas: pointer.Int32Ptr(3),
+			resources:       []*unstructured.Unstructured{deploy()},
+			statusPatchErr:  patchErr,
+			expReplicas:     float64(3),
+			expStatusPatch:  true,
+			expApplied:      true,
+			expErr:          true,
+		},
+		"DaemonSet is applied without scaling": {
+			initialReplicas: pointer.Int32Ptr(3),
+			targetKind:      "DaemonSet",
+			resources:       []*unstructured.Unstructured{daemonSet},
+			expStatusPatch:  true,
+			expApplied:      true,
+		},
+	}
+	for name, c := range cases {
+		var replicas interface{}
+		var statusPatched map[string]interface{}
+		r := AutoscalerReconciler{
+			Client: &test.MockClient{
+				MockPatch: func(_ context.Context, obj runtime.Object, patch client.Patch, _ ...client.PatchOption) error {
+					data, err := patch.Data(obj)
+					if err != nil {
+						return err
+					}
+					var patched map[string]interface{}
+					if err := json.Unmarshal(data, &patched); err != nil {
+						return err
+					}
+					replicas = patched["spec"].(map[string]interface{})["replicas"]
+					return c.patchErr
+				},
+				MockStatusPatch: func(_ context.Context, obj runtime.Object, patch client.Patch, _ ...client.PatchOption) error {
+					data, err := patch.Data(obj)
+					if err != nil {
+						return err
+					}
+					if err := json.Unmarshal(data, &statusPatched); err != nil {
+						return err
+					}
+					return c.statusPatchErr
+				},
+			},
+		}
+		targetKind := "Deployment"
+		if c.targetKind != "" {
+			targetKind = c.targetKind
+		}
+		scaler := &v1alpha1.Autoscaler{Spec: v1alpha1.AutoscalerSpec{
+			InitialReplicas: c.initialReplicas,
+			TargetWorkload:  v1alpha1.TargetWorkload{APIVersion: "apps/v1", Kind: targetKind, Name: "web"},
+		}}
+		scaler.Status.InitialScaleApplied = c.applied
+
+		err := r.applyInitialReplicas(context.Background(), scaler, c.resources, ctrl.Log.WithName("test"))
+		assert.Equal(t, c.expErr, err != nil, name)
+		assert.Equal(t, c.expReplicas, replicas, name)
+		assert.Equal(t, c.expApplied, scaler.Status.InitialScaleApplied, name)
+		if !c.expStatusPatch {
+			assert.Nil(t, statusPatched, name)
+			continue
+		}
+		// only the flag is patched, so the rest of the status isn't overwritten
+		assert.Equal(t, map[string]interface{}{"status": map[string]interface{}{"initialScaleApplied": true}},
+			statusPatched, name)
+	}
+}
+
+func TestMergeOwnerReferences(t *testing.T) {
+	appConfig := metav1.OwnerReference{APIVersion: "core.oam.dev/v1alpha2", Kind: "ApplicationConfiguration",
+		Name: "app", UID: "app-uid"}
+	ownerRef := metav1.OwnerReference{APIVersion: "standard.oam.dev/v1alpha1", Kind: "Autoscaler",
+		Name: "scaler", UID: "scaler-uid"}
+	staleRef := ownerRef
+	staleRef.APIVersion = "standard.oam.dev/v1alpha0"
+
+	cases := map[string]struct {
+		existing   []metav1.OwnerReference
+		expOwners  []metav1.OwnerReference
+		expChanged bool
+	}{
+		"appended if not exist": {
+			existing:   []metav1.OwnerReference{appConfig},
+			expOwners:  []metav1.OwnerReference{appConfig, ownerRef},
+			expChanged: true,
+		},
+		"kept if the same": {
+			existing:  []metav1.OwnerReference{appConfig, ownerRef},
+			expOwners: []metav1.OwnerReference{appConfig, ownerRef},
+		},
+		"replaced if differs": {
+			existing:   []metav1.OwnerReference{staleRef, appConfig},
+			expOwners:  []metav1.OwnerReference{ownerRef, appConfig},
+			expChanged: true,
+		},
+	}
+	for name, c := range cases {
+		owners, changed := mergeOwnerReferences(c.existing, ownerRef)
+		assert.Equal(t, c.expOwners, owners, name)
+		assert.Equal(t, c.expChanged, changed, name)
+	}
+	// the existing owner references are not modified in place
+	existing := []metav1.OwnerReference{staleRef}
+	mergeOwnerReferences(existing, ownerRef)
+	assert.Equal(t, staleRef, existing[0])
+}
+
+func TestPatchStatusIfChanged(t *testing.T) {
+	origin := &v1alpha1.Autoscaler{}
+	origin.SetConditions(cpv1alpha1.ReconcileSuccess())
+	origin.Status.Phase = v1alpha1.AutoscalerPhaseReady
+
+	cases := map[string]struct {
+		condition  cpv1alpha1.Condition
+		expPatched int
+	}{
+		"status is not changed": {
+			condition: cpv1alpha1.ReconcileSuccess(),
+		},
+		"status is changed": {
+			condition:  cpv1alpha1.ReconcileError(errors.New("boom")),
+			expPatched: 1,
+		},
+	}
+	for name, c := range cases {
+		patched := 0
+		r := AutoscalerReconciler{
+			Client: &test.MockClient{
+				MockStatusPatch: func(_ context.Context, _ runtime.Object, _ client.Patch, _ ...client.PatchOption) error {
+					patched++
+					return nil
+				},
+			},
+		}
+		scaler := origin.DeepCopy()
+		err := r.patchCondition(context.Background(), scaler, c.condition)
+		assert.NoError(t, err, name)
+		assert.Equal(t, c.expPatched, patched, name)
+	}
+}
+
+func TestSelectTargetWorkload(t *testing.T) {
+	newResource := func(kind, name string, labels map[string]string) *unstructured.Unstructured {
+		res := &unstructured.Unstructured{}
+		res.SetAPIVersion("apps/v1")
+		res.SetKind(kind)
+		res.SetName(name)
+		res.SetLabels(labels)
+		return res
+	}
+	worker := newResource("Deployment", "worker-7d9f8", map[string]string{"app": "worker"})
+	canary := newResource("Deployment", "worker-canary-5c6d7", map[string]string{"app": "worker", "track": "canary"})
+	svc := newResource("Service", "worker", map[string]string{"app": "worker", "track": "stable"})
+	resources := []*unstructured.Unstructured{worker, canary, svc}
+
+	cases := map[string]struct {
+		selector  *metav1.LabelSelector
+		expTarget *unstructured.Unstructured
+	}{
+		"exactly one matches": {
+			selector:  &metav1.LabelSelector{MatchLabels: map[string]string{"track": "canary"}},
+			expTarget: canary,
+		},
+		"match by expressions": {
+			selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "track", Operator: metav1.LabelSelectorOpDoesNotExist},
+			}},
+			expTarget: worker,
+		},
+		"only workloads are matched": {
+			selector: &metav1.LabelSelector{MatchLabels: map[string]string{"track": "stable"}},
+		},
+		"more than one match": {
+			selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "worker"}},
+		},
+	}
+	for name, c := range cases {
+		target, err := selectTargetWorkload(resources, c.selector)
+		if c.expTarget == nil {
+			assert.Error(t, err, name)
+			continue
+		}
+		assert.NoError(t, err, name)
+		assert.Equal(t, c.expTarget, target, name)
+	}
+}
+
+func TestWithTriggersNote(t *testing.T) {
+	message := "ScaledObject web is bound to Deployment web"
+	assert.Equal(t, message, withTriggersNote(message, []v1alpha1.Trigger{{Type: CPUType}}))
+	assert.Equal(t, message+", scaled to the max replicas computed across 3 triggers",
+		withTriggersNote(message, []v1alpha1.Trigger{{Type: PrometheusType}, {Type: PrometheusType}, {Type: CPUType}}))
+}
+
+func TestReconcileFailureRequeue(t *testing.T) {
+	errBoom := errors.New("boom")
+	key := types.NamespacedName{Namespace: "default", Name: "scaler"}
+
+	cases := map[string]struct {
+		getErr         error
+		statusPatchErr error
+		failures       int
+		expResult      ctrl.Result
+		expErr         error
+	}{
+		"failure is requeued after the backoff": {
+			expResult: ctrl.Result{RequeueAfter: minFailureBackoff},
+		},
+		"repeated failure is requeued after a longer backoff": {
+			failures:  2,
+			expResult: ctrl.Result{RequeueAfter: 4 * minFailureBackoff},
+		},
+		"failure not recorded is returned to the rate limiter": {
+			statusPatchErr: errBoom,
+			expErr:         errBoom,
+		},
+		"autoscaler not fetched is returned to the rate limiter": {
+			getErr: errBoom,
+			expErr: errBoom,
+		},
+	}
+	for name, c := range cases {
+		var recorded *v1alpha1.Autoscaler
+		r := AutoscalerReconciler{
+			Client: &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+					if c.getErr != nil {
+						return c.getErr
+					}
+					// the autoscaler isn't owned by any AppConfig, so it fails to locate the parent
+					scaler := obj.(*v1alpha1.Autoscaler)
+					scaler.SetName(key.Name)
+					scaler.SetNamespace(key.Namespace)
+					scaler.SetFinalizers([]string{autoscalerFinalizer})
+					return nil
+				},
+				MockStatusPatch: func(_ context.Context, obj runtime.Object, _ client.Patch, _ ...client.PatchOption) error {
+					recorded = obj.(*v1alpha1.Autoscaler)
+					return c.statusPatchErr
+				},
+			},
+			Log:    ctrl.Log.WithName("test"),
+			record: event.NewNopRecorder(),
+		}
+		for i := 0; i < c.failures; i++ {
+			r.backoff.next(key)
+		}
+
+		result, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+		assert.Equal(t, c.expResult, result, name)
+		if c.expErr == nil {
+			assert.NoError(t, err, name)
+			assert.Equal(t, cpv1alpha1.ReasonReconcileError, recorded.GetCondition(cpv1alpha1.TypeSynced).Reason, name)
+			continue
+		}
+		assert.True(t, errors.Is(err, c.expErr), name)
+	}
+}
+
+func TestReconcilePausedAutoscaler(t *testing.T) {
+	key := types.NamespacedName{Namespace: "default", Name: "scaler"}
+	paused := true
+	var recorded *v1alpha1.Autoscaler
+	r := AutoscalerReconciler{
+		Client: &test.MockClient{
+			MockGet: func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+				switch o := obj.(type) {
+				case *v1alpha1.Autoscaler:
+					o.SetName(key.Name)
+					o.SetNamespace(key.Namespace)
+					o.SetFinalizers([]string{autoscalerFinalizer})
+					o.SetOwnerReferences([]metav1.OwnerReference{{Kind: v1alpha2.ApplicationConfigurationKind, Name: "app"}})
+				case *v1alpha2.ApplicationConfiguration:
+					o.SetName("app")
+					if paused {
+						o.SetAnnotations(map[string]string{velatypes.AnnPause: "true"})
+					}
+				}
+				return nil
+			},
+			MockStatusPatch: func(_ context.Context, obj runtime.Object, _ client.Patch, _ ...client.PatchOption) error {
+				recorded = obj.(*v1alpha1.Autoscaler)
+				return nil
+			},
+		},
+		Log:    ctrl.Log.WithName("test"),
+		record: event.NewNopRecorder(),
+	}
+
+	// the autoscaler of the paused application is checked again later, since resuming it doesn't change the
+	// generation of the autoscaler
+	result, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err)
+	assert.Equal(t, common.PausedWaitResult, result)
+	assert.Nil(t, recorded)
+
+	// it's reconciled once resumed, which rejects the spec without triggers as a failed reconcile
+	errs := reconcileErrors.WithLabelValues(key.Namespace, "")
+	failed := testutil.ToFloat64(errs)
+	paused = false
+	result, err = r.Reconcile(ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, result)
+	if assert.NotNil(t, recorded) {
+		assert.Equal(t, SpecWarningTriggersRequired, recorded.GetCondition(cpv1alpha1.TypeSynced).Message)
+	}
+	assert.Equal(t, failed+1, testutil.ToFloat64(errs))
+}
@@ -0,0 +1,45 @@
+package autoscalers
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// minFailureBackoff is the requeue delay after the first failure of an autoscaler
+	minFailureBackoff = 5 * time.Second
+	// maxFailureBackoff caps the requeue delay of an autoscaler failing persistently
+	maxFailureBackoff = 5 * time.Minute
+)
+
+// failureBackoff tracks consecutive failures of autoscalers in memory, and doubles the requeue delay on each of them
+type failureBackoff struct {
+	mu       sync.Mutex
+	failures map[types.NamespacedName]int
+}
+
+// next records a failure of the autoscaler and returns the result to requeue it
+func (b *failureBackoff) next(key types.NamespacedName) ctrl.Result {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures == nil {
+		b.failures = make(map[types.NamespacedName]int)
+	}
+	delay := minFailureBackoff << uint(b.failures[key])
+	if delay >= maxFailureBackoff || delay <= 0 {
+		// stop counting once it's capped, so the shift never overflows
+		return ctrl.Result{RequeueAfter: maxFailureBackoff}
+	}
+	b.failures[key]++
+	return ctrl.Result{RequeueAfter: delay}
+}
+
+// reset forgets the failures of the autoscaler once it's reconciled successfully or deleted
+func (b *failureBackoff) reset(key types.NamespacedName) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.failures, key)
+}
@@ -0,0 +1,29 @@
+package autoscalers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestFailureBackoff(t *testing.T) {
+	var b failureBackoff
+	key := types.NamespacedName{Namespace: "default", Name: "scaler"}
+	other := types.NamespacedName{Namespace: "default", Name: "other"}
+
+	var delays []time.Duration
+	for i := 0; i < 8; i++ {
+		delays = append(delays, b.next(key).RequeueAfter)
+	}
+	assert.Equal(t, []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 40 * time.Second,
+		80 * time.Second, 160 * time.Second, maxFailureBackoff, maxFailureBackoff}, delays)
+
+	// failures are tracked per autoscaler
+	assert.Equal(t, minFailureBackoff, b.next(other).RequeueAfter)
+
+	b.reset(key)
+	assert.Equal(t, minFailureBackoff, b.next(key).RequeueAfter)
+	assert.Equal(t, 2*minFailureBackoff, b.next(other).RequeueAfter)
+}
@@ -0,0 +1,73 @@
+package autoscalers
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	kedav1alpha1 "github.com/wonderflow/keda-api/api/v1alpha1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/oam-dev/kubevela/api/v1alpha1"
+)
+
+// autoscalerFinalizer makes sure the scaled resources of the autoscaler are deleted before the autoscaler
+const autoscalerFinalizer = "autoscaler.finalizer.standard.oam.dev"
+
+const (
+	errAddFinalizer    = "cannot add finalizer to the autoscaler"
+	errRemoveFinalizer = "cannot remove finalizer from the autoscaler"
+)
+
+// ensureFinalizer adds the finalizer to the autoscaler if it's not added yet
+func (r *AutoscalerReconciler) ensureFinalizer(ctx context.Context, scaler *v1alpha1.Autoscaler) error {
+	if meta.FinalizerExists(scaler, autoscalerFinalizer) {
+		return nil
+	}
+	meta.AddFinalizer(scaler, autoscalerFinalizer)
+	return errors.Wrap(r.Update(ctx, scaler), errAddFinalizer)
+}
+
+// cleanupScaledResources deletes the ScaledObject and the fallback HPA of the autoscaler, which are matched by
+// the name and namespace of the autoscaler, in case garbage collection by owner references doesn't happen.
+// Only those controlled by the autoscaler are deleted, so a resource of the same name created by others is kept.
+// The finalizer is removed after all of them are gone, and it's requeued if deleting any of them failed.
+func (r *AutoscalerReconciler) cleanupScaledResources(ctx context.Context, scaler *v1alpha1.Autoscaler,
+	log logr.Logger) (ctrl.Result, error) {
+	if !meta.FinalizerExists(scaler, autoscalerFinalizer) {
+		return ctrl.Result{}, nil
+	}
+	key := types.NamespacedName{Name: scaler.Name, Namespace: scaler.Namespace}
+	for _, obj := range []runtime.Object{
+		&kedav1alpha1.ScaledObject{},
+		&autoscalingv2beta2.HorizontalPodAutoscaler{},
+	} {
+		// the ScaledObject CRD doesn't exist if KEDA is not installed
+		if err := r.Get(ctx, key, obj); err != nil {
+			if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+				continue
+			}
+			log.Error(err, "Failed to get the scaled resource of the autoscaler", "Autoscaler", scaler.Name)
+			return ctrl.Result{}, err
+		}
+		if owner := metav1.GetControllerOf(obj.(metav1.Object)); owner == nil || owner.UID != scaler.UID {
+			log.Info("Skip deleting the scaled resource not controlled by the autoscaler", "Autoscaler", scaler.Name,
+				"kind", reflect.TypeOf(obj).Elem().Name())
+			continue
+		}
+		if err := r.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, "Failed to delete the scaled resource of the autoscaler", "Autoscaler", scaler.Name)
+			return ctrl.Result{}, err
+		}
+	}
+	meta.RemoveFinalizer(scaler, autoscalerFinalizer)
+	return ctrl.Result{}, errors.Wrap(r.Update(ctx, scaler), errRemoveFinalizer)
+}
@@ -0,0 +1,105 @@
+package autoscalers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/api/v1alpha1"
+)
+
+func TestCleanupScaledResources(t *testing.T) {
+	errBoom := errors.New("connection refused")
+	cases := map[string]struct {
+		getErr       error
+		controller   types.UID
+		deleteErr    error
+		expErr       bool
+		expFinalizer bool
+		expDeleted   int
+		expUpdated   int
+	}{
+		"scaled resources deleted": {
+			controller: "scaler-uid",
+			expDeleted: 2,
+			expUpdated: 1,
+		},
+		"scaled resources not found": {
+			getErr:     apierrors.NewNotFound(schema.GroupResource{Group: "keda.sh", Resource: "scaledobjects"}, "scaler"),
+			expUpdated: 1,
+		},
+		"KEDA not installed": {
+			getErr:     &apimeta.NoKindMatchError{GroupKind: schema.GroupKind{Group: "keda.sh", Kind: "ScaledObject"}},
+			expUpdated: 1,
+		},
+		"scaled resources controlled by others are kept": {
+			controller: "other-uid",
+			expUpdated: 1,
+		},
+		"scaled resources without controller are kept": {
+			expUpdated: 1,
+		},
+		"failed to get scaled resources": {
+			getErr:       errBoom,
+			expErr:       true,
+			expFinalizer: true,
+		},
+		"failed to delete scaled resources": {
+			controller:   "scaler-uid",
+			deleteErr:    errBoom,
+			expErr:       true,
+			expFinalizer: true,
+			expDeleted:   1,
+		},
+	}
+	for name, c := range cases {
+		deleted, updated := 0, 0
+		r := AutoscalerReconciler{
+			Client: &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+					if c.getErr != nil {
+						return c.getErr
+					}
+					if c.controller != "" {
+						obj.(metav1.Object).SetOwnerReferences([]metav1.OwnerReference{{
+							Kind: "Autoscaler", Name: "scaler", UID: c.controller, Controller: pointer.BoolPtr(true)}})
+					}
+					return nil
+				},
+				MockDelete: func(_ context.Context, _ runtime.Object, _ ...client.DeleteOption) error {
+					deleted++
+					return c.deleteErr
+				},
+				MockUpdate: func(_ context.Context, _ runtime.Object, _ ...client.UpdateOption) error {
+					updated++
+					return nil
+				},
+			},
+		}
+		scaler := &v1alpha1.Autoscaler{}
+		scaler.SetName("scaler")
+		scaler.SetNamespace("default")
+		scaler.SetUID("scaler-uid")
+		meta.AddFinalizer(scaler, autoscalerFinalizer)
+
+		result, err := r.cleanupScaledResources(context.Background(), scaler, ctrl.Log.WithName("test"))
+		assert.Equal(t, c.expErr, err != nil, name)
+		assert.Equal(t, ctrl.Result{}, result, name)
+		assert.Equal(t, c.expFinalizer, meta.FinalizerExists(scaler, autoscalerFinalizer), name)
+		assert.Equal(t, c.expDeleted, deleted, name)
+		assert.Equal(t, c.expUpdated, updated, name)
+	}
+}
@@ -0,0 +1,112 @@
+package autoscalers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/oam-dev/kubevela/api/v1alpha1"
+)
+
+// defaultHPAMaxReplicas is the max replicas of the HPA if maxReplicas is not set, which is the same as the default of KEDA
+const defaultHPAMaxReplicas int32 = 100
+
+// resourceTriggers maps the resource triggers which could be served by a native HPA to the resource names
+var resourceTriggers = map[v1alpha1.TriggerType]corev1.ResourceName{
+	CPUType:              corev1.ResourceCPU,
+	MemoryType:           corev1.ResourceMemory,
+	StorageType:          corev1.ResourceStorage,
+	EphemeralStorageType: corev1.ResourceEphemeralStorage,
+}
+
+// scaleByHPA creates or updates a native HorizontalPodAutoscaler for the autoscaler when KEDA is not installed, an
+// existing HPA not controlled by the autoscaler is an error, which is reported by the condition of the autoscaler.
+// It returns the reason of the event to record, which is empty if the HPA is up to date.
+func (r *AutoscalerReconciler) scaleByHPA(scaler v1alpha1.Autoscaler, spec autoscalingv2beta2.HorizontalPodAutoscalerSpec,
+	namespace string, log logr.Logger) (string, error) {
+	ctx := context.Background()
+	var hpa autoscalingv2beta2.HorizontalPodAutoscaler
+	err := r.Client.Get(ctx, types.NamespacedName{Name: scaler.Name, Namespace: namespace}, &hpa)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "failed to get HPA", "HPAName", scaler.Name)
+			return "", err
+		}
+		hpa = autoscalingv2beta2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            scaler.Name,
+				Namespace:       namespace,
+				OwnerReferences: scalerOwnerReferences(scaler),
+			},
+			Spec: spec,
+		}
+		if err := r.Client.Create(ctx, &hpa); err != nil {
+			log.Error(err, "failed to create HPA", "HPA", hpa)
+			return "", err
+		}
+		log.Info("HPA created", "HPAName", scaler.Name)
+		return ReasonHPACreated, nil
+	}
+	// an HPA of the same name created by others is never taken over, the same as SetControllerReference refuses to
+	if owner := metav1.GetControllerOf(&hpa); owner == nil || owner.UID != scaler.UID {
+		err := fmt.Errorf(ErrHPANotControlled, hpa.Name)
+		log.Error(err, "failed to update HPA", "HPAName", scaler.Name)
+		return "", err
+	}
+	// skip updating the same spec and owner references, so that repeated reconciles don't record identical events
+	owners, ownersChanged := mergeOwnerReferences(hpa.GetOwnerReferences(), scalerOwnerReferences(scaler)[0])
+	if !ownersChanged && equality.Semantic.DeepEqual(hpa.Spec, spec) {
+		return "", nil
+	}
+	hpa.Spec = spec
+	hpa.SetOwnerReferences(owners)
+	if err := r.Client.Update(ctx, &hpa); err != nil {
+		log.Error(err, "failed to update HPA", "HPA", hpa)
+		return "", err
+	}
+	log.Info("HPA updated", "HPAName", scaler.Name)
+	return ReasonHPAUpdated, nil
+}
+
+// prepareHPASpec converts the Autoscaler spec into a HPA spec, only cpu, memory and storage triggers are supported,
+// other triggers require KEDA
+func prepareHPASpec(scaler v1alpha1.Autoscaler) (autoscalingv2beta2.HorizontalPodAutoscalerSpec, error) {
+	targetWorkload := scaler.Spec.TargetWorkload
+	spec := autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{
+			APIVersion: targetWorkload.APIVersion,
+			Kind:       targetWorkload.Kind,
+			Name:       targetWorkload.Name,
+		},
+		MinReplicas: scaler.Spec.MinReplicas,
+		MaxReplicas: defaultHPAMaxReplicas,
+	}
+	if scaler.Spec.MaxReplicas != nil {
+		spec.MaxReplicas = *scaler.Spec.MaxReplicas
+	}
+	for _, t := range scaler.Spec.Triggers {
+		resourceName, ok := resourceTriggers[t.Type]
+		if !ok {
+			return spec, fmt.Errorf(ErrKEDARequired, t.Type)
+		}
+		target, _, err := prepareResourceMetricTarget(t)
+		if err != nil {
+			return spec, err
+		}
+		spec.Metrics = append(spec.Metrics, autoscalingv2beta2.MetricSpec{
+			Type: autoscalingv2beta2.ResourceMetricSourceType,
+			Resource: &autoscalingv2beta2.ResourceMetricSource{
+				Name:   resourceName,
+				Target: target,
+			},
+		})
+	}
+	return spec, nil
+}
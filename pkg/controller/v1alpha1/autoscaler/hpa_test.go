@@ -0,0 +1,198 @@
+package autoscalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/stretchr/testify/assert"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/api/v1alpha1"
+)
+
+func TestPrepareHPASpec(t *testing.T) {
+	targetWorkload := v1alpha1.TargetWorkload{APIVersion: "apps/v1", Kind: "Deployment", Name: "web"}
+	scaleTargetRef := autoscalingv2beta2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "web"}
+	averageValue := resource.MustParse("512Mi")
+
+	cases := map[string]struct {
+		spec    v1alpha1.AutoscalerSpec
+		expSpec autoscalingv2beta2.HorizontalPodAutoscalerSpec
+		expErr  bool
+	}{
+		"cpu and memory triggers": {
+			spec: v1alpha1.AutoscalerSpec{
+				MinReplicas:    pointer.Int32Ptr(1),
+				MaxReplicas:    pointer.Int32Ptr(5),
+				TargetWorkload: targetWorkload,
+				Triggers: []v1alpha1.Trigger{
+					{Type: CPUType, Condition: map[string]string{"type": "Utilization", "value": "80"}},
+					{Type: MemoryType, Condition: map[string]string{"type": "AverageValue", "value": "512Mi"}},
+				},
+			},
+			expSpec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: scaleTargetRef,
+				MinReplicas:    pointer.Int32Ptr(1),
+				MaxReplicas:    5,
+				Metrics: []autoscalingv2beta2.MetricSpec{{
+					Type: autoscalingv2beta2.ResourceMetricSourceType,
+					Resource: &autoscalingv2beta2.ResourceMetricSource{
+						Name:   corev1.ResourceCPU,
+						Target: autoscalingv2beta2.MetricTarget{Type: CPUUtilization, AverageUtilization: pointer.Int32Ptr(80)},
+					},
+				}, {
+					Type: autoscalingv2beta2.ResourceMetricSourceType,
+					Resource: &autoscalingv2beta2.ResourceMetricSource{
+						Name:   corev1.ResourceMemory,
+						Target: autoscalingv2beta2.MetricTarget{Type: MemoryAverageValue, AverageValue: &averageValue},
+					},
+				}},
+			},
+		},
+		"storage triggers": {
+			spec: v1alpha1.AutoscalerSpec{
+				TargetWorkload: targetWorkload,
+				Triggers: []v1alpha1.Trigger{
+					{Type: StorageType, Condition: map[string]string{"type": "AverageValue", "value": "512Mi"}},
+					{Type: EphemeralStorageType, Condition: map[string]string{"type": "Utilization", "value": "80"}},
+				},
+			},
+			expSpec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: scaleTargetRef,
+				MaxReplicas:    defaultHPAMaxReplicas,
+				Metrics: []autoscalingv2beta2.MetricSpec{{
+					Type: autoscalingv2beta2.ResourceMetricSourceType,
+					Resource: &autoscalingv2beta2.ResourceMetricSource{
+						Name:   corev1.ResourceStorage,
+						Target: autoscalingv2beta2.MetricTarget{Type: MemoryAverageValue, AverageValue: &averageValue},
+					},
+				}, {
+					Type: autoscalingv2beta2.ResourceMetricSourceType,
+					Resource: &autoscalingv2beta2.ResourceMetricSource{
+						Name:   corev1.ResourceEphemeralStorage,
+						Target: autoscalingv2beta2.MetricTarget{Type: CPUUtilization, AverageUtilization: pointer.Int32Ptr(80)},
+					},
+				}},
+			},
+		},
+		"maxReplicas not set": {
+			spec: v1alpha1.AutoscalerSpec{TargetWorkload: targetWorkload},
+			expSpec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: scaleTargetRef,
+				MaxReplicas:    defaultHPAMaxReplicas,
+			},
+		},
+		"cron trigger requires KEDA": {
+			spec: v1alpha1.AutoscalerSpec{
+				TargetWorkload: targetWorkload,
+				Triggers: []v1alpha1.Trigger{
+					{Type: CronType, Condition: map[string]string{"startAt": "09:00"}},
+				},
+			},
+			expErr: true,
+		},
+		"invalid utilization": {
+			spec: v1alpha1.AutoscalerSpec{
+				TargetWorkload: targetWorkload,
+				Triggers: []v1alpha1.Trigger{
+					{Type: CPUType, Condition: map[string]string{"type": "Utilization", "value": "high"}},
+				},
+			},
+			expErr: true,
+		},
+	}
+	for name, c := range cases {
+		got, err := prepareHPASpec(v1alpha1.Autoscaler{Spec: c.spec})
+		if c.expErr {
+			assert.Error(t, err, name)
+			continue
+		}
+		assert.NoError(t, err, name)
+		assert.Equal(t, c.expSpec, got, name)
+	}
+}
+
+func TestScaleByHPA(t *testing.T) {
+	scaler := v1alpha1.Autoscaler{}
+	scaler.SetName("scaler")
+	scaler.SetNamespace("default")
+	scaler.SetUID("scaler-uid")
+	spec := autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "web"},
+		MaxReplicas:    5,
+	}
+	cases := map[string]struct {
+		existing  *autoscalingv2beta2.HorizontalPodAutoscaler
+		expReason string
+		expErr    bool
+		expWrites int
+	}{
+		"HPA created": {
+			expReason: ReasonHPACreated,
+			expWrites: 1,
+		},
+		"HPA updated": {
+			existing: &autoscalingv2beta2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{OwnerReferences: scalerOwnerReferences(scaler)},
+				Spec:       autoscalingv2beta2.HorizontalPodAutoscalerSpec{MaxReplicas: 3},
+			},
+			expReason: ReasonHPAUpdated,
+			expWrites: 1,
+		},
+		"HPA up to date": {
+			existing: &autoscalingv2beta2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{OwnerReferences: scalerOwnerReferences(scaler)},
+				Spec:       spec,
+			},
+		},
+		"HPA without controller is not taken over": {
+			existing: &autoscalingv2beta2.HorizontalPodAutoscaler{Spec: spec},
+			expErr:   true,
+		},
+		"HPA owned but not controlled is not taken over": {
+			existing: &autoscalingv2beta2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "Autoscaler", Name: "scaler", UID: "scaler-uid"}}},
+				Spec: spec,
+			},
+			expErr: true,
+		},
+	}
+	for name, c := range cases {
+		writes := 0
+		r := AutoscalerReconciler{
+			Client: &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+					if c.existing == nil {
+						return apierrors.NewNotFound(schema.GroupResource{Group: "autoscaling",
+							Resource: "horizontalpodautoscalers"}, "scaler")
+					}
+					c.existing.DeepCopyInto(obj.(*autoscalingv2beta2.HorizontalPodAutoscaler))
+					return nil
+				},
+				MockCreate: func(_ context.Context, _ runtime.Object, _ ...client.CreateOption) error {
+					writes++
+					return nil
+				},
+				MockUpdate: func(_ context.Context, _ runtime.Object, _ ...client.UpdateOption) error {
+					writes++
+					return nil
+				},
+			},
+		}
+		reason, err := r.scaleByHPA(scaler, spec, "default", ctrl.Log.WithName("test"))
+		assert.Equal(t, c.expErr, err != nil, name)
+		assert.Equal(t, c.expReason, reason, name)
+		assert.Equal(t, c.expWrites, writes, name)
+	}
+}
@@ -0,0 +1,105 @@
+package autoscalers
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/oam-dev/kubevela/api/v1alpha1"
+)
+
+// metricLabels are the labels of all autoscaler metrics, trigger_type joins the sorted trigger types of the autoscaler
+// by comma, like `cpu,kafka`, it's empty if the autoscaler is not found
+var metricLabels = []string{"namespace", "trigger_type"}
+
+var (
+	reconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "vela_autoscaler_reconcile_total",
+		Help: "Total number of reconciles of autoscalers",
+	}, metricLabels)
+	reconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "vela_autoscaler_reconcile_errors_total",
+		Help: "Total number of reconciles of autoscalers which failed",
+	}, metricLabels)
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "vela_autoscaler_reconcile_duration_seconds",
+		Help:    "Duration of reconciles of autoscalers in seconds",
+		Buckets: prometheus.DefBuckets,
+	}, metricLabels)
+	scaledObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vela_autoscaler_scaled_objects",
+		Help: "Number of KEDA ScaledObjects managed by autoscalers",
+	}, metricLabels)
+)
+
+var registerMetricsOnce sync.Once
+
+// registerMetrics registers the autoscaler metrics with the controller-runtime registry, which are exposed by the
+// metrics endpoint of the manager, it's safe to call multiple times
+func registerMetrics() {
+	registerMetricsOnce.Do(func() {
+		metrics.Registry.MustRegister(reconcileTotal, reconcileErrors, reconcileDuration, scaledObjects)
+	})
+}
+
+// triggerTypeLabel joins the distinct trigger types of the autoscaler in order
+func triggerTypeLabel(triggers []v1alpha1.Trigger) string {
+	var triggerTypes []string
+	for _, t := range triggers {
+		if !containsKind(triggerTypes, string(t.Type)) {
+			triggerTypes = append(triggerTypes, string(t.Type))
+		}
+	}
+	sort.Strings(triggerTypes)
+	return strings.Join(triggerTypes, ",")
+}
+
+// observeReconcile records a reconcile of the autoscaler, its duration and whether it failed
+func observeReconcile(namespace string, triggers []v1alpha1.Trigger, duration time.Duration, err error) {
+	labels := prometheus.Labels{"namespace": namespace, "trigger_type": triggerTypeLabel(triggers)}
+	reconcileTotal.With(labels).Inc()
+	reconcileDuration.With(labels).Observe(duration.Seconds())
+	if err != nil {
+		reconcileErrors.With(labels).Inc()
+	}
+}
+
+// scaledObjectTracker tracks the autoscalers bound to KEDA ScaledObjects to count them by labels,
+// the count of labels no longer used is dropped instead of being left as zero
+type scaledObjectTracker struct {
+	mu      sync.Mutex
+	managed map[types.NamespacedName]prometheus.Labels
+}
+
+var managedScaledObjects = &scaledObjectTracker{managed: make(map[types.NamespacedName]prometheus.Labels)}
+
+// track records the autoscaler is bound to a ScaledObject, the labels are updated if its triggers changed
+func (t *scaledObjectTracker) track(key types.NamespacedName, triggers []v1alpha1.Trigger) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.managed[key] = prometheus.Labels{"namespace": key.Namespace, "trigger_type": triggerTypeLabel(triggers)}
+	t.refresh()
+}
+
+// untrack records the ScaledObject of the autoscaler is gone, with the autoscaler or replaced by an HPA
+func (t *scaledObjectTracker) untrack(key types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.managed[key]; !ok {
+		return
+	}
+	delete(t.managed, key)
+	t.refresh()
+}
+
+func (t *scaledObjectTracker) refresh() {
+	scaledObjects.Reset()
+	for _, labels := range t.managed {
+		scaledObjects.With(labels).Inc()
+	}
+}
@@ -0,0 +1,50 @@
+package autoscalers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/oam-dev/kubevela/api/v1alpha1"
+)
+
+func TestTriggerTypeLabel(t *testing.T) {
+	assert.Equal(t, "", triggerTypeLabel(nil))
+	assert.Equal(t, "cpu,kafka", triggerTypeLabel([]v1alpha1.Trigger{{Type: KafkaType}, {Type: CPUType}, {Type: KafkaType}}))
+}
+
+func TestObserveReconcile(t *testing.T) {
+	triggers := []v1alpha1.Trigger{{Type: CronType}}
+	total := reconcileTotal.WithLabelValues("metrics-test", "cron")
+	errs := reconcileErrors.WithLabelValues("metrics-test", "cron")
+
+	observeReconcile("metrics-test", triggers, time.Second, nil)
+	observeReconcile("metrics-test", triggers, time.Second, errors.New("boom"))
+	assert.Equal(t, float64(2), testutil.ToFloat64(total))
+	assert.Equal(t, float64(1), testutil.ToFloat64(errs))
+}
+
+func TestScaledObjectTracker(t *testing.T) {
+	tracker := &scaledObjectTracker{managed: make(map[types.NamespacedName]prometheus.Labels)}
+	web := types.NamespacedName{Namespace: "default", Name: "web"}
+	worker := types.NamespacedName{Namespace: "default", Name: "worker"}
+
+	tracker.track(web, []v1alpha1.Trigger{{Type: CPUType}})
+	tracker.track(worker, []v1alpha1.Trigger{{Type: CPUType}})
+	assert.Equal(t, float64(2), testutil.ToFloat64(scaledObjects.WithLabelValues("default", "cpu")))
+
+	// the labels follow the triggers
+	tracker.track(worker, []v1alpha1.Trigger{{Type: KafkaType}})
+	assert.Equal(t, float64(1), testutil.ToFloat64(scaledObjects.WithLabelValues("default", "cpu")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(scaledObjects.WithLabelValues("default", "kafka")))
+
+	tracker.untrack(web)
+	tracker.untrack(web)
+	assert.Equal(t, float64(0), testutil.ToFloat64(scaledObjects.WithLabelValues("default", "cpu")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(scaledObjects.WithLabelValues("default", "kafka")))
+}
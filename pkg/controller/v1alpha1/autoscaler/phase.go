@@ -0,0 +1,28 @@
+package autoscalers
+
+import (
+	cpv1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/oam-dev/kubevela/api/v1alpha1"
+)
+
+// computePhase summarizes the conditions of the autoscaler into a phase,
+// failing to reconcile has the highest priority, then the availability reported by the Ready condition
+func computePhase(status v1alpha1.AutoscalerStatus) v1alpha1.AutoscalerPhase {
+	synced := status.GetCondition(cpv1alpha1.TypeSynced)
+	ready := status.GetCondition(cpv1alpha1.TypeReady)
+	switch {
+	case synced.Status == corev1.ConditionFalse:
+		return v1alpha1.AutoscalerPhaseError
+	case ready.Status == corev1.ConditionFalse && ready.Reason == cpv1alpha1.ReasonUnavailable:
+		return v1alpha1.AutoscalerPhaseDegraded
+	case ready.Status == corev1.ConditionTrue:
+		return v1alpha1.AutoscalerPhaseReady
+	case synced.Status == corev1.ConditionTrue && ready.Status == corev1.ConditionUnknown:
+		// the Ready condition is not set, the autoscaler is ready once it's reconciled successfully
+		return v1alpha1.AutoscalerPhaseReady
+	default:
+		return v1alpha1.AutoscalerPhasePending
+	}
+}
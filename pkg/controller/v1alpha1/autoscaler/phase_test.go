@@ -0,0 +1,55 @@
+package autoscalers
+
+import (
+	"errors"
+	"testing"
+
+	cpv1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/oam-dev/kubevela/api/v1alpha1"
+)
+
+func TestComputePhase(t *testing.T) {
+	cases := map[string]struct {
+		conditions []cpv1alpha1.Condition
+		want       v1alpha1.AutoscalerPhase
+	}{
+		"no conditions": {
+			want: v1alpha1.AutoscalerPhasePending,
+		},
+		"creating": {
+			conditions: []cpv1alpha1.Condition{cpv1alpha1.Creating()},
+			want:       v1alpha1.AutoscalerPhasePending,
+		},
+		"reconciled successfully": {
+			conditions: []cpv1alpha1.Condition{cpv1alpha1.ReconcileSuccess()},
+			want:       v1alpha1.AutoscalerPhaseReady,
+		},
+		"reconciled successfully and available": {
+			conditions: []cpv1alpha1.Condition{cpv1alpha1.ReconcileSuccess(), cpv1alpha1.Available()},
+			want:       v1alpha1.AutoscalerPhaseReady,
+		},
+		"reconciled successfully but still creating": {
+			conditions: []cpv1alpha1.Condition{cpv1alpha1.ReconcileSuccess(), cpv1alpha1.Creating()},
+			want:       v1alpha1.AutoscalerPhasePending,
+		},
+		"reconciled successfully but unavailable": {
+			conditions: []cpv1alpha1.Condition{cpv1alpha1.ReconcileSuccess(), cpv1alpha1.Unavailable()},
+			want:       v1alpha1.AutoscalerPhaseDegraded,
+		},
+		"reconcile error": {
+			conditions: []cpv1alpha1.Condition{cpv1alpha1.ReconcileError(errors.New("boom"))},
+			want:       v1alpha1.AutoscalerPhaseError,
+		},
+		"reconcile error overrides availability": {
+			conditions: []cpv1alpha1.Condition{cpv1alpha1.ReconcileError(errors.New("boom")), cpv1alpha1.Available()},
+			want:       v1alpha1.AutoscalerPhaseError,
+		},
+	}
+	for name, tc := range cases {
+		status := v1alpha1.AutoscalerStatus{}
+		status.SetConditions(tc.conditions...)
+		assert.Equal(t, tc.want, computePhase(status), name)
+	}
+}
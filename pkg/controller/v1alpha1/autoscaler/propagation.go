@@ -0,0 +1,76 @@
+package autoscalers
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// metadataPropagation selects the labels and annotations of the parent AppConfig and the autoscaler which are copied
+// onto the ScaledObject. A key pattern matches the key itself, or the keys with its prefix if it ends with `*`, like
+// `example.com/*`. Nothing is propagated if no include pattern is set.
+type metadataPropagation struct {
+	include []string
+	exclude []string
+}
+
+// matchesKey tells whether the key matches any of the patterns
+func matchesKey(patterns []string, key string) bool {
+	for _, p := range patterns {
+		if strings.HasSuffix(p, "*") {
+			if strings.HasPrefix(key, strings.TrimSuffix(p, "*")) {
+				return true
+			}
+		} else if p == key {
+			return true
+		}
+	}
+	return false
+}
+
+// allowed tells whether the key is included and not excluded
+func (p metadataPropagation) allowed(key string) bool {
+	return matchesKey(p.include, key) && !matchesKey(p.exclude, key)
+}
+
+// merge copies the allowed keys of sources in order into a copy of target, the latter source takes precedence,
+// and keys set by others on the target are kept. It returns whether the target is changed.
+func (p metadataPropagation) merge(target map[string]string, sources ...map[string]string) (map[string]string, bool) {
+	merged := make(map[string]string, len(target))
+	for k, v := range target {
+		merged[k] = v
+	}
+	for _, source := range sources {
+		for k, v := range source {
+			if p.allowed(k) {
+				merged[k] = v
+			}
+		}
+	}
+	// keys are only added or overwritten, so comparing the values of the merged keys is enough
+	changed := false
+	for k, v := range merged {
+		if old, ok := target[k]; !ok || old != v {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return target, false
+	}
+	return merged, true
+}
+
+// propagate merges the labels and annotations of the parent, which could be nil, and the autoscaler into the object,
+// the autoscaler takes precedence over the parent. It returns whether the object is changed.
+func (p metadataPropagation) propagate(obj, parent, scaler metav1.Object) bool {
+	var parentLabels, parentAnnotations map[string]string
+	if parent != nil {
+		parentLabels, parentAnnotations = parent.GetLabels(), parent.GetAnnotations()
+	}
+	labels, labelsChanged := p.merge(obj.GetLabels(), parentLabels, scaler.GetLabels())
+	annotations, annotationsChanged := p.merge(obj.GetAnnotations(), parentAnnotations, scaler.GetAnnotations())
+	obj.SetLabels(labels)
+	obj.SetAnnotations(annotations)
+	return labelsChanged || annotationsChanged
+}
@@ -0,0 +1,76 @@
+package autoscalers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	kedav1alpha1 "github.com/wonderflow/keda-api/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/oam-dev/kubevela/api/v1alpha1"
+	"github.com/oam-dev/kubevela/pkg/controller/common"
+)
+
+func TestMetadataPropagationAllowed(t *testing.T) {
+	p := metadataPropagation{include: common.DefaultAutoscalerPropagatedKeys, exclude: common.DefaultAutoscalerExcludedKeys}
+	assert.True(t, p.allowed("cost-center"))
+	assert.True(t, p.allowed("app.oam.dev/name"))
+	assert.False(t, p.allowed("kubectl.kubernetes.io/last-applied-configuration"))
+	assert.False(t, p.allowed("trait.oam.dev/type"))
+
+	p = metadataPropagation{include: []string{"team", "example.com/*"}}
+	assert.True(t, p.allowed("team"))
+	assert.True(t, p.allowed("example.com/cost-center"))
+	assert.False(t, p.allowed("teams"))
+	// nothing is propagated by default
+	assert.False(t, metadataPropagation{}.allowed("team"))
+}
+
+func TestMetadataPropagationPropagate(t *testing.T) {
+	p := metadataPropagation{include: []string{"*"}, exclude: []string{"kubectl.kubernetes.io/*"}}
+	parent := &metav1.ObjectMeta{
+		Labels:      map[string]string{"team": "platform", "cost-center": "1001"},
+		Annotations: map[string]string{"kubectl.kubernetes.io/last-applied-configuration": "{}"},
+	}
+	scaler := &v1alpha1.Autoscaler{ObjectMeta: metav1.ObjectMeta{
+		Labels:      map[string]string{"cost-center": "1002"},
+		Annotations: map[string]string{"owner": "web-team"},
+	}}
+
+	cases := map[string]struct {
+		live           metav1.ObjectMeta
+		parent         metav1.Object
+		expLabels      map[string]string
+		expAnnotations map[string]string
+		expChanged     bool
+	}{
+		"the autoscaler takes precedence over the parent": {
+			parent:         parent,
+			expLabels:      map[string]string{"team": "platform", "cost-center": "1002"},
+			expAnnotations: map[string]string{"owner": "web-team"},
+			expChanged:     true,
+		},
+		"keys set by others are kept": {
+			live:           metav1.ObjectMeta{Labels: map[string]string{"scaledobject.keda.sh/name": "web"}},
+			expLabels:      map[string]string{"scaledobject.keda.sh/name": "web", "cost-center": "1002"},
+			expAnnotations: map[string]string{"owner": "web-team"},
+			expChanged:     true,
+		},
+		"up to date": {
+			live: metav1.ObjectMeta{
+				Labels:      map[string]string{"team": "platform", "cost-center": "1002"},
+				Annotations: map[string]string{"owner": "web-team"},
+			},
+			parent:         parent,
+			expLabels:      map[string]string{"team": "platform", "cost-center": "1002"},
+			expAnnotations: map[string]string{"owner": "web-team"},
+		},
+	}
+	for name, c := range cases {
+		obj := &kedav1alpha1.ScaledObject{ObjectMeta: c.live}
+		changed := p.propagate(obj, c.parent, scaler)
+		assert.Equal(t, c.expChanged, changed, name)
+		assert.Equal(t, c.expLabels, obj.GetLabels(), name)
+		assert.Equal(t, c.expAnnotations, obj.GetAnnotations(), name)
+	}
+}
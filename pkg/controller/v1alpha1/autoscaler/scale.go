@@ -0,0 +1,59 @@
+package autoscalers
+
+import (
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// scaleDiscovery tells whether a kind of resource exposes the scale subresource, which is required by KEDA and HPA
+// to scale it. The results are cached, so a CRD adding the scale subresource takes effect after restarting the controller.
+type scaleDiscovery struct {
+	client discovery.DiscoveryInterface
+
+	mu       sync.Mutex
+	scalable map[schema.GroupVersionKind]bool
+}
+
+func newScaleDiscovery(client discovery.DiscoveryInterface) *scaleDiscovery {
+	return &scaleDiscovery{client: client, scalable: make(map[schema.GroupVersionKind]bool)}
+}
+
+// isScalable discovers whether the kind exposes the scale subresource
+func (d *scaleDiscovery) isScalable(gvk schema.GroupVersionKind) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if scalable, ok := d.scalable[gvk]; ok {
+		return scalable, nil
+	}
+	resources, err := d.client.ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+	if err != nil {
+		return false, err
+	}
+	scalable := hasScaleSubresource(resources, gvk.Kind)
+	d.scalable[gvk] = scalable
+	return scalable, nil
+}
+
+// hasScaleSubresource finds the resource of the kind in the list and checks whether `<resource>/scale` is listed as well
+func hasScaleSubresource(resources *metav1.APIResourceList, kind string) bool {
+	var name string
+	for _, r := range resources.APIResources {
+		if r.Kind == kind && !strings.Contains(r.Name, "/") {
+			name = r.Name
+			break
+		}
+	}
+	if name == "" {
+		return false
+	}
+	for _, r := range resources.APIResources {
+		if r.Name == name+"/scale" {
+			return true
+		}
+	}
+	return false
+}
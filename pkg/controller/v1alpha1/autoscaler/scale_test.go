@@ -0,0 +1,97 @@
+package autoscalers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestResolveTargetWorkload(t *testing.T) {
+	newResource := func(apiVersion, kind, name string) *unstructured.Unstructured {
+		res := &unstructured.Unstructured{}
+		res.SetAPIVersion(apiVersion)
+		res.SetKind(kind)
+		res.SetName(name)
+		return res
+	}
+	dc := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: []*metav1.APIResourceList{
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "deployments", Kind: "Deployment"},
+				{Name: "deployments/scale", Kind: "Scale"},
+				{Name: "daemonsets", Kind: "DaemonSet"},
+			},
+		},
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{{Name: "services", Kind: "Service"}},
+		},
+		{
+			GroupVersion: "example.com/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "clonesets", Kind: "CloneSet"},
+				{Name: "clonesets/scale", Kind: "Scale"},
+			},
+		},
+	}}}
+	deploy := newResource("apps/v1", "Deployment", "web")
+	daemonSet := newResource("apps/v1", "DaemonSet", "agent")
+	cloneSet := newResource("example.com/v1", "CloneSet", "web")
+	svc := newResource("v1", "Service", "web")
+	workload := newResource("standard.oam.dev/v1alpha1", "PodSpecWorkload", "web")
+
+	cases := map[string]struct {
+		resources []*unstructured.Unstructured
+		discover  bool
+		expTarget *unstructured.Unstructured
+	}{
+		"chosen by the priority of kinds": {
+			resources: []*unstructured.Unstructured{svc, cloneSet, deploy, workload},
+			discover:  true,
+			expTarget: deploy,
+		},
+		"kinds without the scale subresource are skipped": {
+			resources: []*unstructured.Unstructured{daemonSet, cloneSet, svc, workload},
+			discover:  true,
+			expTarget: cloneSet,
+		},
+		"kinds are trusted without discovery": {
+			resources: []*unstructured.Unstructured{daemonSet, cloneSet, svc, workload},
+			expTarget: daemonSet,
+		},
+		"CRD-based workloads are not chosen without discovery": {
+			resources: []*unstructured.Unstructured{cloneSet, svc, workload},
+			expTarget: workload,
+		},
+	}
+	for name, c := range cases {
+		r := AutoscalerReconciler{targetKinds: []string{"Deployment", "DaemonSet"}}
+		if c.discover {
+			r.scale = newScaleDiscovery(dc)
+		}
+		target, err := r.resolveTargetWorkload(c.resources)
+		assert.NoError(t, err, name)
+		assert.Equal(t, c.expTarget, target, name)
+	}
+}
+
+func TestHasScaleSubresource(t *testing.T) {
+	resources := &metav1.APIResourceList{
+		GroupVersion: "apps/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "deployments", Kind: "Deployment"},
+			{Name: "deployments/status", Kind: "Deployment"},
+			{Name: "deployments/scale", Kind: "Scale"},
+			{Name: "daemonsets", Kind: "DaemonSet"},
+			{Name: "daemonsets/status", Kind: "DaemonSet"},
+		},
+	}
+	assert.True(t, hasScaleSubresource(resources, "Deployment"))
+	assert.False(t, hasScaleSubresource(resources, "DaemonSet"))
+	assert.False(t, hasScaleSubresource(resources, "StatefulSet"))
+}
@@ -1,10 +1,39 @@
 package autoscalers
 
 import (
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+
 	"github.com/oam-dev/kubevela/api/v1alpha1"
 )
 
 const (
-	CronType v1alpha1.TriggerType = "cron"
-	CPUType  v1alpha1.TriggerType = "cpu"
+	CronType   v1alpha1.TriggerType = "cron"
+	CPUType    v1alpha1.TriggerType = "cpu"
+	MemoryType v1alpha1.TriggerType = "memory"
+	// StorageType and EphemeralStorageType scale the workload by the storage usage of its pods, like cpu triggers,
+	// they're passed through to KEDA as is, and served by the native HPA if KEDA is not installed
+	StorageType          v1alpha1.TriggerType = "storage"
+	EphemeralStorageType v1alpha1.TriggerType = "ephemeral-storage"
+	// PrometheusType scales the workload by the result of a Prometheus query
+	PrometheusType v1alpha1.TriggerType = "prometheus"
+	// KafkaType scales the workload by the lag of a Kafka consumer group
+	KafkaType v1alpha1.TriggerType = "kafka"
+	// RabbitMQType scales the workload by the length or the message rate of a RabbitMQ queue
+	RabbitMQType v1alpha1.TriggerType = "rabbitmq"
+	// ExternalType scales the workload by an external scaler implementing the KEDA external scaler gRPC interface,
+	// the condition is passed through to KEDA as is, so any scaler not modeled here could be used
+	ExternalType v1alpha1.TriggerType = "external"
+)
+
+// supportedTriggerTypes are the trigger types which could be converted into KEDA triggers, any other type is rejected,
+// scalers of KEDA not modeled here could be used by external triggers
+var supportedTriggerTypes = []v1alpha1.TriggerType{CPUType, MemoryType, StorageType, EphemeralStorageType, CronType,
+	PrometheusType, KafkaType, RabbitMQType, ExternalType}
+
+const (
+	// CPUUtilization is the metric target type of resource triggers, which targets the percentage of the requests
+	CPUUtilization autoscalingv2beta2.MetricTargetType = "Utilization"
+	// MemoryAverageValue is the metric target type of memory and storage triggers, which targets an absolute quantity
+	// like 512Mi
+	MemoryAverageValue autoscalingv2beta2.MetricTargetType = "AverageValue"
 )
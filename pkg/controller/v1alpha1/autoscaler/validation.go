@@ -0,0 +1,168 @@
+package autoscalers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/oam-dev/kubevela/api/v1alpha1"
+)
+
+// ValidateSpec checks the autoscaler spec which KEDA would accept but fail obscurely with, an autoscaler without
+// triggers is rejected as there is nothing to scale by. It's shared by the reconcile and the validating webhook.
+func ValidateSpec(spec v1alpha1.AutoscalerSpec) error {
+	if err := validateReplicas(spec); err != nil {
+		return err
+	}
+	if err := validatePeriods(spec); err != nil {
+		return err
+	}
+	if len(spec.Triggers) == 0 {
+		return errors.New(SpecWarningTriggersRequired)
+	}
+	if err := validateTriggerTypes(spec); err != nil {
+		return err
+	}
+	if err := validateCronWindows(spec); err != nil {
+		return err
+	}
+	if err := validateAuthRefs(spec); err != nil {
+		return err
+	}
+	if err := validateExternalTriggers(spec); err != nil {
+		return err
+	}
+	return validateScaleToZero(spec)
+}
+
+// validateReplicas checks replicas are not negative, minReplicas is not greater than maxReplicas, and idleReplicas is
+// less than minReplicas
+func validateReplicas(spec v1alpha1.AutoscalerSpec) error {
+	replicas := []struct {
+		field string
+		value *int32
+	}{
+		{"minReplicas", spec.MinReplicas},
+		{"maxReplicas", spec.MaxReplicas},
+		{"initialReplicas", spec.InitialReplicas},
+		{"idleReplicas", spec.IdleReplicas},
+	}
+	for _, r := range replicas {
+		if r.value != nil && *r.value < 0 {
+			return fmt.Errorf(SpecWarningNegativeReplicas, r.field, *r.value)
+		}
+	}
+	if spec.MinReplicas != nil && spec.MaxReplicas != nil && *spec.MinReplicas > *spec.MaxReplicas {
+		return fmt.Errorf(SpecWarningMinReplicasGreaterThanMax, *spec.MinReplicas, *spec.MaxReplicas)
+	}
+	// KEDA takes 0 as minReplicas if it's not set, so idleReplicas can't be used without a positive minReplicas
+	if spec.IdleReplicas != nil {
+		var minReplicas int32
+		if spec.MinReplicas != nil {
+			minReplicas = *spec.MinReplicas
+		}
+		if *spec.IdleReplicas >= minReplicas {
+			return fmt.Errorf(SpecWarningIdleReplicasNotLessThanMin, *spec.IdleReplicas, minReplicas)
+		}
+	}
+	return nil
+}
+
+// validatePeriods checks cooldownPeriod and pollingInterval are not negative seconds
+func validatePeriods(spec v1alpha1.AutoscalerSpec) error {
+	if spec.CooldownPeriod != nil && *spec.CooldownPeriod < 0 {
+		return fmt.Errorf(SpecWarningNegativeSeconds, "cooldownPeriod", *spec.CooldownPeriod)
+	}
+	if spec.PollingInterval != nil && *spec.PollingInterval < 0 {
+		return fmt.Errorf(SpecWarningNegativeSeconds, "pollingInterval", *spec.PollingInterval)
+	}
+	return nil
+}
+
+// validateTriggerTypes checks all triggers are of the supported types, so that a typo like `cpuu` isn't passed through
+// to KEDA silently
+func validateTriggerTypes(spec v1alpha1.AutoscalerSpec) error {
+	for _, t := range spec.Triggers {
+		supported := false
+		for _, typ := range supportedTriggerTypes {
+			if t.Type == typ {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return fmt.Errorf(SpecWarningTriggerTypeUnsupported, t.Type, supportedTriggerTypes)
+		}
+	}
+	return nil
+}
+
+// validateCronWindows checks the start time and duration of all windows of cron triggers
+func validateCronWindows(spec v1alpha1.AutoscalerSpec) error {
+	for _, t := range spec.Triggers {
+		if t.Type != CronType {
+			continue
+		}
+		condition, err := GetCronTypeCondition(t.Condition)
+		if err != nil {
+			return err
+		}
+		windows, _, err := getCronWindows(condition, t)
+		if err != nil {
+			return err
+		}
+		for _, w := range windows {
+			if _, _, err := parseCronWindow(w.StartAt, w.Duration); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateScaleToZero checks scale-to-zero isn't combined with resource triggers, as KEDA can't scale to zero with
+// cpu, memory or storage triggers, which will silently ignore the zero minReplicas or idleReplicas
+func validateScaleToZero(spec v1alpha1.AutoscalerSpec) error {
+	var field string
+	switch {
+	case spec.MinReplicas != nil && *spec.MinReplicas == 0:
+		field = "minReplicas"
+	case spec.IdleReplicas != nil && *spec.IdleReplicas == 0:
+		// scaling to zero only when idle is blocked by resource triggers the same way
+		field = "idleReplicas"
+	default:
+		return nil
+	}
+	for _, t := range spec.Triggers {
+		if _, ok := resourceTriggers[t.Type]; ok {
+			return fmt.Errorf(SpecWarningScaleToZeroWithResourceTrigger, field, t.Type)
+		}
+	}
+	return nil
+}
+
+// validateAuthRefs checks the authRef of triggers references either a TriggerAuthentication or the keys of a Secret
+func validateAuthRefs(spec v1alpha1.AutoscalerSpec) error {
+	for _, t := range spec.Triggers {
+		if t.AuthRef == nil {
+			continue
+		}
+		if t.AuthRef.Name == "" && t.AuthRef.SecretName == "" {
+			return errors.New(SpecWarningAuthRefRequired)
+		}
+		if t.AuthRef.SecretName != "" && len(t.AuthRef.SecretKeys) == 0 {
+			return errors.New(SpecWarningSecretKeysRequired)
+		}
+	}
+	return nil
+}
+
+// validateExternalTriggers checks the scalerAddress of external triggers is set, the rest of the condition is up to
+// the external scaler
+func validateExternalTriggers(spec v1alpha1.AutoscalerSpec) error {
+	for _, t := range spec.Triggers {
+		if t.Type == ExternalType && t.Condition["scalerAddress"] == "" {
+			return errors.New(SpecWarningScalerAddressRequired)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package autoscalers
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	"github.com/oam-dev/kubevela/api/v1alpha1"
+)
+
+func TestValidateSpec(t *testing.T) {
+	cpuTrigger := v1alpha1.Trigger{Type: CPUType, Condition: map[string]string{"type": "Utilization", "value": "50"}}
+	assert.NoError(t, ValidateSpec(v1alpha1.AutoscalerSpec{Triggers: []v1alpha1.Trigger{cpuTrigger, cpuTrigger}}))
+	assert.Equal(t, errors.New(SpecWarningTriggersRequired), ValidateSpec(v1alpha1.AutoscalerSpec{}))
+	assert.Equal(t, errors.New(SpecWarningTriggersRequired),
+		ValidateSpec(v1alpha1.AutoscalerSpec{Triggers: []v1alpha1.Trigger{}}))
+}
+
+func TestValidateScaleToZero(t *testing.T) {
+	cpuTrigger := v1alpha1.Trigger{Type: CPUType, Condition: map[string]string{"type": "Utilization", "value": "50"}}
+	memoryTrigger := v1alpha1.Trigger{Type: MemoryType, Condition: map[string]string{"type": "Utilization", "value": "50"}}
+	cronTrigger := v1alpha1.Trigger{Type: CronType, Condition: map[string]string{"startAt": "08:00", "duration": "2h",
+		"days": "Monday", "replicas": "2"}}
+
+	cases := map[string]struct {
+		spec   v1alpha1.AutoscalerSpec
+		expErr error
+	}{
+		"minReplicas not set with cpu trigger": {
+			spec: v1alpha1.AutoscalerSpec{Triggers: []v1alpha1.Trigger{cpuTrigger}},
+		},
+		"non-zero minReplicas with cpu trigger": {
+			spec: v1alpha1.AutoscalerSpec{MinReplicas: pointer.Int32Ptr(1), Triggers: []v1alpha1.Trigger{cpuTrigger}},
+		},
+		"scale-to-zero with cron trigger": {
+			spec: v1alpha1.AutoscalerSpec{MinReplicas: pointer.Int32Ptr(0), Triggers: []v1alpha1.Trigger{cronTrigger}},
+		},
+		"scale-to-zero with cpu trigger": {
+			spec:   v1alpha1.AutoscalerSpec{MinReplicas: pointer.Int32Ptr(0), Triggers: []v1alpha1.Trigger{cpuTrigger}},
+			expErr: fmt.Errorf(SpecWarningScaleToZeroWithResourceTrigger, "minReplicas", CPUType),
+		},
+		"scale-to-zero with ephemeral-storage trigger": {
+			spec: v1alpha1.AutoscalerSpec{MinReplicas: pointer.Int32Ptr(0),
+				Triggers: []v1alpha1.Trigger{{Type: EphemeralStorageType}}},
+			expErr: fmt.Errorf(SpecWarningScaleToZeroWithResourceTrigger, "minReplicas", EphemeralStorageType),
+		},
+		"scale-to-zero with cron and memory triggers": {
+			spec: v1alpha1.AutoscalerSpec{MinReplicas: pointer.Int32Ptr(0),
+				Triggers: []v1alpha1.Trigger{cronTrigger, memoryTrigger}},
+			expErr: fmt.Errorf(SpecWarningScaleToZeroWithResourceTrigger, "minReplicas", MemoryType),
+		},
+		"scale to zero when idle with cron trigger": {
+			spec: v1alpha1.AutoscalerSpec{IdleReplicas: pointer.Int32Ptr(0), MinReplicas: pointer.Int32Ptr(1),
+				Triggers: []v1alpha1.Trigger{cronTrigger}},
+		},
+		"scale to zero when idle with cpu trigger": {
+			spec: v1alpha1.AutoscalerSpec{IdleReplicas: pointer.Int32Ptr(0), MinReplicas: pointer.Int32Ptr(1),
+				Triggers: []v1alpha1.Trigger{cpuTrigger}},
+			expErr: fmt.Errorf(SpecWarningScaleToZeroWithResourceTrigger, "idleReplicas", CPUType),
+		},
+	}
+	for caseName, c := range cases {
+		err := validateScaleToZero(c.spec)
+		assert.Equal(t, c.expErr, err, caseName)
+	}
+}
+
+func TestValidateReplicas(t *testing.T) {
+	cases := map[string]struct {
+		spec   v1alpha1.AutoscalerSpec
+		expErr error
+	}{
+		"replicas not set": {
+			spec: v1alpha1.AutoscalerSpec{},
+		},
+		"min equals to max": {
+			spec: v1alpha1.AutoscalerSpec{MinReplicas: pointer.Int32Ptr(2), MaxReplicas: pointer.Int32Ptr(2)},
+		},
+		"min greater than max": {
+			spec:   v1alpha1.AutoscalerSpec{MinReplicas: pointer.Int32Ptr(5), MaxReplicas: pointer.Int32Ptr(2)},
+			expErr: fmt.Errorf(SpecWarningMinReplicasGreaterThanMax, 5, 2),
+		},
+		"negative min": {
+			spec:   v1alpha1.AutoscalerSpec{MinReplicas: pointer.Int32Ptr(-1), MaxReplicas: pointer.Int32Ptr(2)},
+			expErr: fmt.Errorf(SpecWarningNegativeReplicas, "minReplicas", -1),
+		},
+		"negative max": {
+			spec:   v1alpha1.AutoscalerSpec{MaxReplicas: pointer.Int32Ptr(-3)},
+			expErr: fmt.Errorf(SpecWarningNegativeReplicas, "maxReplicas", -3),
+		},
+		"negative initial replicas": {
+			spec:   v1alpha1.AutoscalerSpec{InitialReplicas: pointer.Int32Ptr(-2)},
+			expErr: fmt.Errorf(SpecWarningNegativeReplicas, "initialReplicas", -2),
+		},
+		"idle less than min": {
+			spec: v1alpha1.AutoscalerSpec{IdleReplicas: pointer.Int32Ptr(1), MinReplicas: pointer.Int32Ptr(2)},
+		},
+		"idle equals to min": {
+			spec:   v1alpha1.AutoscalerSpec{IdleReplicas: pointer.Int32Ptr(2), MinReplicas: pointer.Int32Ptr(2)},
+			expErr: fmt.Errorf(SpecWarningIdleReplicasNotLessThanMin, 2, 2),
+		},
+		"idle without min": {
+			spec:   v1alpha1.AutoscalerSpec{IdleReplicas: pointer.Int32Ptr(0)},
+			expErr: fmt.Errorf(SpecWarningIdleReplicasNotLessThanMin, 0, 0),
+		},
+		"negative idle replicas": {
+			spec:   v1alpha1.AutoscalerSpec{IdleReplicas: pointer.Int32Ptr(-1), MinReplicas: pointer.Int32Ptr(2)},
+			expErr: fmt.Errorf(SpecWarningNegativeReplicas, "idleReplicas", -1),
+		},
+	}
+	for caseName, c := range cases {
+		err := validateReplicas(c.spec)
+		assert.Equal(t, c.expErr, err, caseName)
+	}
+}
+
+func TestValidatePeriods(t *testing.T) {
+	cases := map[string]struct {
+		spec   v1alpha1.AutoscalerSpec
+		expErr error
+	}{
+		"periods not set": {
+			spec: v1alpha1.AutoscalerSpec{},
+		},
+		"valid periods": {
+			spec: v1alpha1.AutoscalerSpec{CooldownPeriod: pointer.Int32Ptr(60), PollingInterval: pointer.Int32Ptr(0)},
+		},
+		"negative cooldownPeriod": {
+			spec:   v1alpha1.AutoscalerSpec{CooldownPeriod: pointer.Int32Ptr(-1)},
+			expErr: fmt.Errorf(SpecWarningNegativeSeconds, "cooldownPeriod", -1),
+		},
+		"negative pollingInterval": {
+			spec:   v1alpha1.AutoscalerSpec{PollingInterval: pointer.Int32Ptr(-5)},
+			expErr: fmt.Errorf(SpecWarningNegativeSeconds, "pollingInterval", -5),
+		},
+	}
+	for caseName, c := range cases {
+		err := validatePeriods(c.spec)
+		assert.Equal(t, c.expErr, err, caseName)
+	}
+}
+
+func TestValidateTriggerTypes(t *testing.T) {
+	cases := map[string]struct {
+		triggers []v1alpha1.Trigger
+		expErr   error
+	}{
+		"supported triggers": {
+			triggers: []v1alpha1.Trigger{{Type: CPUType}, {Type: CronType}, {Type: RabbitMQType}},
+		},
+		"storage triggers": {
+			triggers: []v1alpha1.Trigger{{Type: StorageType}, {Type: EphemeralStorageType}},
+		},
+		"no trigger": {},
+		"typo of trigger type": {
+			triggers: []v1alpha1.Trigger{{Type: CPUType}, {Type: "cpuu"}},
+			expErr:   fmt.Errorf(SpecWarningTriggerTypeUnsupported, "cpuu", supportedTriggerTypes),
+		},
+	}
+	for caseName, c := range cases {
+		err := validateTriggerTypes(v1alpha1.AutoscalerSpec{Triggers: c.triggers})
+		assert.Equal(t, c.expErr, err, caseName)
+	}
+}
+
+func TestValidateCronWindows(t *testing.T) {
+	cronTrigger := func(startAt string) v1alpha1.Trigger {
+		return v1alpha1.Trigger{Type: CronType, Condition: map[string]string{"startAt": startAt, "duration": "2h",
+			"days": "Monday", "replicas": "2"}}
+	}
+	cases := map[string]struct {
+		triggers []v1alpha1.Trigger
+		expErr   bool
+	}{
+		"valid windows": {
+			triggers: []v1alpha1.Trigger{cronTrigger("08:00"), {Type: CPUType}},
+		},
+		"invalid startAt": {
+			triggers: []v1alpha1.Trigger{cronTrigger("25:00")},
+			expErr:   true,
+		},
+		"invalid extra window": {
+			triggers: []v1alpha1.Trigger{{Type: CronType, Condition: map[string]string{"days": "Monday"},
+				Windows: []v1alpha1.CronWindow{{StartAt: "12:60", Duration: "1h", Replicas: 2}}}},
+			expErr: true,
+		},
+	}
+	for caseName, c := range cases {
+		err := validateCronWindows(v1alpha1.AutoscalerSpec{Triggers: c.triggers})
+		assert.Equal(t, c.expErr, err != nil, caseName)
+	}
+}
+
+func TestValidateAuthRefs(t *testing.T) {
+	cases := map[string]struct {
+		authRef *v1alpha1.TriggerAuthRef
+		expErr  error
+	}{
+		"no authRef": {},
+		"reference a TriggerAuthentication": {
+			authRef: &v1alpha1.TriggerAuthRef{Name: "kafka-auth"},
+		},
+		"reference keys of a Secret": {
+			authRef: &v1alpha1.TriggerAuthRef{SecretName: "kafka", SecretKeys: map[string]string{"password": "password"}},
+		},
+		"empty authRef": {
+			authRef: &v1alpha1.TriggerAuthRef{},
+			expErr:  errors.New(SpecWarningAuthRefRequired),
+		},
+		"secret without keys": {
+			authRef: &v1alpha1.TriggerAuthRef{SecretName: "kafka"},
+			expErr:  errors.New(SpecWarningSecretKeysRequired),
+		},
+	}
+	for caseName, c := range cases {
+		err := validateAuthRefs(v1alpha1.AutoscalerSpec{Triggers: []v1alpha1.Trigger{{Type: KafkaType, AuthRef: c.authRef}}})
+		assert.Equal(t, c.expErr, err, caseName)
+	}
+}
+
+func TestValidateExternalTriggers(t *testing.T) {
+	cases := map[string]struct {
+		triggers []v1alpha1.Trigger
+		expErr   error
+	}{
+		"scalerAddress is set": {
+			triggers: []v1alpha1.Trigger{{Type: ExternalType, Condition: map[string]string{
+				"scalerAddress": "external-scaler.default:8080"}}},
+		},
+		"no external trigger": {
+			triggers: []v1alpha1.Trigger{{Type: CPUType}},
+		},
+		"scalerAddress is missing": {
+			triggers: []v1alpha1.Trigger{{Type: ExternalType, Condition: map[string]string{"queue": "orders"}}},
+			expErr:   errors.New(SpecWarningScalerAddressRequired),
+		},
+	}
+	for caseName, c := range cases {
+		err := validateExternalTriggers(v1alpha1.AutoscalerSpec{Triggers: c.triggers})
+		assert.Equal(t, c.expErr, err, caseName)
+	}
+}
@@ -107,6 +107,10 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		mLog.Error(err, "add events to metricsTrait itself", "name", metricsTrait.Name)
 		eventObj = &metricsTrait
 	}
+	if common.IsPaused(eventObj) {
+		mLog.Info("The application is paused, skip reconciling", "name", metricsTrait.Name)
+		return common.PausedWaitResult, nil
+	}
 	if metricsTrait.Spec.ScrapeService.Enabled != nil && !*metricsTrait.Spec.ScrapeService.Enabled {
 		r.record.Event(eventObj, event.Normal("Metrics Trait disabled", "no op"))
 		r.gcOrphanServiceMonitor(ctx, mLog, &metricsTrait)
@@ -335,7 +339,7 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 }
 
 // Setup adds a controller that reconciles MetricsTrait.
-func Setup(mgr ctrl.Manager) error {
+func Setup(mgr ctrl.Manager, _ common.Args) error {
 	dm, err := discoverymapper.New(mgr.GetConfig())
 	if err != nil {
 		return err
@@ -36,6 +36,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/oam-dev/kubevela/api/v1alpha1"
+	"github.com/oam-dev/kubevela/pkg/controller/common"
 )
 
 // Reconcile error strings.
@@ -89,6 +90,10 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		log.Error(err, "workload", "name", workload.Name)
 		eventObj = &workload
 	}
+	if common.IsPaused(eventObj) {
+		log.Info("The application is paused, skip reconciling", "name", workload.Name)
+		return common.PausedWaitResult, nil
+	}
 	deploy, err := r.renderDeployment(ctx, &workload)
 	if err != nil {
 		log.Error(err, "Failed to render a deployment")
@@ -278,7 +283,7 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 }
 
 // Setup adds a controller that reconciles PodSpecWorkload.
-func Setup(mgr ctrl.Manager) error {
+func Setup(mgr ctrl.Manager, _ common.Args) error {
 	reconciler := Reconciler{
 		Client: mgr.GetClient(),
 		log:    ctrl.Log.WithName("PodSpecWorkload"),
@@ -88,6 +88,10 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		mLog.Error(err, "add events to route trait itself", "name", routeTrait.Name)
 		eventObj = &routeTrait
 	}
+	if common.IsPaused(eventObj) {
+		mLog.Info("The application is paused, skip reconciling", "name", routeTrait.Name)
+		return common.PausedWaitResult, nil
+	}
 
 	// Fetch the workload instance to which we want to do routes
 	workload, err := oamutil.FetchWorkload(ctx, r, mLog, &routeTrait)
@@ -318,7 +322,7 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 }
 
 // Setup adds a controller that reconciles MetricsTrait.
-func Setup(mgr ctrl.Manager) error {
+func Setup(mgr ctrl.Manager, _ common.Args) error {
 	dm, err := discoverymapper.New(mgr.GetConfig())
 	if err != nil {
 		return err
@@ -3,12 +3,15 @@ package routes
 import (
 	"context"
 	"errors"
+	"testing"
 	"time"
 
 	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
 
 	"github.com/oam-dev/kubevela/api/v1alpha1"
 
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
 	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam/util"
 	. "github.com/onsi/ginkgo"
@@ -21,7 +24,12 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	velatypes "github.com/oam-dev/kubevela/api/types"
+	"github.com/oam-dev/kubevela/pkg/controller/common"
 )
 
 var _ = Describe("Route Trait Integration Test", func() {
@@ -351,3 +359,42 @@ var _ = Describe("Route Trait Integration Test", func() {
 		Expect(createdSvc.Spec.Ports[0].TargetPort.IntVal).Should(Equal(int32(podPort)))
 	})
 })
+
+func TestReconcilePausedRoute(t *testing.T) {
+	g := NewGomegaWithT(t)
+	paused := true
+	statusPatched := false
+	r := &Reconciler{
+		Client: &test.MockClient{
+			MockGet: func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+				route := obj.(*v1alpha1.Route)
+				route.SetName("route")
+				route.SetNamespace("default")
+				if paused {
+					route.SetAnnotations(map[string]string{velatypes.AnnPause: "true"})
+				}
+				return nil
+			},
+			MockStatusPatch: func(_ context.Context, _ runtime.Object, _ client.Patch, _ ...client.PatchOption) error {
+				statusPatched = true
+				return nil
+			},
+		},
+		Log:    logf.Log.WithName("test"),
+		record: event.NewNopRecorder(),
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "route"}}
+
+	// the paused route is checked again later, without touching anything
+	result, err := r.Reconcile(req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(common.PausedWaitResult))
+	g.Expect(statusPatched).To(BeFalse())
+
+	// it's reconciled once resumed, which fails to fetch the workload as no workload is referred
+	paused = false
+	result, err = r.Reconcile(req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(util.ReconcileWaitResult))
+	g.Expect(statusPatched).To(BeTrue())
+}
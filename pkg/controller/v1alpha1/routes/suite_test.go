@@ -41,6 +41,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	standardv1alpha1 "github.com/oam-dev/kubevela/api/v1alpha1"
+	"github.com/oam-dev/kubevela/pkg/controller/common"
 	"github.com/oam-dev/kubevela/pkg/controller/v1alpha1/podspecworkload"
 	// +kubebuilder:scaffold:imports
 )
@@ -107,7 +108,7 @@ var _ = BeforeSuite(func(done Done) {
 	}
 	Expect(r.SetupWithManager(mgr)).ToNot(HaveOccurred())
 	Expect(applicationconfiguration.Setup(mgr, controller.Args{}, logging.NewLogrLogger(ctrl.Log.WithName("AppConfig")))).ToNot(HaveOccurred())
-	Expect(podspecworkload.Setup(mgr)).ToNot(HaveOccurred())
+	Expect(podspecworkload.Setup(mgr, common.Args{})).ToNot(HaveOccurred())
 
 	controllerDone = make(chan struct{}, 1)
 	// +kubebuilder:scaffold:builder
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/oam-dev/kubevela/api/types"
@@ -15,7 +16,10 @@ import (
 	"github.com/spf13/cobra"
 
 	corev1alpha2 "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
+	gocmp "github.com/google/go-cmp/cmp"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -87,7 +91,19 @@ func ListApplications(ctx context.Context, c client.Client, opt Option) ([]apis.
 func ListApplicationConfigurations(ctx context.Context, c client.Client, opt Option) (corev1alpha2.ApplicationConfigurationList, error) {
 	var appConfigList corev1alpha2.ApplicationConfigurationList
 
-	if opt.AppName != "" {
+	if opt.AppName != "" && opt.Namespace == "" {
+		// the application may be in any namespace
+		if err := c.List(ctx, &appConfigList); err != nil {
+			return appConfigList, err
+		}
+		var matched []corev1alpha2.ApplicationConfiguration
+		for _, a := range appConfigList.Items {
+			if a.Name == opt.AppName {
+				matched = append(matched, a)
+			}
+		}
+		appConfigList.Items = matched
+	} else if opt.AppName != "" {
 		var appConfig corev1alpha2.ApplicationConfiguration
 		if err := c.Get(ctx, client.ObjectKey{Name: opt.AppName, Namespace: opt.Namespace}, &appConfig); err != nil {
 			return appConfigList, err
@@ -112,7 +128,7 @@ func ListComponents(ctx context.Context, c client.Client, opt Option) ([]apis.Co
 
 	for _, a := range appConfigList.Items {
 		for _, com := range a.Spec.Components {
-			component, err := cmdutil.GetComponent(ctx, c, com.ComponentName, opt.Namespace)
+			component, err := cmdutil.GetComponent(ctx, c, com.ComponentName, a.Namespace)
 			if err != nil {
 				return componentMetaList, err
 			}
@@ -123,6 +139,7 @@ func ListComponents(ctx context.Context, c client.Client, opt Option) ([]apis.Co
 				Component:   component,
 				AppConfig:   a,
 				App:         a.Name,
+				Namespace:   a.Namespace,
 			})
 		}
 	}
@@ -130,6 +147,130 @@ func ListComponents(ctx context.Context, c client.Client, opt Option) ([]apis.Co
 	return componentMetaList, nil
 }
 
+// ListServices lists the services of all applications in the env, or only the ones of appName if it's specified,
+// both deployed services and staging services of local appfiles are listed
+func ListServices(ctx context.Context, c client.Client, appName string, env *types.EnvMeta, ioStreams cmdutil.IOStreams) ([]apis.ComponentMeta, error) {
+	return ListServicesInNamespace(ctx, c, appName, env.Namespace, env, ioStreams)
+}
+
+// ListServicesInNamespace lists the services in the namespace, or in all namespaces if namespace is empty. Local appfiles
+// only belong to the namespace of the env, so staging services are merged for that namespace only, services in other
+// namespaces are listed as they're deployed.
+func ListServicesInNamespace(ctx context.Context, c client.Client, appName, namespace string, env *types.EnvMeta,
+	ioStreams cmdutil.IOStreams) ([]apis.ComponentMeta, error) {
+	deployed, err := ListComponents(ctx, c, Option{
+		AppName:   appName,
+		Namespace: namespace,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var inEnv, others []apis.ComponentMeta
+	for _, comp := range deployed {
+		if comp.Namespace == env.Namespace {
+			inEnv = append(inEnv, comp)
+		} else {
+			others = append(others, comp)
+		}
+	}
+	if namespace == "" || namespace == env.Namespace {
+		inEnv = mergeStagingComponents(inEnv, env, ioStreams)
+	}
+	return append(inEnv, others...), nil
+}
+
+// ToServiceListItems converts the services listed by ListServices into the schema printed by `vela ls`
+func ToServiceListItems(comps []apis.ComponentMeta) []apis.ServiceListItem {
+	items := make([]apis.ServiceListItem, 0, len(comps))
+	for _, comp := range comps {
+		traits := comp.TraitNames
+		if traits == nil {
+			traits = []string{}
+		}
+		items = append(items, apis.ServiceListItem{
+			Name:        comp.Name,
+			App:         comp.App,
+			Type:        comp.WorkloadName,
+			Traits:      traits,
+			Status:      comp.Status,
+			CreatedTime: comp.CreatedTime,
+			Namespace:   comp.Namespace,
+		})
+	}
+	return items
+}
+
+// mergeStagingComponents merges the services of local appfiles into the deployed ones, the services not deployed yet
+// or changed locally are marked as staging
+func mergeStagingComponents(deployed []apis.ComponentMeta, env *types.EnvMeta, ioStreams cmdutil.IOStreams) []apis.ComponentMeta {
+	localApps, err := application.List(env.Name)
+	if err != nil {
+		ioStreams.Error("list application err", err)
+		return deployed
+	}
+	var all []apis.ComponentMeta
+	for _, app := range localApps {
+		comps, appConfig, _, err := app.OAM(env, ioStreams, true)
+		if err != nil {
+			ioStreams.Errorf("convert app %s err %v\n", app.Name, err)
+			continue
+		}
+		for _, c := range comps {
+			traits, err := app.GetTraitNames(c.Name)
+			if err != nil {
+				ioStreams.Errorf("get traits from app %s %s err %v\n", app.Name, c.Name, err)
+				continue
+			}
+			compMeta, exist := GetCompMeta(deployed, app.Name, c.Name)
+			if !exist {
+				all = append(all, apis.ComponentMeta{
+					Name:         c.Name,
+					App:          app.Name,
+					WorkloadName: c.Labels[oam.WorkloadTypeLabel],
+					TraitNames:   traits,
+					Status:       types.StatusStaging,
+					CreatedTime:  app.CreateTime.String(),
+					Namespace:    env.Namespace,
+				})
+				continue
+			}
+			compMeta.TraitNames = traits
+			compMeta.WorkloadName = app.AppFile.Services[c.Name].GetType()
+			cspec := c.Spec.DeepCopy()
+			cspec.Workload.Raw, _ = cspec.Workload.MarshalJSON()
+			cspec.Workload.Object = nil
+			aspec := appConfig.Spec.DeepCopy()
+			for i, v := range aspec.Components {
+				for j, t := range v.Traits {
+					t.Trait.Raw, _ = t.Trait.MarshalJSON()
+					t.Trait.Object = nil
+					v.Traits[j] = t
+				}
+				aspec.Components[i] = v
+				if compMeta.AppConfig.Spec.Components[i].Traits == nil && len(v.Traits) == 0 {
+					compMeta.AppConfig.Spec.Components[i].Traits = make([]corev1alpha2.ComponentTrait, 0)
+				}
+			}
+
+			if !gocmp.Equal(compMeta.Component.Spec, *cspec) || !gocmp.Equal(compMeta.AppConfig.Spec, *aspec) {
+				compMeta.Status = types.StatusStaging
+			}
+			all = append(all, compMeta)
+		}
+	}
+	return all
+}
+
+// GetCompMeta finds the component of the app from the deployed ones
+func GetCompMeta(deployed []apis.ComponentMeta, appName, compName string) (apis.ComponentMeta, bool) {
+	for _, v := range deployed {
+		if v.Name == compName && v.App == appName {
+			return v, true
+		}
+	}
+	return apis.ComponentMeta{}, false
+}
+
 func RetrieveApplicationStatusByName(ctx context.Context, c client.Client, applicationName string, namespace string) (apis.ApplicationMeta, error) {
 	var applicationMeta apis.ApplicationMeta
 	var appConfig corev1alpha2.ApplicationConfiguration
@@ -164,11 +305,31 @@ func RetrieveApplicationStatusByName(ctx context.Context, c client.Client, appli
 	return applicationMeta, nil
 }
 
-func (o *DeleteOptions) DeleteApp() (string, error) {
+// SetApplicationPaused sets or removes the pause annotation of the application,
+// KubeVela controllers will skip reconciling traits of a paused application
+func SetApplicationPaused(ctx context.Context, c client.Client, appName, namespace string, paused bool) error {
+	var appConfig corev1alpha2.ApplicationConfiguration
+	if err := c.Get(ctx, client.ObjectKey{Name: appName, Namespace: namespace}, &appConfig); err != nil {
+		return err
+	}
+	patch := client.MergeFrom(appConfig.DeepCopy())
+	annotations := appConfig.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	if paused {
+		annotations[types.AnnPause] = "true"
+	} else {
+		delete(annotations, types.AnnPause)
+	}
+	appConfig.SetAnnotations(annotations)
+	return c.Patch(ctx, &appConfig, patch)
+}
+
+func (o *DeleteOptions) DeleteApp(ctx context.Context) (string, error) {
 	if err := application.Delete(o.Env.Name, o.AppName); err != nil && !os.IsNotExist(err) {
 		return "", err
 	}
-	ctx := context.Background()
 	var appConfig corev1alpha2.ApplicationConfiguration
 	err := o.Client.Get(ctx, client.ObjectKey{Name: o.AppName, Namespace: o.Env.Namespace}, &appConfig)
 	if err != nil {
@@ -191,6 +352,15 @@ func (o *DeleteOptions) DeleteApp() (string, error) {
 	if err != nil && !apierrors.IsNotFound(err) {
 		return "", fmt.Errorf("delete application err %s", err)
 	}
+	// the appConfig is deleted first, so that the traits are not rendered again by it after cleaned up
+	var cleaned []string
+	for _, comp := range appConfig.Spec.Components {
+		traits, err := CleanupComponentTraits(ctx, o.Client, &appConfig, comp.ComponentName)
+		cleaned = append(cleaned, traits...)
+		if err != nil {
+			return "", err
+		}
+	}
 	var healthScope corev1alpha2.HealthScope
 	healthScope.Name = appfile.FormatDefaultHealthScopeName(o.AppName)
 	healthScope.Namespace = o.Env.Namespace
@@ -199,10 +369,10 @@ func (o *DeleteOptions) DeleteApp() (string, error) {
 		return "", fmt.Errorf("delete health scope %s err %v", healthScope.Name, err)
 	}
 
-	return fmt.Sprintf("delete apps succeed %s from %s", o.AppName, o.Env.Name), nil
+	return fmt.Sprintf("delete apps succeed %s from %s", o.AppName, o.Env.Name) + cleanedTraitsMessage(cleaned), nil
 }
 
-func (o *DeleteOptions) DeleteComponent(io cmdutil.IOStreams) (string, error) {
+func (o *DeleteOptions) DeleteComponent(ctx context.Context, io cmdutil.IOStreams) (string, error) {
 	var app *application.Application
 	var err error
 	if o.AppName != "" {
@@ -215,7 +385,14 @@ func (o *DeleteOptions) DeleteComponent(io cmdutil.IOStreams) (string, error) {
 	}
 
 	if len(app.GetComponents()) <= 1 {
-		return o.DeleteApp()
+		return o.DeleteApp(ctx)
+	}
+
+	// The traits of the component are recorded in the status of the appConfig before it's updated
+	var appConfig corev1alpha2.ApplicationConfiguration
+	if err := o.Client.Get(ctx, client.ObjectKey{Name: app.Name, Namespace: o.Env.Namespace}, &appConfig); err != nil &&
+		!apierrors.IsNotFound(err) {
+		return "", fmt.Errorf("get appconfig err %s", err)
 	}
 
 	// Remove component from local appfile
@@ -227,21 +404,66 @@ func (o *DeleteOptions) DeleteComponent(io cmdutil.IOStreams) (string, error) {
 	}
 
 	// Remove component from appConfig in k8s cluster
-	ctx := context.Background()
 	if err := app.BuildRun(ctx, o.Client, o.Env, io); err != nil {
 		return "", err
 	}
 
+	// Remove traits of the component along with the resources they created
+	cleaned, err := CleanupComponentTraits(ctx, o.Client, &appConfig, o.CompName)
+	if err != nil {
+		return "", err
+	}
+
 	// Remove component in k8s cluster
 	var c corev1alpha2.Component
 	c.Name = o.CompName
 	c.Namespace = o.Env.Namespace
-	err = o.Client.Delete(context.Background(), &c)
+	err = o.Client.Delete(ctx, &c)
 	if err != nil && !apierrors.IsNotFound(err) {
 		return "", fmt.Errorf("delete component err: %s", err)
 	}
 
-	return fmt.Sprintf("delete component succeed %s from %s", o.CompName, o.AppName), nil
+	return fmt.Sprintf("delete component succeed %s from %s", o.CompName, o.AppName) + cleanedTraitsMessage(cleaned), nil
+}
+
+// CleanupComponentTraits deletes the traits of the component recorded in the status of the appConfig, instead of
+// waiting for the appConfig controller to garbage collect them. The resources created by the traits are deleted in
+// the foreground by their owner references, and traits with finalizers like the autoscaler clean up the rest of
+// theirs, such as the KEDA ScaledObject. It returns the traits cleaned up, like `autoscale/web-autoscaler`.
+func CleanupComponentTraits(ctx context.Context, c client.Client, appConfig *corev1alpha2.ApplicationConfiguration,
+	compName string) ([]string, error) {
+	var cleaned []string
+	for _, wl := range appConfig.Status.Workloads {
+		if wl.ComponentName != compName {
+			continue
+		}
+		for _, tr := range wl.Traits {
+			trait, err := GetUnstructured(ctx, c, appConfig.Namespace, tr.Reference)
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return cleaned, fmt.Errorf("get trait %s of %s err %v", tr.Reference.Name, compName, err)
+			}
+			err = c.Delete(ctx, trait, client.PropagationPolicy(metav1.DeletePropagationForeground))
+			if err != nil && !apierrors.IsNotFound(err) {
+				return cleaned, fmt.Errorf("delete trait %s of %s err %v", trait.GetName(), compName, err)
+			}
+			traitType := trait.GetLabels()[oam.TraitTypeLabel]
+			if traitType == "" {
+				traitType = trait.GetKind()
+			}
+			cleaned = append(cleaned, traitType+"/"+trait.GetName())
+		}
+	}
+	return cleaned, nil
+}
+
+func cleanedTraitsMessage(cleaned []string) string {
+	if len(cleaned) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\ncleaned up traits: %s", strings.Join(cleaned, ", "))
 }
 
 func chooseSvc(services []string) (string, error) {
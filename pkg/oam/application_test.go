@@ -0,0 +1,71 @@
+package oam
+
+import (
+	"context"
+	"testing"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core"
+	corev1alpha2 "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
+	"gotest.tools/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/api/v1alpha1"
+)
+
+func TestCleanupComponentTraits(t *testing.T) {
+	ctx := context.Background()
+	s := runtime.NewScheme()
+	assert.NilError(t, core.AddToScheme(s))
+	assert.NilError(t, v1alpha1.AddToScheme(s))
+
+	autoscaler := &v1alpha1.Autoscaler{ObjectMeta: metav1.ObjectMeta{Name: "web-autoscale", Namespace: "default",
+		Labels: map[string]string{oam.TraitTypeLabel: "autoscale"}}}
+	manualScaler := &corev1alpha2.ManualScalerTrait{ObjectMeta: metav1.ObjectMeta{Name: "web-scaler",
+		Namespace: "default"}}
+	workerScaler := &corev1alpha2.ManualScalerTrait{ObjectMeta: metav1.ObjectMeta{Name: "worker-scaler",
+		Namespace: "default"}}
+	c := fake.NewFakeClientWithScheme(s, autoscaler, manualScaler, workerScaler)
+
+	traitRef := func(apiVersion, kind, name string) corev1alpha2.WorkloadTrait {
+		return corev1alpha2.WorkloadTrait{Reference: runtimev1alpha1.TypedReference{APIVersion: apiVersion,
+			Kind: kind, Name: name}}
+	}
+	appConfig := &corev1alpha2.ApplicationConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "myapp", Namespace: "default"},
+		Status: corev1alpha2.ApplicationConfigurationStatus{Workloads: []corev1alpha2.WorkloadStatus{
+			{
+				ComponentName: "web",
+				Traits: []corev1alpha2.WorkloadTrait{
+					traitRef("standard.oam.dev/v1alpha1", "Autoscaler", "web-autoscale"),
+					traitRef("core.oam.dev/v1alpha2", "ManualScalerTrait", "web-scaler"),
+					// already garbage collected
+					traitRef("core.oam.dev/v1alpha2", "ManualScalerTrait", "web-gone"),
+				},
+			},
+			{
+				ComponentName: "worker",
+				Traits: []corev1alpha2.WorkloadTrait{
+					traitRef("core.oam.dev/v1alpha2", "ManualScalerTrait", "worker-scaler"),
+				},
+			},
+		}},
+	}
+
+	cleaned, err := CleanupComponentTraits(ctx, c, appConfig, "web")
+	assert.NilError(t, err)
+	// the trait type is shown if the trait is labeled with it, otherwise the kind
+	assert.DeepEqual(t, cleaned, []string{"autoscale/web-autoscale", "ManualScalerTrait/web-scaler"})
+	err = c.Get(ctx, client.ObjectKey{Name: "web-autoscale", Namespace: "default"}, &v1alpha1.Autoscaler{})
+	assert.Assert(t, apierrors.IsNotFound(err))
+	err = c.Get(ctx, client.ObjectKey{Name: "web-scaler", Namespace: "default"}, &corev1alpha2.ManualScalerTrait{})
+	assert.Assert(t, apierrors.IsNotFound(err))
+	// traits of other components are kept
+	assert.NilError(t, c.Get(ctx, client.ObjectKey{Name: "worker-scaler", Namespace: "default"},
+		&corev1alpha2.ManualScalerTrait{}))
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
 	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam/discoverymapper"
 	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam/util"
 	"github.com/ghodss/yaml"
@@ -61,7 +62,7 @@ func SyncCapabilityFromCenter(capName, capURL, capToken string) error {
 	return client.SyncCapabilityFromCenter()
 }
 
-func AddCapabilityIntoCluster(c client.Client, mapper discoverymapper.DiscoveryMapper, capability string) (string, error) {
+func AddCapabilityIntoCluster(ctx context.Context, c client.Client, mapper discoverymapper.DiscoveryMapper, capability string) (string, error) {
 	ss := strings.Split(capability, "/")
 	if len(ss) < 2 {
 		return "", errors.New("invalid format for " + capability + ", please follow format <center>/<name>")
@@ -69,13 +70,13 @@ func AddCapabilityIntoCluster(c client.Client, mapper discoverymapper.DiscoveryM
 	repoName := ss[0]
 	name := ss[1]
 	ioStreams := cmdutil.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr}
-	if err := InstallCapability(c, mapper, repoName, name, ioStreams); err != nil {
+	if err := InstallCapability(ctx, c, mapper, repoName, name, ioStreams); err != nil {
 		return "", err
 	}
 	return fmt.Sprintf("Successfully installed capability %s from %s", name, repoName), nil
 }
 
-func InstallCapability(client client.Client, mapper discoverymapper.DiscoveryMapper, centerName, capabilityName string, ioStreams cmdutil.IOStreams) error {
+func InstallCapability(ctx context.Context, client client.Client, mapper discoverymapper.DiscoveryMapper, centerName, capabilityName string, ioStreams cmdutil.IOStreams) error {
 	dir, _ := system.GetCapCenterDir()
 	repoDir := filepath.Join(dir, centerName)
 	tp, err := GetSyncedCapabilities(centerName, capabilityName)
@@ -110,7 +111,7 @@ func InstallCapability(client client.Client, mapper discoverymapper.DiscoveryMap
 			APIVersion: gvk.GroupVersion().String(),
 			Kind:       gvk.Kind,
 		}
-		if err = client.Create(context.Background(), &wd); err != nil && !apierrors.IsAlreadyExists(err) {
+		if err = createOrUpdateDefinition(ctx, client, &wd, &v1alpha2.WorkloadDefinition{}); err != nil {
 			return err
 		}
 	case types.TypeTrait:
@@ -138,7 +139,7 @@ func InstallCapability(client client.Client, mapper discoverymapper.DiscoveryMap
 			APIVersion: gvk.GroupVersion().String(),
 			Kind:       gvk.Kind,
 		}
-		if err = client.Create(context.Background(), &td); err != nil && !apierrors.IsAlreadyExists(err) {
+		if err = createOrUpdateDefinition(ctx, client, &td, &v1alpha2.TraitDefinition{}); err != nil {
 			return err
 		}
 	case types.TypeScope:
@@ -152,6 +153,157 @@ func InstallCapability(client client.Client, mapper discoverymapper.DiscoveryMap
 	return nil
 }
 
+// createOrUpdateDefinition creates the definition, or updates it with the latest spec if it already exists
+func createOrUpdateDefinition(ctx context.Context, c client.Client, def, existing oam.Object) error {
+	err := c.Create(ctx, def)
+	if err == nil || !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	if err = c.Get(ctx, client.ObjectKey{Namespace: def.GetNamespace(), Name: def.GetName()}, existing); err != nil {
+		return err
+	}
+	def.SetResourceVersion(existing.GetResourceVersion())
+	return c.Update(ctx, def)
+}
+
+// CapabilityUpdateReport tells how capabilities in the cluster change by syncing from a capability center
+type CapabilityUpdateReport struct {
+	Added     []types.Capability
+	Updated   []types.Capability
+	Unchanged []types.Capability
+	Removed   []types.Capability
+}
+
+// CapabilityFilter selects the capabilities to sync from a capability center, all of them are selected if it's empty
+type CapabilityFilter struct {
+	Names []string
+	Type  types.CapType
+}
+
+func (f CapabilityFilter) match(cap types.Capability) bool {
+	if f.Type != "" && cap.Type != f.Type {
+		return false
+	}
+	if len(f.Names) == 0 {
+		return true
+	}
+	for _, name := range f.Names {
+		if cap.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// apply keeps the capabilities of the center and the installed ones matching the filter,
+// it fails if any name is found in neither the center nor the capabilities installed from it
+func (f CapabilityFilter) apply(caps, installed []types.Capability, centerName string) ([]types.Capability, []types.Capability, error) {
+	var matchedCaps, matchedInstalled []types.Capability
+	found := make(map[string]bool)
+	for _, cap := range caps {
+		if f.match(cap) {
+			matchedCaps = append(matchedCaps, cap)
+			found[cap.Name] = true
+		}
+	}
+	for _, cap := range installed {
+		if f.match(cap) {
+			matchedInstalled = append(matchedInstalled, cap)
+			if cap.Source != nil && cap.Source.RepoName == centerName {
+				found[cap.Name] = true
+			}
+		}
+	}
+	for _, name := range f.Names {
+		if !found[name] {
+			if f.Type != "" {
+				return nil, nil, fmt.Errorf("%s definition %s not found in %s", f.Type, name, centerName)
+			}
+			return nil, nil, fmt.Errorf("definition %s not found in %s", name, centerName)
+		}
+	}
+	return matchedCaps, matchedInstalled, nil
+}
+
+// UpdateCapabilitiesFromCenter syncs capabilities from the center and applies all of them into the cluster,
+// capabilities installed from the center before but no longer existing in it are removed from the cluster.
+// Nothing is applied or removed if dryRun is true, only the report is computed.
+func UpdateCapabilitiesFromCenter(ctx context.Context, c client.Client, mapper discoverymapper.DiscoveryMapper, centerName string,
+	filter CapabilityFilter, dryRun bool, ioStreams cmdutil.IOStreams) (CapabilityUpdateReport, error) {
+	installed, err := plugins.LoadAllInstalledCapability()
+	if err != nil {
+		return CapabilityUpdateReport{}, err
+	}
+	dir, _ := system.GetCapCenterDir()
+	caps, err := plugins.LoadCapabilityFromSyncedCenter(filepath.Join(dir, centerName))
+	if err != nil {
+		return CapabilityUpdateReport{}, err
+	}
+	if caps, installed, err = filter.apply(caps, installed, centerName); err != nil {
+		return CapabilityUpdateReport{}, err
+	}
+	report := DiffCapabilities(caps, installed, centerName)
+	if dryRun {
+		return report, nil
+	}
+	for _, cap := range caps {
+		if err = InstallCapability(ctx, c, mapper, centerName, cap.Name, ioStreams); err != nil {
+			return report, err
+		}
+	}
+	for _, old := range report.Removed {
+		if err = UninstallCap(ctx, c, old, ioStreams); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// DiffCapabilities compares capabilities of the center with the installed ones, installed capabilities are only
+// regarded removed if they were installed from the same center
+func DiffCapabilities(caps, installed []types.Capability, centerName string) CapabilityUpdateReport {
+	var report CapabilityUpdateReport
+	for _, cap := range caps {
+		var old *types.Capability
+		for i := range installed {
+			if installed[i].Name == cap.Name {
+				old = &installed[i]
+				break
+			}
+		}
+		switch {
+		case old == nil:
+			report.Added = append(report.Added, cap)
+		case !sameCapability(*old, cap):
+			report.Updated = append(report.Updated, cap)
+		default:
+			report.Unchanged = append(report.Unchanged, cap)
+		}
+	}
+	for _, old := range installed {
+		if old.Source == nil || old.Source.RepoName != centerName {
+			continue
+		}
+		exist := false
+		for _, cap := range caps {
+			if cap.Name == old.Name {
+				exist = true
+				break
+			}
+		}
+		if !exist {
+			report.Removed = append(report.Removed, old)
+		}
+	}
+	return report
+}
+
+// sameCapability compares what's defined in the center, fields filled when installing the capability are ignored
+func sameCapability(installed, cap types.Capability) bool {
+	cap.Source, cap.DefinitionPath, cap.CrdInfo = installed.Source, installed.DefinitionPath, installed.CrdInfo
+	return types.EqualCapability(installed, cap)
+}
+
 func GetSyncedCapabilities(repoName, addonName string) (types.Capability, error) {
 	dir, _ := system.GetCapCenterDir()
 	repoDir := filepath.Join(dir, repoName)
@@ -217,16 +369,16 @@ func SyncCapabilityCenter(capabilityCenterName string) error {
 	return nil
 }
 
-func RemoveCapabilityFromCluster(client client.Client, capabilityName string) (string, error) {
+func RemoveCapabilityFromCluster(ctx context.Context, client client.Client, capabilityName string) (string, error) {
 	ioStreams := cmdutil.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr}
-	if err := RemoveCapability(client, capabilityName, ioStreams); err != nil {
+	if err := RemoveCapability(ctx, client, capabilityName, ioStreams); err != nil {
 		return "", err
 	}
 	msg := fmt.Sprintf("%s removed successfully", capabilityName)
 	return msg, nil
 }
 
-func RemoveCapability(client client.Client, capabilityName string, ioStreams cmdutil.IOStreams) error {
+func RemoveCapability(ctx context.Context, client client.Client, capabilityName string, ioStreams cmdutil.IOStreams) error {
 	// TODO(wonderflow): make sure no apps is using this capability
 	caps, err := plugins.LoadAllInstalledCapability()
 	if err != nil {
@@ -234,15 +386,14 @@ func RemoveCapability(client client.Client, capabilityName string, ioStreams cmd
 	}
 	for _, w := range caps {
 		if w.Name == capabilityName {
-			return UninstallCap(client, w, ioStreams)
+			return UninstallCap(ctx, client, w, ioStreams)
 		}
 	}
 	return errors.New(capabilityName + " not exist")
 }
 
-func UninstallCap(client client.Client, cap types.Capability, ioStreams cmdutil.IOStreams) error {
+func UninstallCap(ctx context.Context, client client.Client, cap types.Capability, ioStreams cmdutil.IOStreams) error {
 	// 1. Remove WorkloadDefinition or TraitDefinition
-	ctx := context.Background()
 	var obj runtime.Object
 	switch cap.Type {
 	case types.TypeTrait:
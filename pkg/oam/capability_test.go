@@ -0,0 +1,88 @@
+package oam
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	"github.com/oam-dev/kubevela/api/types"
+)
+
+func TestDiffCapabilities(t *testing.T) {
+	fromCenter := &types.Source{RepoName: "center"}
+	installed := []types.Capability{
+		{Name: "webservice", Type: types.TypeWorkload, Description: "old", Source: fromCenter},
+		{Name: "route", Type: types.TypeTrait, Description: "route", Source: fromCenter, DefinitionPath: "/tmp/route.yaml"},
+		{Name: "metrics", Type: types.TypeTrait, Source: fromCenter},
+		{Name: "worker", Type: types.TypeWorkload},
+		{Name: "scaler", Type: types.TypeTrait, Source: &types.Source{RepoName: "other"}},
+	}
+	caps := []types.Capability{
+		{Name: "webservice", Type: types.TypeWorkload, Description: "new"},
+		{Name: "route", Type: types.TypeTrait, Description: "route"},
+		{Name: "autoscale", Type: types.TypeTrait},
+	}
+	report := DiffCapabilities(caps, installed, "center")
+	assert.DeepEqual(t, []types.Capability{caps[2]}, report.Added)
+	assert.DeepEqual(t, []types.Capability{caps[0]}, report.Updated)
+	assert.DeepEqual(t, []types.Capability{caps[1]}, report.Unchanged)
+	// capabilities not installed from the center are never removed
+	assert.DeepEqual(t, []types.Capability{installed[2]}, report.Removed)
+}
+
+func TestCapabilityFilter(t *testing.T) {
+	fromCenter := &types.Source{RepoName: "center"}
+	caps := []types.Capability{
+		{Name: "webservice", Type: types.TypeWorkload},
+		{Name: "route", Type: types.TypeTrait},
+	}
+	installed := []types.Capability{
+		{Name: "route", Type: types.TypeTrait, Source: fromCenter},
+		{Name: "metrics", Type: types.TypeTrait, Source: fromCenter},
+		{Name: "worker", Type: types.TypeWorkload},
+	}
+
+	cases := map[string]struct {
+		filter       CapabilityFilter
+		expCaps      []types.Capability
+		expInstalled []types.Capability
+		expErr       string
+	}{
+		"empty filter selects all": {
+			expCaps:      caps,
+			expInstalled: installed,
+		},
+		"filter by type": {
+			filter:       CapabilityFilter{Type: types.TypeTrait},
+			expCaps:      caps[1:],
+			expInstalled: installed[:2],
+		},
+		"filter by name": {
+			filter:       CapabilityFilter{Names: []string{"webservice", "route"}},
+			expCaps:      caps,
+			expInstalled: installed[:1],
+		},
+		"removed definition selected by name": {
+			filter:       CapabilityFilter{Names: []string{"metrics"}},
+			expInstalled: installed[1:2],
+		},
+		"name not found of the type": {
+			filter: CapabilityFilter{Names: []string{"route"}, Type: types.TypeWorkload},
+			expErr: "workload definition route not found in center",
+		},
+		"name not installed from the center": {
+			filter: CapabilityFilter{Names: []string{"worker"}},
+			expErr: "definition worker not found in center",
+		},
+	}
+	for name, c := range cases {
+		gotCaps, gotInstalled, err := c.filter.apply(caps, installed, "center")
+		if c.expErr != "" {
+			assert.Error(t, err, c.expErr, name)
+			continue
+		}
+		assert.NilError(t, err, name)
+		assert.DeepEqual(t, c.expCaps, gotCaps)
+		assert.DeepEqual(t, c.expInstalled, gotInstalled)
+	}
+}
@@ -0,0 +1,155 @@
+package oam
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	corev1alpha2 "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/pkg/server/apis"
+)
+
+const (
+	// ServiceHealthy means the workload and traits of a service are all ready
+	ServiceHealthy = "Healthy"
+	// ServiceUnhealthy means the workload or any trait of a service is not ready
+	ServiceUnhealthy = "Unhealthy"
+)
+
+// GetAppStatus gets the health of all services of the application, it's healthy only if all services are healthy
+func GetAppStatus(ctx context.Context, c client.Client, appName, namespace string) (apis.AppStatus, error) {
+	status := apis.AppStatus{Name: appName}
+	var appConfig corev1alpha2.ApplicationConfiguration
+	if err := c.Get(ctx, client.ObjectKey{Name: appName, Namespace: namespace}, &appConfig); err != nil {
+		return status, err
+	}
+	var services []string
+	for _, comp := range appConfig.Spec.Components {
+		services = append(services, comp.ComponentName)
+	}
+	health, err := GetServicesHealth(ctx, c, &appConfig, services)
+	if err != nil {
+		return status, err
+	}
+	status.Services = health
+	status.Health = ServiceHealthy
+	if !AllServicesHealthy(health) {
+		status.Health = ServiceUnhealthy
+	}
+	return status, nil
+}
+
+// GetServicesHealth aggregates the readiness of the workload and the conditions of the workload and traits
+// into the health of each service, the reasons tell why a service is unhealthy
+func GetServicesHealth(ctx context.Context, c client.Client, appConfig *corev1alpha2.ApplicationConfiguration, services []string) ([]apis.ServiceHealth, error) {
+	var health []apis.ServiceHealth
+	for _, svcName := range services {
+		wlStatus, ok := getWorkloadStatus(appConfig, svcName)
+		if !ok {
+			health = append(health, apis.ServiceHealth{Service: svcName, Health: ServiceUnhealthy, Reason: "workload not created yet"})
+			continue
+		}
+		h := apis.ServiceHealth{Service: svcName, Health: ServiceHealthy}
+		workload, err := getResourceHealth(ctx, c, appConfig.Namespace, wlStatus.Reference)
+		if err != nil {
+			return nil, err
+		}
+		h.Workload = &workload
+		resources := []apis.ResourceHealth{workload}
+		for _, tr := range wlStatus.Traits {
+			trait, err := getResourceHealth(ctx, c, appConfig.Namespace, tr.Reference)
+			if err != nil {
+				return nil, err
+			}
+			h.Traits = append(h.Traits, trait)
+			resources = append(resources, trait)
+		}
+		var reasons []string
+		for _, r := range resources {
+			for _, reason := range r.Reasons {
+				reasons = append(reasons, fmt.Sprintf("%s %s: %s", r.Kind, r.Name, reason))
+			}
+		}
+		if len(reasons) > 0 {
+			h.Health = ServiceUnhealthy
+			h.Reason = strings.Join(reasons, "; ")
+		}
+		health = append(health, h)
+	}
+	return health, nil
+}
+
+// AllServicesHealthy tells whether all the services are healthy
+func AllServicesHealthy(health []apis.ServiceHealth) bool {
+	for _, h := range health {
+		if h.Health != ServiceHealthy {
+			return false
+		}
+	}
+	return true
+}
+
+func getResourceHealth(ctx context.Context, c client.Client, namespace string, ref runtimev1alpha1.TypedReference) (apis.ResourceHealth, error) {
+	rh := apis.ResourceHealth{Kind: ref.Kind, Name: ref.Name}
+	u, err := GetUnstructured(ctx, c, namespace, ref)
+	if err != nil {
+		return rh, err
+	}
+	if replicas, found, err := unstructured.NestedInt64(u.Object, "spec", "replicas"); err == nil && found {
+		ready, _, _ := unstructured.NestedInt64(u.Object, "status", "readyReplicas")
+		rh.Replicas, rh.ReadyReplicas = &replicas, &ready
+	}
+	if rh.Conditions, err = GetConditionsFromObject(u); err != nil {
+		return rh, err
+	}
+	if rh.Reasons, err = unhealthyReasons(u); err != nil {
+		return rh, err
+	}
+	rh.Ready = len(rh.Reasons) == 0
+	return rh, nil
+}
+
+// unhealthyReasons tells why a workload or trait isn't ready, by its replicas and the conditions not true,
+// it's healthy if nothing returned
+func unhealthyReasons(u *unstructured.Unstructured) ([]string, error) {
+	var reasons []string
+	replicas, found, err := unstructured.NestedInt64(u.Object, "spec", "replicas")
+	if err == nil && found {
+		ready, _, _ := unstructured.NestedInt64(u.Object, "status", "readyReplicas")
+		if ready < replicas {
+			reasons = append(reasons, fmt.Sprintf("%d/%d replicas ready", ready, replicas))
+		}
+	}
+	conds, err := GetConditionsFromObject(u)
+	if err != nil {
+		return nil, err
+	}
+	for _, cond := range conds {
+		if cond.Status != corev1.ConditionFalse {
+			continue
+		}
+		reason := fmt.Sprintf("%s is false", cond.Type)
+		if cond.Reason != "" {
+			reason += fmt.Sprintf(" (%s)", cond.Reason)
+		}
+		if cond.Message != "" {
+			reason += ", " + cond.Message
+		}
+		reasons = append(reasons, reason)
+	}
+	return reasons, nil
+}
+
+func getWorkloadStatus(appConfig *corev1alpha2.ApplicationConfiguration, compName string) (corev1alpha2.WorkloadStatus, bool) {
+	for _, v := range appConfig.Status.Workloads {
+		if v.ComponentName == compName {
+			return v, true
+		}
+	}
+	return corev1alpha2.WorkloadStatus{}, false
+}
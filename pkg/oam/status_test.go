@@ -0,0 +1,56 @@
+package oam
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/oam-dev/kubevela/pkg/server/apis"
+)
+
+func TestUnhealthyReasons(t *testing.T) {
+	healthy := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"replicas": int64(2)},
+		"status": map[string]interface{}{
+			"readyReplicas": int64(2),
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Available", "status": "True"},
+			},
+		},
+	}}
+	reasons, err := unhealthyReasons(healthy)
+	assert.NilError(t, err)
+	assert.Equal(t, len(reasons), 0)
+
+	unhealthy := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"replicas": int64(2)},
+		"status": map[string]interface{}{
+			"readyReplicas": int64(1),
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Available", "status": "False", "reason": "MinimumReplicasUnavailable",
+					"message": "Deployment does not have minimum availability."},
+			},
+		},
+	}}
+	reasons, err = unhealthyReasons(unhealthy)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{
+		"1/2 replicas ready",
+		"Available is false (MinimumReplicasUnavailable), Deployment does not have minimum availability.",
+	}, reasons)
+
+	// traits without replicas or conditions are regarded healthy
+	reasons, err = unhealthyReasons(&unstructured.Unstructured{Object: map[string]interface{}{}})
+	assert.NilError(t, err)
+	assert.Equal(t, len(reasons), 0)
+}
+
+func TestAllServicesHealthy(t *testing.T) {
+	assert.Assert(t, AllServicesHealthy(nil))
+	assert.Assert(t, AllServicesHealthy([]apis.ServiceHealth{{Service: "frontend", Health: ServiceHealthy}}))
+	assert.Assert(t, !AllServicesHealthy([]apis.ServiceHealth{
+		{Service: "frontend", Health: ServiceHealthy},
+		{Service: "backend", Health: ServiceUnhealthy, Reason: "workload not created yet"},
+	}))
+}
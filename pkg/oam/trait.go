@@ -150,6 +150,53 @@ func ValidateAndMutateForCore(traitType, workloadName string, flags *pflag.FlagS
 	return nil
 }
 
+// flags of the cron scaling policy of autoscale trait, which is a struct parameter and can't be generated from the template
+const (
+	AutoscaleCronStartAt  = "cron-start-at"
+	AutoscaleCronDuration = "cron-duration"
+	AutoscaleCronDays     = "cron-days"
+	AutoscaleCronReplicas = "cron-replicas"
+	AutoscaleCronTimezone = "cron-timezone"
+)
+
+// AddAutoscaleCronFlags adds flags of the cron scaling policy to the command of autoscale trait
+func AddAutoscaleCronFlags(flags *pflag.FlagSet) {
+	flags.String(AutoscaleCronStartAt, "", "the time to start cron scaling, like 08:00")
+	flags.String(AutoscaleCronDuration, "", "for how long the cron scaling will last, like 2h")
+	flags.String(AutoscaleCronDays, "", "several workdays or weekends of cron scaling, like \"Monday, Tuesday\"")
+	flags.Int64(AutoscaleCronReplicas, 0, "the target replicas to be scaled to by cron scaling")
+	flags.String(AutoscaleCronTimezone, "", "IANA time zone of cron scaling, like \"America/Los_Angeles\"")
+}
+
+// SetStructParamsForCore sets struct parameters of vela-core traits from flags, which are skipped by the template
+func SetStructParamsForCore(traitType string, flags *pflag.FlagSet, traitData map[string]interface{}) error {
+	switch traitType {
+	case "autoscale":
+		startAt, _ := flags.GetString(AutoscaleCronStartAt)
+		if startAt == "" {
+			return nil
+		}
+		duration, _ := flags.GetString(AutoscaleCronDuration)
+		days, _ := flags.GetString(AutoscaleCronDays)
+		replicas, _ := flags.GetInt64(AutoscaleCronReplicas)
+		if duration == "" || days == "" || replicas <= 0 {
+			return fmt.Errorf("--%s, --%s and a positive --%s are required with --%s", AutoscaleCronDuration,
+				AutoscaleCronDays, AutoscaleCronReplicas, AutoscaleCronStartAt)
+		}
+		cron := map[string]interface{}{
+			"startAt":  startAt,
+			"duration": duration,
+			"days":     days,
+			"replicas": replicas,
+		}
+		if timezone, _ := flags.GetString(AutoscaleCronTimezone); timezone != "" {
+			cron["timezone"] = timezone
+		}
+		traitData["cron"] = cron
+	}
+	return nil
+}
+
 //AddOrUpdateTrait attach trait to workload
 func AddOrUpdateTrait(env *types.EnvMeta, appName string, componentName string, flagSet *pflag.FlagSet, template types.Capability) (*application.Application, error) {
 	err := ValidateAndMutateForCore(template.Name, componentName, flagSet, env)
@@ -188,6 +235,9 @@ func AddOrUpdateTrait(env *types.EnvMeta, appName string, componentName string,
 			return nil, fmt.Errorf("get flag(s) \"%s\" err %v", name, err)
 		}
 	}
+	if err = SetStructParamsForCore(template.Name, flagSet, traitData); err != nil {
+		return app, err
+	}
 	if err = app.SetTrait(componentName, traitAlias, traitData); err != nil {
 		return app, err
 	}
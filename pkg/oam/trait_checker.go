@@ -151,6 +151,10 @@ func (d *AutoscalerChecker) Check(ctx context.Context, ref runtimev1alpha1.Typed
 	if len(triggers) >= 1 {
 		scalerType = string(triggers[0].Type)
 	}
+	// the autoscaler is rejected by the controller, e.g. the spec is invalid
+	if cond := scaler.GetCondition(runtimev1alpha1.TypeSynced); cond.Status == v1.ConditionFalse {
+		return StatusDone, fmt.Sprintf("type: %-8serror: %s", scalerType, cond.Message), nil
+	}
 
 	hpaName := "keda-hpa-" + traitName
 	var hpa v12.HorizontalPodAutoscaler
@@ -196,3 +200,20 @@ func GetStatusFromObject(resource *unstructured.Unstructured) (string, error) {
 	}
 	return fmt.Sprintf("%s status: %s", resource.GetName(), message), nil
 }
+
+// GetConditionsFromObject gets the raw conditions from status of the resource
+func GetConditionsFromObject(resource *unstructured.Unstructured) ([]runtimev1alpha1.Condition, error) {
+	var conditions []runtimev1alpha1.Condition
+	conditionsData, found, err := unstructured.NestedSlice(resource.Object, "status", "conditions")
+	if err != nil || !found {
+		return conditions, err
+	}
+	data, err := json.Marshal(conditionsData)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &conditions); err != nil {
+		return nil, err
+	}
+	return conditions, nil
+}
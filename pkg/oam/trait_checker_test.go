@@ -0,0 +1,40 @@
+package oam
+
+import (
+	"testing"
+
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGetConditionsFromObject(t *testing.T) {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True", "reason": "Available",
+					"message": "ready to serve", "lastTransitionTime": "2020-10-01T00:00:00Z"},
+			},
+		},
+	}}
+	conditions, err := GetConditionsFromObject(u)
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(conditions))
+	assert.Equal(t, runtimev1alpha1.ConditionType("Ready"), conditions[0].Type)
+	assert.Equal(t, corev1.ConditionTrue, conditions[0].Status)
+	assert.Equal(t, runtimev1alpha1.ConditionReason("Available"), conditions[0].Reason)
+	assert.Equal(t, "ready to serve", conditions[0].Message)
+	assert.Equal(t, "2020-10-01T00:00:00Z", conditions[0].LastTransitionTime.UTC().Format("2006-01-02T15:04:05Z"))
+
+	// resources without conditions have none
+	conditions, err = GetConditionsFromObject(&unstructured.Unstructured{Object: map[string]interface{}{}})
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(conditions))
+
+	// malformed conditions fail
+	_, err = GetConditionsFromObject(&unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{"conditions": "ready"},
+	}})
+	assert.Assert(t, err != nil)
+}
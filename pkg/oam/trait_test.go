@@ -3,6 +3,7 @@ package oam
 import (
 	"testing"
 
+	"github.com/spf13/pflag"
 	"gotest.tools/assert"
 )
 
@@ -10,3 +11,33 @@ func TestParse(t *testing.T) {
 	assert.Equal(t, "containerizedworkloads.core.oam.dev", Parse("core.oam.dev/v1alpha2.ContainerizedWorkload"))
 	assert.Equal(t, "containerizedworkloads.core.oam.dev", Parse("containerizedworkloads.core.oam.dev"))
 }
+
+func TestSetStructParamsForCore(t *testing.T) {
+	newFlags := func(args ...string) *pflag.FlagSet {
+		flags := pflag.NewFlagSet("autoscale", pflag.ContinueOnError)
+		AddAutoscaleCronFlags(flags)
+		assert.NilError(t, flags.Parse(args))
+		return flags
+	}
+
+	traitData := map[string]interface{}{"min": int64(1)}
+	assert.NilError(t, SetStructParamsForCore("autoscale", newFlags(), traitData))
+	assert.DeepEqual(t, map[string]interface{}{"min": int64(1)}, traitData)
+
+	assert.NilError(t, SetStructParamsForCore("autoscale", newFlags("--cron-start-at", "08:00", "--cron-duration", "2h",
+		"--cron-days", "Monday, Friday", "--cron-replicas", "3", "--cron-timezone", "Asia/Shanghai"), traitData))
+	assert.DeepEqual(t, map[string]interface{}{
+		"min": int64(1),
+		"cron": map[string]interface{}{
+			"startAt":  "08:00",
+			"duration": "2h",
+			"days":     "Monday, Friday",
+			"replicas": int64(3),
+			"timezone": "Asia/Shanghai",
+		},
+	}, traitData)
+
+	err := SetStructParamsForCore("autoscale", newFlags("--cron-start-at", "08:00", "--cron-days", "Monday"),
+		map[string]interface{}{})
+	assert.ErrorContains(t, err, "are required with --cron-start-at")
+}
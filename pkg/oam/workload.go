@@ -43,6 +43,15 @@ func LoadIfExist(envName string, workloadName string, appGroup string) (*applica
 }
 
 func BaseComplete(envName string, workloadName string, appName string, flagSet *pflag.FlagSet, workloadType string) (*application.Application, error) {
+	app, err := PrepareApp(envName, workloadName, appName, flagSet, workloadType)
+	if err != nil {
+		return app, err
+	}
+	return app, app.Save(envName)
+}
+
+// PrepareApp loads the application and sets the workload by flags without saving it locally
+func PrepareApp(envName string, workloadName string, appName string, flagSet *pflag.FlagSet, workloadType string) (*application.Application, error) {
 	app, err := LoadIfExist(envName, workloadName, appName)
 	if err != nil {
 		return nil, err
@@ -106,17 +115,14 @@ func BaseComplete(envName string, workloadName string, appName string, flagSet *
 			return nil, fmt.Errorf("get flag(s) \"%s\" err %v", v.Name, err)
 		}
 	}
-	if err = app.SetWorkload(workloadName, tp, workloadData); err != nil {
-		return app, err
-	}
-	return app, app.Save(envName)
+	return app, app.SetWorkload(workloadName, tp, workloadData)
 }
 
-func BaseRun(staging bool, app *application.Application, kubeClient client.Client, Env *types.EnvMeta, io cmdutil.IOStreams) (string, error) {
+func BaseRun(ctx context.Context, staging bool, app *application.Application, kubeClient client.Client, Env *types.EnvMeta, io cmdutil.IOStreams) (string, error) {
 	if staging {
 		return "Staging saved", nil
 	}
-	if err := app.BuildRun(context.Background(), kubeClient, Env, io); err != nil {
+	if err := app.BuildRun(ctx, kubeClient, Env, io); err != nil {
 		err = fmt.Errorf("create app err: %s", err)
 		return "", err
 	}
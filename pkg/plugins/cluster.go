@@ -141,6 +141,7 @@ func HandleDefinition(name, syncDir, crdName string, annotation map[string]strin
 	}
 	tmp.CrdName = crdName
 	tmp.Description = GetDescription(annotation)
+	tmp.Example = GetExample(annotation)
 	return tmp, nil
 }
 
@@ -155,6 +156,14 @@ func GetDescription(annotation map[string]string) string {
 	return desc
 }
 
+// GetExample gets the example snippets of a capability from annotation
+func GetExample(annotation map[string]string) string {
+	if annotation == nil {
+		return ""
+	}
+	return annotation[types.AnnExample]
+}
+
 func HandleTemplate(in *runtime.RawExtension, name, syncDir string) (types.Capability, error) {
 	tmp, err := types.ConvertTemplateJSON2Object(in)
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/oam-dev/kubevela/api/types"
+	"github.com/oam-dev/kubevela/pkg/server/util"
 
 	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam/discoverymapper"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -16,9 +17,19 @@ type APIServer struct {
 	server     *http.Server
 	KubeClient client.Client
 	dm         discoverymapper.DiscoveryMapper
+	// clients caches clients of clusters which envs are bound to
+	clients *clientCache
 }
 
-func New(c types.Args, port, staticPath string) (*APIServer, error) {
+// writeTimeoutBuffer leaves time to write the response of a request timed out
+const writeTimeoutBuffer = 2 * time.Second
+
+// New creates the API server, handlers calling Kubernetes are canceled once requestTimeout elapses,
+// the access log is printed in logFormat which is either text or json
+func New(c types.Args, port, staticPath string, requestTimeout time.Duration, logFormat string) (*APIServer, error) {
+	if err := util.ValidateLogFormat(logFormat); err != nil {
+		return nil, err
+	}
 	newClient, err := client.New(c.Config, client.Options{Scheme: c.Schema})
 	if err != nil {
 		return nil, err
@@ -30,12 +41,18 @@ func New(c types.Args, port, staticPath string) (*APIServer, error) {
 	s := &APIServer{
 		KubeClient: newClient,
 		dm:         dm,
+		clients:    newClientCache(c.Schema),
+	}
+	// no write timeout either if requests have no deadline
+	var writeTimeout time.Duration
+	if requestTimeout > 0 {
+		writeTimeout = requestTimeout + writeTimeoutBuffer
 	}
 	server := &http.Server{
 		Addr:         port,
-		Handler:      s.setupRoute(staticPath),
+		Handler:      s.setupRoute(staticPath, requestTimeout, logFormat),
 		ReadTimeout:  5 * time.Second,
-		WriteTimeout: 10 * time.Second,
+		WriteTimeout: writeTimeout,
 	}
 	server.SetKeepAlivesEnabled(true)
 	s.server = server
@@ -1,6 +1,7 @@
 package apis
 
 import (
+	runtimev1alpha1 "github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
 	corev1alpha2 "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -13,6 +14,7 @@ type Environment struct {
 	Email     string `json:"email"`
 	Domain    string `json:"domain"`
 	Current   string `json:"current,omitempty"`
+	Context   string `json:"context,omitempty"`
 }
 
 type EnvironmentBody struct {
@@ -29,6 +31,26 @@ type AppConfig struct {
 type Response struct {
 	Code int         `json:"code"`
 	Data interface{} `json:"data"`
+	// Meta is only set in responses of paginated lists
+	Meta *ListMeta `json:"meta,omitempty"`
+	// Error is only set in responses of failed requests, Data holds the error message as well for compatibility
+	Error *Error `json:"error,omitempty"`
+}
+
+// Error is the detail of a failed request
+type Error struct {
+	// Code identifies the kind of the error, like InvalidArgument
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Field is the invalid field of the request body, like EnvName
+	Field string `json:"field,omitempty"`
+}
+
+// ListMeta holds the pagination metadata of a list, Limit is zero if the list isn't limited
+type ListMeta struct {
+	Total  int `json:"total"`
+	Offset int `json:"offset"`
+	Limit  int `json:"limit,omitempty"`
 }
 
 type CommonFlag struct {
@@ -79,6 +101,7 @@ type ComponentMeta struct {
 	TraitNames  []string                              `json:"traitsNames,omitempty"`
 	App         string                                `json:"app"`
 	CreatedTime string                                `json:"createdTime,omitempty"`
+	Namespace   string                                `json:"namespace,omitempty"`
 	AppConfig   corev1alpha2.ApplicationConfiguration `json:"-"`
 	Component   corev1alpha2.Component                `json:"-"`
 }
@@ -90,6 +113,54 @@ type ApplicationMeta struct {
 	CreatedTime string          `json:"createdTime,omitempty"`
 }
 
+// ServiceListItem is the schema of a service printed by `vela ls -o json|yaml`, fields are only added but never
+// changed, so that scripts parsing the output keep working
+type ServiceListItem struct {
+	Name        string   `json:"name"`
+	App         string   `json:"app"`
+	Type        string   `json:"type"`
+	Traits      []string `json:"traits"`
+	Status      string   `json:"status"`
+	CreatedTime string   `json:"createdTime"`
+	Namespace   string   `json:"namespace,omitempty"`
+}
+
+// AppListItem is an application listed by `GET /apps/` along with its services
+type AppListItem struct {
+	Name     string            `json:"name"`
+	Services []ServiceListItem `json:"services"`
+}
+
+// AppStatus is the status of an application returned by `GET /apps/:appName/status`,
+// Health is Healthy only if all services are healthy
+type AppStatus struct {
+	Name     string          `json:"name"`
+	Health   string          `json:"health"`
+	Services []ServiceHealth `json:"services"`
+}
+
+// ServiceHealth is the health of a service aggregated from its workload and traits
+type ServiceHealth struct {
+	Service string `json:"service"`
+	Health  string `json:"health"`
+	// Reason tells why the service is unhealthy
+	Reason   string           `json:"reason,omitempty"`
+	Workload *ResourceHealth  `json:"workload,omitempty"`
+	Traits   []ResourceHealth `json:"traits,omitempty"`
+}
+
+// ResourceHealth is the readiness and the raw conditions of a workload or trait
+type ResourceHealth struct {
+	Kind  string `json:"kind"`
+	Name  string `json:"name"`
+	Ready bool   `json:"ready"`
+	// Replicas and ReadyReplicas are only set for workloads having replicas in their spec
+	Replicas      *int64                      `json:"replicas,omitempty"`
+	ReadyReplicas *int64                      `json:"readyReplicas,omitempty"`
+	Reasons       []string                    `json:"reasons,omitempty"`
+	Conditions    []runtimev1alpha1.Condition `json:"conditions,omitempty"`
+}
+
 type CapabilityMeta struct {
 	CapabilityName       string `json:"capabilityName"`
 	CapabilityCenterName string `json:"capabilityCenterName,omitempty"`
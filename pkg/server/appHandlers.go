@@ -1,7 +1,14 @@
 package server
 
 import (
+	"os"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/oam-dev/kubevela/api/types"
+	cmdutil "github.com/oam-dev/kubevela/pkg/commands/util"
 	"github.com/oam-dev/kubevela/pkg/oam"
+	"github.com/oam-dev/kubevela/pkg/server/apis"
 	"github.com/oam-dev/kubevela/pkg/server/util"
 	"github.com/oam-dev/kubevela/pkg/utils/env"
 
@@ -18,10 +25,15 @@ func (s *APIServer) GetApp(c *gin.Context) {
 		util.HandleError(c, util.StatusInternalServerError, err)
 		return
 	}
+	kubeClient, err := s.getClient(envMeta)
+	if err != nil {
+		handleClientError(c, err)
+		return
+	}
 	namespace := envMeta.Namespace
 	appName := c.Param("appName")
 	ctx := util.GetContext(c)
-	applicationMeta, err := oam.RetrieveApplicationStatusByName(ctx, s.KubeClient, appName, namespace)
+	applicationMeta, err := oam.RetrieveApplicationStatusByName(ctx, kubeClient, appName, namespace)
 	if err != nil {
 		util.HandleError(c, util.StatusInternalServerError, err)
 		return
@@ -36,10 +48,15 @@ func (s *APIServer) ListApps(c *gin.Context) {
 		util.HandleError(c, util.StatusInternalServerError, err)
 		return
 	}
+	kubeClient, err := s.getClient(envMeta)
+	if err != nil {
+		handleClientError(c, err)
+		return
+	}
 	namespace := envMeta.Namespace
 
 	ctx := util.GetContext(c)
-	applicationMetaList, err := oam.ListApplications(ctx, s.KubeClient, oam.Option{Namespace: namespace})
+	applicationMetaList, err := oam.ListApplications(ctx, kubeClient, oam.Option{Namespace: namespace})
 	if err != nil {
 		util.HandleError(c, util.StatusInternalServerError, err.Error())
 		return
@@ -47,6 +64,89 @@ func (s *APIServer) ListApps(c *gin.Context) {
 	util.AssembleResponse(c, applicationMetaList, nil)
 }
 
+// ListAllApps lists applications along with their services and traits, in the env of `?env=` or the current env,
+// it shares the listing logic with `vela ls` so the two stay consistent
+func (s *APIServer) ListAllApps(c *gin.Context) {
+	envMeta, ok := getEnvFromQuery(c)
+	if !ok {
+		return
+	}
+	kubeClient, err := s.getClient(envMeta)
+	if err != nil {
+		handleClientError(c, err)
+		return
+	}
+	ctx := util.GetContext(c)
+	comps, err := oam.ListServices(ctx, kubeClient, "", envMeta,
+		cmdutil.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	if err != nil {
+		util.HandleError(c, util.StatusInternalServerError, err)
+		return
+	}
+	util.AssembleResponse(c, groupServicesByApp(oam.ToServiceListItems(comps)), nil)
+}
+
+// GetAppStatus gets the health of the application and each of its services, along with the readiness and conditions
+// of their workloads and traits, the application is looked up in the env of `?env=` or the current env
+func (s *APIServer) GetAppStatus(c *gin.Context) {
+	envMeta, ok := getEnvFromQuery(c)
+	if !ok {
+		return
+	}
+	kubeClient, err := s.getClient(envMeta)
+	if err != nil {
+		handleClientError(c, err)
+		return
+	}
+	appName := c.Param("appName")
+	ctx := util.GetContext(c)
+	status, err := oam.GetAppStatus(ctx, kubeClient, appName, envMeta.Namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			util.HandleError(c, util.AppNotFound, appName, envMeta.Name)
+			return
+		}
+		util.HandleError(c, util.StatusInternalServerError, err)
+		return
+	}
+	util.AssembleResponse(c, status, nil)
+}
+
+// getEnvFromQuery gets the env of `?env=`, or the current env if it's not specified,
+// it responds the error and returns false if the env can't be found
+func getEnvFromQuery(c *gin.Context) (*types.EnvMeta, bool) {
+	envName := c.Query("env")
+	if envName == "" {
+		var err error
+		if envName, err = env.GetCurrentEnvName(); err != nil {
+			util.HandleError(c, util.StatusInternalServerError, err)
+			return nil, false
+		}
+	}
+	envMeta, err := env.GetEnvByName(envName)
+	if err != nil {
+		handleEnvError(c, envName, err)
+		return nil, false
+	}
+	return envMeta, true
+}
+
+// groupServicesByApp groups the services by their applications, in the order the applications first appear
+func groupServicesByApp(services []apis.ServiceListItem) []apis.AppListItem {
+	apps := make([]apis.AppListItem, 0)
+	index := make(map[string]int)
+	for _, svc := range services {
+		i, ok := index[svc.App]
+		if !ok {
+			i = len(apps)
+			index[svc.App] = i
+			apps = append(apps, apis.AppListItem{Name: svc.App})
+		}
+		apps[i].Services = append(apps[i].Services, svc)
+	}
+	return apps
+}
+
 func (s *APIServer) DeleteApps(c *gin.Context) {
 	envName := c.Param("envName")
 	envMeta, err := env.GetEnvByName(envName)
@@ -54,13 +154,18 @@ func (s *APIServer) DeleteApps(c *gin.Context) {
 		util.HandleError(c, util.StatusInternalServerError, err)
 		return
 	}
+	kubeClient, err := s.getClient(envMeta)
+	if err != nil {
+		handleClientError(c, err)
+		return
+	}
 	appName := c.Param("appName")
 
 	o := oam.DeleteOptions{
-		Client:  s.KubeClient,
+		Client:  kubeClient,
 		Env:     envMeta,
 		AppName: appName,
 	}
-	message, err := o.DeleteApp()
+	message, err := o.DeleteApp(util.GetContext(c))
 	util.AssembleResponse(c, message, err)
 }
@@ -11,7 +11,7 @@ import (
 func (s *APIServer) AddCapabilityCenter(c *gin.Context) {
 	var body plugins.CapCenterConfig
 	if err := c.ShouldBindJSON(&body); err != nil {
-		util.HandleError(c, util.StatusInternalServerError, "the add capability center request body is invalid")
+		util.HandleBindingError(c, err, "the add capability center request body is invalid")
 		return
 	}
 	if err := oam.AddCapabilityCenter(body.Name, body.Address, body.Token); err != nil {
@@ -41,7 +41,7 @@ func (s *APIServer) SyncCapabilityCenter(c *gin.Context) {
 
 func (s *APIServer) AddCapabilityIntoCluster(c *gin.Context) {
 	cap := c.Param("capabilityCenterName") + "/" + c.Param("capabilityName")
-	msg, err := oam.AddCapabilityIntoCluster(s.KubeClient, s.dm, cap)
+	msg, err := oam.AddCapabilityIntoCluster(util.GetContext(c), s.KubeClient, s.dm, cap)
 	if err != nil {
 		util.HandleError(c, util.StatusInternalServerError)
 		return
@@ -57,7 +57,7 @@ func (s *APIServer) DeleteCapabilityCenter(c *gin.Context) {
 
 func (s *APIServer) RemoveCapabilityFromCluster(c *gin.Context) {
 	capabilityCenterName := c.Param("capabilityName")
-	msg, err := oam.RemoveCapabilityFromCluster(s.KubeClient, capabilityCenterName)
+	msg, err := oam.RemoveCapabilityFromCluster(util.GetContext(c), s.KubeClient, capabilityCenterName)
 	if err != nil {
 		util.HandleError(c, util.StatusInternalServerError, err.Error())
 		return
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	"github.com/oam-dev/kubevela/api/types"
+	"github.com/oam-dev/kubevela/pkg/server/util"
+)
+
+// clusterProbeTimeout is the max time to check whether the cluster of a context is reachable
+const clusterProbeTimeout = 5 * time.Second
+
+// clientCache caches kubernetes clients keyed by the kubeconfig context
+type clientCache struct {
+	mu      sync.Mutex
+	scheme  *runtime.Scheme
+	clients map[string]client.Client
+	// locks serialize creating the client of each context, so that an unreachable cluster only blocks its own context
+	locks map[string]*sync.Mutex
+}
+
+func newClientCache(scheme *runtime.Scheme) *clientCache {
+	return &clientCache{
+		scheme:  scheme,
+		clients: make(map[string]client.Client),
+		locks:   make(map[string]*sync.Mutex),
+	}
+}
+
+// get returns the cached client of the context, or creates one after checking the cluster is reachable
+func (cc *clientCache) get(kubeContext string) (client.Client, error) {
+	c, lock := cc.lookup(kubeContext)
+	if c != nil {
+		return c, nil
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	// the client may be created by another request while waiting for the lock
+	if c, _ = cc.lookup(kubeContext); c != nil {
+		return c, nil
+	}
+	c, err := cc.newClient(kubeContext)
+	if err != nil {
+		return nil, err
+	}
+	cc.mu.Lock()
+	cc.clients[kubeContext] = c
+	cc.mu.Unlock()
+	return c, nil
+}
+
+// lookup returns the cached client of the context, and the lock to create it if it's not cached
+func (cc *clientCache) lookup(kubeContext string) (client.Client, *sync.Mutex) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if c, ok := cc.clients[kubeContext]; ok {
+		return c, nil
+	}
+	lock, ok := cc.locks[kubeContext]
+	if !ok {
+		lock = &sync.Mutex{}
+		cc.locks[kubeContext] = lock
+	}
+	return nil, lock
+}
+
+func (cc *clientCache) newClient(kubeContext string) (client.Client, error) {
+	restConf, err := config.GetConfigWithContext(kubeContext)
+	if err != nil {
+		return nil, err
+	}
+	probeConf := rest.CopyConfig(restConf)
+	probeConf.Timeout = clusterProbeTimeout
+	dc, err := discovery.NewDiscoveryClientForConfig(probeConf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = dc.ServerVersion(); err != nil {
+		return nil, err
+	}
+	return client.New(restConf, client.Options{Scheme: cc.scheme})
+}
+
+// contextUnreachableError means the cluster of the context bound to the env is unreachable
+type contextUnreachableError struct {
+	env *types.EnvMeta
+	err error
+}
+
+func (e *contextUnreachableError) Error() string {
+	return util.ConstructError(util.EnvContextUnreachable, e.env.Context, e.env.Name, e.err.Error()).Error()
+}
+
+// getClient returns the client of the cluster bound to the env, the default client is used if the env isn't bound
+func (s *APIServer) getClient(envMeta *types.EnvMeta) (client.Client, error) {
+	if envMeta.Context == "" {
+		return s.KubeClient, nil
+	}
+	c, err := s.clients.get(envMeta.Context)
+	if err != nil {
+		return nil, &contextUnreachableError{env: envMeta, err: err}
+	}
+	return c, nil
+}
+
+// handleClientError responds EnvContextUnreachable if the cluster bound to the env is unreachable, other errors are
+// responded as internal errors
+func handleClientError(c *gin.Context, err error) {
+	var unreachable *contextUnreachableError
+	if errors.As(err, &unreachable) {
+		util.HandleError(c, util.EnvContextUnreachable, unreachable.env.Context, unreachable.env.Name, unreachable.err.Error())
+		return
+	}
+	util.HandleError(c, util.StatusInternalServerError, err.Error())
+}
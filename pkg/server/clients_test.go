@@ -0,0 +1,149 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/oam-dev/kubevela/api/types"
+	"github.com/oam-dev/kubevela/pkg/server/apis"
+)
+
+// unreachableKubeConfig has a context bound to a cluster which is never reachable
+const unreachableKubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: offline
+  cluster:
+    server: https://127.0.0.1:1
+contexts:
+- name: offline
+  context:
+    cluster: offline
+    user: offline
+users:
+- name: offline
+  user:
+    token: fake
+current-context: offline
+`
+
+func TestGetClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubeconfig")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	kubeConfig := filepath.Join(dir, "config")
+	assert.NoError(t, ioutil.WriteFile(kubeConfig, []byte(unreachableKubeConfig), 0600))
+	origin, set := os.LookupEnv("KUBECONFIG")
+	assert.NoError(t, os.Setenv("KUBECONFIG", kubeConfig))
+	defer func() {
+		if set {
+			_ = os.Setenv("KUBECONFIG", origin)
+		} else {
+			_ = os.Unsetenv("KUBECONFIG")
+		}
+	}()
+
+	defaultClient := &test.MockClient{}
+	cachedClient := &test.MockClient{}
+	s := &APIServer{KubeClient: defaultClient, clients: newClientCache(runtime.NewScheme())}
+	s.clients.clients["staging"] = cachedClient
+
+	cases := map[string]struct {
+		env       *types.EnvMeta
+		expClient interface{}
+		expErr    string
+	}{
+		"env not bound to a context": {
+			env:       &types.EnvMeta{Name: "default"},
+			expClient: defaultClient,
+		},
+		"cached client of the context": {
+			env:       &types.EnvMeta{Name: "staging", Context: "staging"},
+			expClient: cachedClient,
+		},
+		"context not found": {
+			env:    &types.EnvMeta{Name: "prod", Context: "prod"},
+			expErr: "cluster of context 'prod' bound to env 'prod' is unreachable",
+		},
+		"cluster unreachable": {
+			env:    &types.EnvMeta{Name: "offline", Context: "offline"},
+			expErr: "cluster of context 'offline' bound to env 'offline' is unreachable",
+		},
+	}
+	for name, c := range cases {
+		got, err := s.getClient(c.env)
+		if c.expErr != "" {
+			if assert.Error(t, err, name) {
+				assert.Contains(t, err.Error(), c.expErr, name)
+			}
+			continue
+		}
+		assert.NoError(t, err, name)
+		assert.Same(t, c.expClient, got, name)
+	}
+	// clients of unreachable clusters are not cached
+	assert.Len(t, s.clients.clients, 1)
+
+	// creating the client of a context doesn't wait for other contexts
+	_, lock := s.clients.lookup("offline")
+	lock.Lock()
+	defer lock.Unlock()
+	done := make(chan error)
+	go func() {
+		_, err := s.clients.get("prod")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		assert.Error(t, err)
+	case <-time.After(clusterProbeTimeout):
+		t.Error("getting the client of context prod is blocked by context offline")
+	}
+}
+
+func TestHandleClientError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cases := map[string]struct {
+		err        error
+		expStatus  int
+		expCode    string
+		expMessage string
+	}{
+		"cluster unreachable": {
+			err:        &contextUnreachableError{env: &types.EnvMeta{Name: "prod", Context: "prod"}, err: errors.New("connection refused")},
+			expStatus:  http.StatusServiceUnavailable,
+			expCode:    "EnvContextUnreachable",
+			expMessage: "cluster of context 'prod' bound to env 'prod' is unreachable: connection refused",
+		},
+		"other errors": {
+			err:        errors.New("app not found"),
+			expStatus:  http.StatusInternalServerError,
+			expCode:    "StatusInternalServerError",
+			expMessage: "app not found",
+		},
+	}
+	for name, c := range cases {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		handleClientError(ctx, c.err)
+		assert.Equal(t, c.expStatus, w.Code, name)
+		var resp apis.Response
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp), name)
+		if assert.NotNil(t, resp.Error, name) {
+			assert.Equal(t, c.expCode, resp.Error.Code, name)
+			assert.Equal(t, c.expMessage, resp.Error.Message, name)
+		}
+	}
+}
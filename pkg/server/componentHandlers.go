@@ -18,11 +18,16 @@ func (s *APIServer) GetComponent(c *gin.Context) {
 		util.HandleError(c, util.StatusInternalServerError, err)
 		return
 	}
+	kubeClient, err := s.getClient(envMeta)
+	if err != nil {
+		handleClientError(c, err)
+		return
+	}
 	namespace := envMeta.Namespace
 	applicationName := c.Param("appName")
 	componentName := c.Param("compName")
 	ctx := util.GetContext(c)
-	componentMeta, err := oam.RetrieveComponent(ctx, s.KubeClient, applicationName, componentName, namespace)
+	componentMeta, err := oam.RetrieveComponent(ctx, kubeClient, applicationName, componentName, namespace)
 	if err != nil {
 		util.HandleError(c, util.StatusInternalServerError, err)
 		return
@@ -37,16 +42,21 @@ func (s *APIServer) DeleteComponent(c *gin.Context) {
 		util.HandleError(c, util.StatusInternalServerError, err)
 		return
 	}
+	kubeClient, err := s.getClient(envMeta)
+	if err != nil {
+		handleClientError(c, err)
+		return
+	}
 	appName := c.Param("appName")
 	componentName := c.Param("compName")
 
 	o := oam.DeleteOptions{
-		Client:   s.KubeClient,
+		Client:   kubeClient,
 		Env:      envMeta,
 		AppName:  appName,
 		CompName: componentName}
 
-	message, err := o.DeleteComponent(
+	message, err := o.DeleteComponent(util.GetContext(c),
 		cmdutil.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
 	util.AssembleResponse(c, message, err)
 }
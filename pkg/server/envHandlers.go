@@ -1,8 +1,9 @@
 package server
 
 import (
+	"errors"
+
 	"github.com/gin-gonic/gin"
-	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/oam-dev/kubevela/api/types"
 	"github.com/oam-dev/kubevela/pkg/server/apis"
@@ -14,70 +15,150 @@ import (
 func (s *APIServer) CreateEnv(c *gin.Context) {
 	var environment apis.Environment
 	if err := c.ShouldBindJSON(&environment); err != nil {
-		util.HandleError(c, util.InvalidArgument, "the create environment request body is invalid")
+		util.HandleBindingError(c, err, "the create environment request body is invalid")
 		return
 	}
-	ctrl.Log.Info("Get a create environment request", "env", environment)
+	util.GetLogger(c).Info("Get a create environment request", "env", environment)
 	name := environment.EnvName
 	namespace := environment.Namespace
 	if namespace == "" {
 		namespace = "default"
 	}
 
-	ctx := util.GetContext(c)
-	message, err := env.CreateEnv(ctx, s.KubeClient, name, &types.EnvMeta{
+	envMeta := &types.EnvMeta{
 		Name:      name,
 		Current:   environment.Current,
 		Namespace: namespace,
 		Email:     environment.Email,
 		Domain:    environment.Domain,
-	})
+		Context:   environment.Context,
+	}
+	kubeClient, err := s.getClient(envMeta)
+	if err != nil {
+		handleClientError(c, err)
+		return
+	}
+	ctx := util.GetContext(c)
+	message, err := env.CreateEnv(ctx, kubeClient, name, envMeta)
 	util.AssembleResponse(c, message, err)
 }
 
 func (s *APIServer) UpdateEnv(c *gin.Context) {
 	envName := c.Param("envName")
-	ctrl.Log.Info("Put a update environment request", "envName", envName)
+	util.GetLogger(c).Info("Put a update environment request", "envName", envName)
 	var environmentBody apis.EnvironmentBody
 	if err := c.ShouldBindJSON(&environmentBody); err != nil {
-		util.HandleError(c, util.InvalidArgument, "the update environment request body is invalid")
+		util.HandleBindingError(c, err, "the update environment request body is invalid")
+		return
+	}
+	envMeta, err := env.GetEnvByName(envName)
+	if err != nil {
+		handleEnvError(c, envName, err)
+		return
+	}
+	kubeClient, err := s.getClient(envMeta)
+	if err != nil {
+		handleClientError(c, err)
 		return
 	}
 	ctx := util.GetContext(c)
-	message, err := env.UpdateEnv(ctx, s.KubeClient, envName, environmentBody.Namespace)
+	message, err := env.UpdateEnv(ctx, kubeClient, envName, environmentBody.Namespace)
 	util.AssembleResponse(c, message, err)
 }
 
+// GetEnv gets the environment by name, EnvNotFound is responded if it doesn't exist
 func (s *APIServer) GetEnv(c *gin.Context) {
 	envName := c.Param("envName")
-	ctrl.Log.Info("Get a get environment request", "envName", envName)
+	util.GetLogger(c).Info("Get a get environment request", "envName", envName)
 	envList, err := env.ListEnvs(envName)
-
-	environmentList := make([]apis.Environment, 0)
-	for _, envMeta := range envList {
-		environmentList = append(environmentList, apis.Environment{
-			EnvName:   envMeta.Name,
-			Namespace: envMeta.Namespace,
-			Current:   envMeta.Current,
-		})
-	}
-	util.AssembleResponse(c, environmentList, err)
+	if err != nil {
+		handleEnvError(c, envName, err)
+		return
+	}
+	util.AssembleResponse(c, toEnvironment(envList[0]), nil)
 }
 
+// ListEnv lists environments, a page of them is returned if `offset` or `limit` is specified
 func (s *APIServer) ListEnv(c *gin.Context) {
-	s.GetEnv(c)
+	util.GetLogger(c).Info("Get a list environment request")
+	offset, limit, err := util.ParsePagination(c)
+	if err != nil {
+		util.HandleError(c, util.InvalidArgument, err.Error())
+		return
+	}
+	envList, err := env.ListEnvs("")
+	if err != nil {
+		util.AssembleResponse(c, nil, err)
+		return
+	}
+	start, end := util.Paginate(len(envList), offset, limit)
+	environmentList := make([]apis.Environment, 0)
+	for _, envMeta := range envList[start:end] {
+		environmentList = append(environmentList, toEnvironment(envMeta))
+	}
+	util.AssembleListResponse(c, environmentList, apis.ListMeta{Total: len(envList), Offset: offset, Limit: limit})
 }
 
+// DeleteEnv deletes the environment, EnvInUse is responded if it's the current one
 func (s *APIServer) DeleteEnv(c *gin.Context) {
 	envName := c.Param("envName")
-	ctrl.Log.Info("Delete a delete environment request", "envName", envName)
+	util.GetLogger(c).Info("Delete a delete environment request", "envName", envName)
+	if _, err := env.GetEnvByName(envName); err != nil {
+		handleEnvError(c, envName, err)
+		return
+	}
+	if curEnv, err := env.GetCurrentEnvName(); err == nil && curEnv == envName {
+		util.HandleError(c, util.EnvInUse, envName)
+		return
+	}
 	msg, err := env.DeleteEnv(envName)
 	util.AssembleResponse(c, msg, err)
 }
 
 func (s *APIServer) SetEnv(c *gin.Context) {
 	envName := c.Param("envName")
-	ctrl.Log.Info("Patch a set environment request", "envName", envName)
+	util.GetLogger(c).Info("Patch a set environment request", "envName", envName)
 	msg, err := env.SetEnv(envName)
-	util.AssembleResponse(c, msg, err)
+	if err != nil {
+		handleEnvError(c, envName, err)
+		return
+	}
+	util.AssembleResponse(c, msg, nil)
+}
+
+// SwitchEnv sets the environment as the current one like `vela env sw`, and responds the newly current environment
+func (s *APIServer) SwitchEnv(c *gin.Context) {
+	envName := c.Param("envName")
+	util.GetLogger(c).Info("Put a switch environment request", "envName", envName)
+	if _, err := env.SetEnv(envName); err != nil {
+		handleEnvError(c, envName, err)
+		return
+	}
+	envList, err := env.ListEnvs(envName)
+	if err != nil {
+		handleEnvError(c, envName, err)
+		return
+	}
+	util.AssembleResponse(c, toEnvironment(envList[0]), nil)
+}
+
+// handleEnvError responds EnvNotFound if the environment doesn't exist, or the error as it is
+func handleEnvError(c *gin.Context, envName string, err error) {
+	var notFound *env.NotFoundError
+	if errors.As(err, &notFound) {
+		util.HandleError(c, util.EnvNotFound, envName)
+		return
+	}
+	util.AssembleResponse(c, nil, err)
+}
+
+func toEnvironment(envMeta *types.EnvMeta) apis.Environment {
+	return apis.Environment{
+		EnvName:   envMeta.Name,
+		Namespace: envMeta.Namespace,
+		Email:     envMeta.Email,
+		Domain:    envMeta.Domain,
+		Current:   envMeta.Current,
+		Context:   envMeta.Context,
+	}
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"io"
 	"os"
 	"os/signal"
@@ -20,6 +21,11 @@ import (
 // main will only start up API server
 func main() {
 	var development = true
+	var requestTimeout time.Duration
+	var logFormat string
+	flag.DurationVar(&requestTimeout, "request-timeout", util.DefaultRequestTimeout, "timeout of handling a request, 0 means no timeout")
+	flag.StringVar(&logFormat, "log-format", util.LogFormatText, "format of the access log, support text and json")
+	flag.Parse()
 	// setup logging
 	var w io.Writer = os.Stdout
 
@@ -33,7 +39,7 @@ func main() {
 		ctrl.Log.Error(err, "failed to init Kubernetes Config")
 		os.Exit(1)
 	}
-	apiServer, err := server.New(c, util.DefaultAPIServerPort, "")
+	apiServer, err := server.New(c, util.DefaultAPIServerPort, "", requestTimeout, logFormat)
 	if err != nil {
 		ctrl.Log.Error(err, "failed to init dashboard server")
 		os.Exit(1)
@@ -1,17 +1,58 @@
 package server
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
 	"github.com/gin-gonic/gin"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/oam-dev/kubevela/pkg/server/apis"
+	"github.com/oam-dev/kubevela/pkg/server/util"
 	"github.com/oam-dev/kubevela/version"
 )
 
+// probeTimeout bounds the requests to Kubernetes made by health probes, so that probes fail fast
+const probeTimeout = 3 * time.Second
+
 func (s *APIServer) GetVersion(c *gin.Context) {
 	c.JSON(http.StatusOK, apis.Response{
 		Code: http.StatusOK,
 		Data: map[string]string{"version": version.VelaVersion},
 	})
 }
+
+// Healthz responds ok if the Kubernetes API is reachable
+func (s *APIServer) Healthz(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), probeTimeout)
+	defer cancel()
+	var namespaces corev1.NamespaceList
+	if err := s.KubeClient.List(ctx, &namespaces, client.Limit(1)); err != nil {
+		util.HandleError(c, util.NotReady, fmt.Sprintf("kubernetes API is unreachable: %v", err))
+		return
+	}
+	util.AssembleResponse(c, "ok", nil)
+}
+
+// Readyz responds ok if the Kubernetes API is reachable and the OAM CRDs are installed
+func (s *APIServer) Readyz(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), probeTimeout)
+	defer cancel()
+	for kind, list := range map[string]runtime.Object{
+		"ApplicationConfiguration": &v1alpha2.ApplicationConfigurationList{},
+		"Component":                &v1alpha2.ComponentList{},
+		"WorkloadDefinition":       &v1alpha2.WorkloadDefinitionList{},
+		"TraitDefinition":          &v1alpha2.TraitDefinitionList{},
+	} {
+		if err := s.KubeClient.List(ctx, list, client.Limit(1)); err != nil {
+			util.HandleError(c, util.NotReady, fmt.Sprintf("failed to list %s, make sure the OAM CRDs are installed: %v", kind, err))
+			return
+		}
+	}
+	util.AssembleResponse(c, "ok", nil)
+}
@@ -1,9 +1,9 @@
 package server
 
 import (
-	"fmt"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/gin-contrib/static"
 	"github.com/gin-gonic/gin"
@@ -13,7 +13,7 @@ import (
 
 // setup the gin http server handler
 
-func (s *APIServer) setupRoute(staticPath string) http.Handler {
+func (s *APIServer) setupRoute(staticPath string, requestTimeout time.Duration, logFormat string) http.Handler {
 	// if deploying static Dashboard, set the mode to `release`, or to `debug`
 	if staticPath != "" {
 		gin.SetMode(gin.ReleaseMode)
@@ -21,18 +21,8 @@ func (s *APIServer) setupRoute(staticPath string) http.Handler {
 	// create the router
 	router := gin.New()
 	loggerConfig := gin.LoggerConfig{
-		Output: os.Stdout,
-		Formatter: func(param gin.LogFormatterParams) string {
-			return fmt.Sprintf("%v | %3d | %13v | %15s | %-7s %s | %s\n",
-				param.TimeStamp.Format("2006/01/02 - 15:04:05"),
-				param.StatusCode,
-				param.Latency,
-				param.ClientIP,
-				param.Method,
-				param.Path,
-				param.ErrorMessage,
-			)
-		},
+		Output:    os.Stdout,
+		Formatter: util.LogFormatter(logFormat),
 	}
 
 	if staticPath != "" {
@@ -41,9 +31,12 @@ func (s *APIServer) setupRoute(staticPath string) http.Handler {
 
 	router.Use(gin.LoggerWithConfig(loggerConfig))
 	router.Use(util.SetRequestID())
-	router.Use(util.SetContext())
+	router.Use(util.SetContext(requestTimeout))
 	router.Use(gin.Recovery())
 	router.Use(util.ValidateHeaders())
+	// liveness and readiness probes
+	router.GET(util.HealthzPath, s.Healthz)
+	router.GET(util.ReadyzPath, s.Readyz)
 	// all requests start with /api
 	api := router.Group(util.RootPath)
 	// env related operation
@@ -57,6 +50,7 @@ func (s *APIServer) setupRoute(staticPath string) http.Handler {
 		envs.GET("", s.ListEnv)
 		envs.DELETE("/:envName", s.DeleteEnv)
 		envs.PATCH("/:envName", s.SetEnv)
+		envs.PUT("/:envName/current", s.SwitchEnv)
 		// app related operation
 		apps := envs.Group("/:envName/apps")
 		{
@@ -83,6 +77,13 @@ func (s *APIServer) setupRoute(staticPath string) http.Handler {
 			}
 		}
 	}
+	// application related api across environments, scoped by `?env=`
+	apps := api.Group(util.ApplicationPath)
+	{
+		apps.GET("/", s.ListAllApps)
+		apps.GET("", s.ListAllApps)
+		apps.GET("/:appName/status", s.GetAppStatus)
+	}
 	// workload related api
 	workload := api.Group(util.WorkloadDefinitionPath)
 	{
@@ -11,7 +11,6 @@ import (
 	"github.com/spf13/pflag"
 
 	"github.com/gin-gonic/gin"
-	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/oam-dev/kubevela/api/types"
 	"github.com/oam-dev/kubevela/pkg/oam"
@@ -27,13 +26,13 @@ func (s *APIServer) AttachTrait(c *gin.Context) {
 	body.ComponentName = c.Param("compName")
 
 	if err := c.ShouldBindJSON(&body); err != nil {
-		util.HandleError(c, util.InvalidArgument, "the trait attach request body is invalid")
+		util.HandleBindingError(c, err, "the trait attach request body is invalid")
 		return
 	}
-	ctrl.Log.Info("request parameters body:", "body", body)
+	util.GetLogger(c).Info("request parameters body:", "body", body)
 	msg, err := s.DoAttachTrait(c, body)
 	if err != nil {
-		util.HandleError(c, util.StatusInternalServerError, err.Error())
+		handleClientError(c, err)
 		return
 	}
 	util.AssembleResponse(c, msg, nil)
@@ -79,7 +78,7 @@ func (s *APIServer) DetachTrait(c *gin.Context) {
 	}
 	msg, err := s.DoDetachTrait(c, envName, traitType, componentName, applicationName, staging)
 	if err != nil {
-		util.HandleError(c, util.StatusInternalServerError, err.Error())
+		handleClientError(c, err)
 		return
 	}
 	util.AssembleResponse(c, msg, nil)
@@ -115,8 +114,12 @@ func (s *APIServer) DoAttachTrait(c *gin.Context, body apis.TraitBody) (string,
 	if err != nil {
 		return "", err
 	}
+	kubeClient, err := s.getClient(env)
+	if err != nil {
+		return "", err
+	}
 	io := util2.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr}
-	return oam.TraitOperationRun(c, s.KubeClient, env, appObj, staging, io)
+	return oam.TraitOperationRun(util.GetContext(c), kubeClient, env, appObj, staging, io)
 }
 
 func (s *APIServer) DoDetachTrait(c *gin.Context, envName string, traitType string, componentName string, appName string, staging bool) (string, error) {
@@ -133,6 +136,10 @@ func (s *APIServer) DoDetachTrait(c *gin.Context, envName string, traitType stri
 	if err != nil {
 		return "", err
 	}
+	kubeClient, err := s.getClient(env)
+	if err != nil {
+		return "", err
+	}
 	io := util2.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr}
-	return oam.TraitOperationRun(c, s.KubeClient, env, appObj, staging, io)
+	return oam.TraitOperationRun(util.GetContext(c), kubeClient, env, appObj, staging, io)
 }
@@ -3,6 +3,8 @@ package util
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -13,14 +15,14 @@ var DefaultDashboardPort = ":38081"
 
 const DefaultAPIServerPort = ":8081"
 
+// DefaultRequestTimeout is the default timeout of handling a request, requests calling Kubernetes longer than it
+// are responded as RequestTimeout
+const DefaultRequestTimeout = 8 * time.Second
+
 func AssembleResponse(c *gin.Context, data interface{}, err error) {
 	var code = http.StatusOK
 	if err != nil {
-		code = http.StatusInternalServerError
-		c.JSON(code, apis.Response{
-			Code: code,
-			Data: err.Error(),
-		})
+		assembleErrorResponse(c, StatusInternalServerError, &apis.Error{Message: err.Error()})
 		return
 	}
 
@@ -30,6 +32,57 @@ func AssembleResponse(c *gin.Context, data interface{}, err error) {
 	})
 }
 
+// assembleErrorResponse responds the error with the http status code of the error code,
+// internal errors caused by the request timing out are responded as RequestTimeout
+func assembleErrorResponse(c *gin.Context, code Code, e *apis.Error) {
+	if code == StatusInternalServerError && timedOut(c) {
+		code = RequestTimeout
+		e.Message = ConstructError(RequestTimeout, e.Message).Error()
+	}
+	e.Code = code.ID()
+	c.JSON(code.StatusCode(), apis.Response{
+		Code:  code.StatusCode(),
+		Data:  e.Message,
+		Error: e,
+	})
+}
+
+// AssembleListResponse responds a page of a list along with the pagination metadata
+func AssembleListResponse(c *gin.Context, data interface{}, meta apis.ListMeta) {
+	c.JSON(http.StatusOK, apis.Response{
+		Code: http.StatusOK,
+		Data: data,
+		Meta: &meta,
+	})
+}
+
+// ParsePagination parses the `offset` and `limit` query parameters, limit is zero if it's not specified
+func ParsePagination(c *gin.Context) (offset, limit int, err error) {
+	if v := c.Query("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			return 0, 0, fmt.Errorf("offset should be a non-negative integer, got %s", v)
+		}
+	}
+	if v := c.Query("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 0 {
+			return 0, 0, fmt.Errorf("limit should be a non-negative integer, got %s", v)
+		}
+	}
+	return offset, limit, nil
+}
+
+// Paginate returns the range [start, end) of the page within a list of the total length
+func Paginate(total, offset, limit int) (start, end int) {
+	if offset > total {
+		offset = total
+	}
+	end = total
+	if limit > 0 && offset+limit < total {
+		end = offset + limit
+	}
+	return offset, end
+}
+
 func URL(url string) string {
 	return fmt.Sprintf("http://127.0.0.1%s/api%s", DefaultDashboardPort, url)
 }
@@ -6,8 +6,11 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 	"github.com/pkg/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/oam-dev/kubevela/pkg/server/apis"
 )
 
 // Code defines the error code type.
@@ -20,6 +23,12 @@ const (
 	InvalidArgument
 	UnsupportedMediaType
 	StatusInternalServerError
+	EnvContextUnreachable
+	EnvNotFound
+	EnvInUse
+	NotReady
+	AppNotFound
+	RequestTimeout
 )
 
 type errorDetail struct {
@@ -32,7 +41,13 @@ var errorDetails = map[Code]errorDetail{
 	PathNotSupported:          {"PathNotSupported", http.StatusNotFound, "'%s' against '%s' is not supported"},
 	InvalidArgument:           {"InvalidArgument", http.StatusBadRequest, "%s"},
 	UnsupportedMediaType:      {"UnsupportedMediaType", http.StatusUnsupportedMediaType, "content type should be 'application/json' or 'application/octet-stream'"},
-	StatusInternalServerError: {"StatusInternalServerError", http.StatusInternalServerError, "%s"}}
+	StatusInternalServerError: {"StatusInternalServerError", http.StatusInternalServerError, "%s"},
+	EnvContextUnreachable:     {"EnvContextUnreachable", http.StatusServiceUnavailable, "cluster of context '%s' bound to env '%s' is unreachable: %s"},
+	EnvNotFound:               {"EnvNotFound", http.StatusNotFound, "env %s not exist"},
+	EnvInUse:                  {"EnvInUse", http.StatusConflict, "you can't delete current using environment %s"},
+	NotReady:                  {"NotReady", http.StatusServiceUnavailable, "%s"},
+	AppNotFound:               {"AppNotFound", http.StatusNotFound, "app %s not found in env %s"},
+	RequestTimeout:            {"RequestTimeout", http.StatusGatewayTimeout, "request timed out: %s"}}
 
 // ID returns the error ID.
 func (c Code) ID() string {
@@ -68,13 +83,30 @@ func ConstructError(ec Code, a ...interface{}) error {
 }
 
 // - use setErrorAndAbort to abort the rest of the handlers, mostly called in middleware
+// the error is responded in the same envelope as HandleError
 func SetErrorAndAbort(c *gin.Context, code Code, msg ...interface{}) {
 	// Calling abort so no handlers and middlewares will be executed.
-	c.AbortWithStatusJSON(code.StatusCode(), gin.H{"error": ConstructError(code, msg...).Error()})
-
+	message := ConstructError(code, msg...).Error()
+	c.AbortWithStatusJSON(code.StatusCode(), apis.Response{
+		Code:  code.StatusCode(),
+		Data:  message,
+		Error: &apis.Error{Code: code.ID(), Message: message},
+	})
 }
 
 func HandleError(c *gin.Context, code Code, msg ...interface{}) {
 	err := ConstructError(code, msg...)
-	AssembleResponse(c, nil, err)
+	assembleErrorResponse(c, code, &apis.Error{Message: err.Error()})
+}
+
+// HandleBindingError responds an InvalidArgument error for the request body failed to bind,
+// the path of the first invalid field is included if the body failed in validation
+func HandleBindingError(c *gin.Context, err error, msg string) {
+	e := &apis.Error{Message: ConstructError(InvalidArgument, msg).Error()}
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) && len(validationErrs) > 0 {
+		e.Field = validationErrs[0].Field()
+		e.Message = fmt.Sprintf("%s: %v", e.Message, validationErrs[0])
+	}
+	assembleErrorResponse(c, InvalidArgument, e)
 }
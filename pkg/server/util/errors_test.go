@@ -0,0 +1,71 @@
+package util
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/oam-dev/kubevela/pkg/server/apis"
+)
+
+func TestSetErrorAndAbort(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cases := map[string]struct {
+		method      string
+		path        string
+		contentType string
+		wantStatus  int
+		wantError   apis.Error
+	}{
+		"no route matches": {
+			method:     http.MethodGet,
+			path:       "/unknown",
+			wantStatus: http.StatusNotFound,
+			wantError: apis.Error{
+				Code:    "PathNotSupported",
+				Message: "'GET' against '/unknown' is not supported",
+			},
+		},
+		"unsupported content type": {
+			method:      http.MethodPost,
+			path:        "/",
+			contentType: "text/plain",
+			wantStatus:  http.StatusUnsupportedMediaType,
+			wantError: apis.Error{
+				Code:    "UnsupportedMediaType",
+				Message: "content type should be 'application/json' or 'application/octet-stream'",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			handled := false
+			router := gin.New()
+			router.Use(ValidateHeaders())
+			router.POST("/", func(c *gin.Context) {
+				handled = true
+			})
+			router.NoRoute(NoRoute())
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.contentType != "" {
+				req.Header.Set(HeaderContentType, tc.contentType)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.False(t, handled)
+			assert.Equal(t, tc.wantStatus, w.Code)
+			var resp apis.Response
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tc.wantStatus, resp.Code)
+			assert.Equal(t, tc.wantError.Message, resp.Data)
+			if assert.NotNil(t, resp.Error) {
+				assert.Equal(t, tc.wantError, *resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// access log formats
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// accessLog is an access log entry in json format
+type accessLog struct {
+	Time      string `json:"time"`
+	RequestID string `json:"requestID"`
+	Status    int    `json:"status"`
+	Latency   string `json:"latency"`
+	ClientIP  string `json:"clientIP"`
+	Method    string `json:"method"`
+	Path      string `json:"path"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ValidateLogFormat checks the access log format is either text or json
+func ValidateLogFormat(format string) error {
+	if format != LogFormatText && format != LogFormatJSON {
+		return fmt.Errorf("unsupported log format %s, only %s and %s are supported", format, LogFormatText, LogFormatJSON)
+	}
+	return nil
+}
+
+// LogFormatter formats the access log of a request in text or json, along with the request ID set by SetRequestID
+func LogFormatter(format string) gin.LogFormatter {
+	return func(param gin.LogFormatterParams) string {
+		requestID, _ := param.Keys[string(HeaderRequestID)].(string)
+		if format == LogFormatJSON {
+			b, err := json.Marshal(accessLog{
+				Time:      param.TimeStamp.Format("2006-01-02T15:04:05.000Z07:00"),
+				RequestID: requestID,
+				Status:    param.StatusCode,
+				Latency:   param.Latency.String(),
+				ClientIP:  param.ClientIP,
+				Method:    param.Method,
+				Path:      param.Path,
+				Error:     param.ErrorMessage,
+			})
+			if err == nil {
+				return string(b) + "\n"
+			}
+		}
+		return fmt.Sprintf("%v | %s | %3d | %13v | %15s | %-7s %s | %s\n",
+			param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+			requestID,
+			param.StatusCode,
+			param.Latency,
+			param.ClientIP,
+			param.Method,
+			param.Path,
+			param.ErrorMessage,
+		)
+	}
+}
+
+// GetLogger returns the logger with the request ID, so logs of handlers can be correlated with the access log
+func GetLogger(c *gin.Context) logr.Logger {
+	return ctrl.Log.WithValues("requestID", c.GetString(string(HeaderRequestID)))
+}
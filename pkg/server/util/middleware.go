@@ -3,6 +3,7 @@ package util
 import (
 	"context"
 	"mime"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	uuid "github.com/satori/go.uuid"
@@ -52,6 +53,10 @@ const (
 	CapabilityPath         = "/capabilities"
 	CapabilityCenterPath   = "/capability-centers"
 	VersionPath            = "/version"
+
+	// probe paths out of RootPath
+	HealthzPath = "/healthz"
+	ReadyzPath  = "/readyz"
 )
 
 //NoRoute is a handler which is invoked when there is no route matches.
@@ -67,7 +72,8 @@ func generateRequestID() string {
 	return id.String()
 }
 
-// SetRequestID ...
+// SetRequestID sets the request ID into the gin context and the response header, the trace ID or request ID
+// of the request header is reused if there's one
 func SetRequestID() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		requestID := ""
@@ -81,17 +87,24 @@ func SetRequestID() gin.HandlerFunc {
 			traceID = requestID
 		}
 		c.Set(string(HeaderRequestID), requestID)
+		c.Header(string(HeaderRequestID), requestID)
 		c.Set(HeaderClientIP, c.ClientIP())
 		c.Set(HeaderTraceID, traceID)
 	}
 }
 
 // SetContext :Set context metadata for request
-// Before get request
-func SetContext() gin.HandlerFunc {
+// Before get request, the context is canceled once the timeout elapses, zero timeout means no deadline
+func SetContext(timeout time.Duration) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		reqID := c.MustGet(string(HeaderRequestID)).(string)
-		ctx, cancel := context.WithCancel(c.Request.Context())
+		var ctx context.Context
+		var cancel context.CancelFunc
+		if timeout > 0 {
+			ctx, cancel = context.WithTimeout(c.Request.Context(), timeout)
+		} else {
+			ctx, cancel = context.WithCancel(c.Request.Context())
+		}
 		fields := make(map[string]zapcore.Field)
 		mctx := context.WithValue(ctx, ServiceLogFields, fields)
 		fctx := context.WithValue(mctx, HeaderRequestID, reqID)
@@ -106,6 +119,15 @@ func GetContext(c *gin.Context) context.Context {
 	return c.MustGet(ContextKey).(context.Context)
 }
 
+// timedOut tells whether the deadline of the request context has exceeded
+func timedOut(c *gin.Context) bool {
+	ctx, ok := c.Get(ContextKey)
+	if !ok {
+		return false
+	}
+	return ctx.(context.Context).Err() == context.DeadlineExceeded
+}
+
 // ValidateHeaders validates the common headers.
 //
 // It reports one problem at a time.
@@ -0,0 +1,106 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/oam-dev/kubevela/pkg/server/apis"
+)
+
+func TestSetContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cases := map[string]struct {
+		timeout      time.Duration
+		handler      gin.HandlerFunc
+		wantStatus   int
+		wantCode     string
+		wantDeadline bool
+	}{
+		"request timed out": {
+			timeout: 10 * time.Millisecond,
+			handler: func(c *gin.Context) {
+				<-GetContext(c).Done()
+				HandleError(c, StatusInternalServerError, GetContext(c).Err().Error())
+			},
+			wantStatus:   http.StatusGatewayTimeout,
+			wantCode:     "RequestTimeout",
+			wantDeadline: true,
+		},
+		"request finished in time": {
+			timeout: time.Minute,
+			handler: func(c *gin.Context) {
+				AssembleResponse(c, "ok", nil)
+			},
+			wantStatus:   http.StatusOK,
+			wantDeadline: true,
+		},
+		"failure not caused by the timeout": {
+			timeout: time.Minute,
+			handler: func(c *gin.Context) {
+				AssembleResponse(c, nil, errors.New("boom"))
+			},
+			wantStatus:   http.StatusInternalServerError,
+			wantCode:     "StatusInternalServerError",
+			wantDeadline: true,
+		},
+		"zero timeout means no deadline": {
+			handler: func(c *gin.Context) {
+				AssembleResponse(c, "ok", nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var hasDeadline bool
+			router := gin.New()
+			router.Use(SetRequestID(), SetContext(tc.timeout))
+			router.GET("/", func(c *gin.Context) {
+				_, hasDeadline = GetContext(c).Deadline()
+				tc.handler(c)
+			})
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+			assert.Equal(t, tc.wantDeadline, hasDeadline)
+			var resp apis.Response
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tc.wantStatus, resp.Code)
+			if tc.wantCode == "" {
+				assert.Nil(t, resp.Error)
+				return
+			}
+			if assert.NotNil(t, resp.Error) {
+				assert.Equal(t, tc.wantCode, resp.Error.Code)
+			}
+		})
+	}
+}
+
+func TestSetContextDerivedFromRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reqCtx, cancel := context.WithCancel(context.Background())
+	router := gin.New()
+	router.Use(SetRequestID(), SetContext(time.Minute))
+	var ctx context.Context
+	router.GET("/", func(c *gin.Context) {
+		ctx = GetContext(c)
+		cancel()
+		<-ctx.Done()
+		AssembleResponse(c, "ok", nil)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(reqCtx)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, context.Canceled, ctx.Err())
+	assert.NotNil(t, ctx.Value(HeaderRequestID))
+}
@@ -19,7 +19,7 @@ import (
 func (s *APIServer) CreateWorkload(c *gin.Context) {
 	var body apis.WorkloadRunBody
 	if err := c.ShouldBindJSON(&body); err != nil {
-		util.HandleError(c, util.InvalidArgument, "the workload run request body is invalid")
+		util.HandleBindingError(c, err, "the workload run request body is invalid")
 		return
 	}
 	fs := pflag.NewFlagSet("workload", pflag.ContinueOnError)
@@ -38,8 +38,13 @@ func (s *APIServer) CreateWorkload(c *gin.Context) {
 		util.HandleError(c, util.StatusInternalServerError, err.Error())
 		return
 	}
+	kubeClient, err := s.getClient(env)
+	if err != nil {
+		handleClientError(c, err)
+		return
+	}
 	io := cmdutil.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr}
-	msg, err := oam.BaseRun(body.Staging, appObj, s.KubeClient, env, io)
+	msg, err := oam.BaseRun(util.GetContext(c), body.Staging, appObj, kubeClient, env, io)
 	if err != nil {
 		util.HandleError(c, util.StatusInternalServerError, err.Error())
 		return
@@ -52,7 +57,7 @@ func (s *APIServer) CreateWorkload(c *gin.Context) {
 			t.ComponentName = body.WorkloadName
 			msg, err = s.DoAttachTrait(c, t)
 			if err != nil {
-				util.HandleError(c, util.StatusInternalServerError, err.Error())
+				handleClientError(c, err)
 				return
 			}
 		}
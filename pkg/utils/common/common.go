@@ -6,6 +6,7 @@ import (
 
 	"github.com/crossplane/oam-kubernetes-runtime/apis/core"
 	certmanager "github.com/wonderflow/cert-manager-api/pkg/apis/certmanager/v1"
+	kedav1alpha1 "github.com/wonderflow/keda-api/api/v1alpha1"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 
 	"github.com/oam-dev/kubevela/api/types"
@@ -23,6 +24,7 @@ func init() {
 	_ = certmanager.AddToScheme(Scheme)
 	_ = core.AddToScheme(Scheme)
 	_ = v1alpha1.AddToScheme(Scheme)
+	_ = kedav1alpha1.AddToScheme(Scheme)
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -29,11 +29,20 @@ func GetEnvDirByName(name string) string {
 	return filepath.Join(envdir, name)
 }
 
+// NotFoundError means the env doesn't exist
+type NotFoundError struct {
+	Name string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("env %s not exist", e.Name)
+}
+
 func GetEnvByName(name string) (*types.EnvMeta, error) {
 	data, err := ioutil.ReadFile(filepath.Join(GetEnvDirByName(name), system.EnvConfigName))
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("env %s not exist", name)
+			return nil, &NotFoundError{Name: name}
 		}
 		return nil, err
 	}
@@ -47,7 +56,9 @@ func GetEnvByName(name string) (*types.EnvMeta, error) {
 //Create or update env.
 //If it does not exist, create it and set to the new env.
 //If it exists, update it and set to the new env.
-func CreateOrUpdateEnv(ctx context.Context, c client.Client, envName string, envArgs *types.EnvMeta) (string, error) {
+// CreateOrUpdateEnv creates the namespace of the env and records the env, if adopt is true, an existing namespace is
+// reused without creating it, which may be provisioned by others who don't grant the permission to create namespaces
+func CreateOrUpdateEnv(ctx context.Context, c client.Client, envName string, envArgs *types.EnvMeta, adopt bool) (string, error) {
 
 	createOrUpdated := "created"
 	old, err := GetEnvByName(envName)
@@ -65,6 +76,17 @@ func CreateOrUpdateEnv(ctx context.Context, c client.Client, envName string, env
 		if envArgs.Namespace == "" {
 			envArgs.Namespace = old.Namespace
 		}
+		// the settings specified are merged into the existing ones
+		if len(old.Settings) > 0 {
+			settings := make(map[string]string, len(old.Settings)+len(envArgs.Settings))
+			for k, v := range old.Settings {
+				settings[k] = v
+			}
+			for k, v := range envArgs.Settings {
+				settings[k] = v
+			}
+			envArgs.Settings = settings
+		}
 	}
 
 	if envArgs.Namespace == "" {
@@ -72,9 +94,20 @@ func CreateOrUpdateEnv(ctx context.Context, c client.Client, envName string, env
 	}
 
 	var message = ""
+	adopted := false
+	if adopt {
+		var ns corev1.Namespace
+		err := c.Get(ctx, client.ObjectKey{Name: envArgs.Namespace}, &ns)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return message, err
+		}
+		adopted = err == nil
+	}
 	// Create Namespace
-	if err := c.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: envArgs.Namespace}}); err != nil && !apierrors.IsAlreadyExists(err) {
-		return message, err
+	if !adopted {
+		if err := c.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: envArgs.Namespace}}); err != nil && !apierrors.IsAlreadyExists(err) {
+			return message, err
+		}
 	}
 
 	// Create Issuer For SSL if both email and domain are all set.
@@ -131,6 +164,9 @@ func CreateOrUpdateEnv(ctx context.Context, c client.Client, envName string, env
 	if envArgs.Email != "" {
 		message += fmt.Sprintf(", Email: %s", envArgs.Email)
 	}
+	if adopted {
+		message += fmt.Sprintf("\nNamespace %s already exists, reused it without creating", envArgs.Namespace)
+	}
 	return message, nil
 }
 
@@ -145,7 +181,7 @@ func CreateEnv(ctx context.Context, c client.Client, envName string, envArgs *ty
 		message := fmt.Sprintf("Env %s already exist", envName)
 		return message, errors.New(message)
 	}
-	return CreateOrUpdateEnv(ctx, c, envName, envArgs)
+	return CreateOrUpdateEnv(ctx, c, envName, envArgs, false)
 }
 
 //Update Env, if env does not exist, return error
@@ -185,8 +221,11 @@ func ListEnvs(envName string) ([]*types.EnvMeta, error) {
 			}
 			return envList, err
 		}
+		if curEnv, err := GetCurrentEnvName(); err == nil && curEnv == envName {
+			env.Current = "*"
+		}
 		envList = append(envList, env)
-		return envList, err
+		return envList, nil
 	}
 	envDir, err := system.GetEnvDir()
 	if err != nil {
@@ -261,6 +300,47 @@ func DeleteEnv(envName string) (string, error) {
 	return message, err
 }
 
+// RenameEnv renames the env, it's still the current env after renamed if it was
+func RenameEnv(oldName, newName string) (string, error) {
+	var message string
+	if oldName == types.DefaultEnvName {
+		return message, fmt.Errorf("you can't rename the default environment %s", oldName)
+	}
+	envMeta, err := GetEnvByName(oldName)
+	if err != nil {
+		return message, err
+	}
+	if _, err := GetEnvByName(newName); err == nil {
+		return message, fmt.Errorf("env %s already exist", newName)
+	}
+	curEnv, err := GetCurrentEnvName()
+	if err != nil {
+		return message, err
+	}
+	if err = os.Rename(GetEnvDirByName(oldName), GetEnvDirByName(newName)); err != nil {
+		return message, err
+	}
+	envMeta.Name = newName
+	data, err := json.Marshal(envMeta)
+	if err != nil {
+		return message, err
+	}
+	if err = ioutil.WriteFile(filepath.Join(GetEnvDirByName(newName), system.EnvConfigName), data, 0644); err != nil {
+		return message, err
+	}
+	if curEnv == oldName {
+		currentEnvPath, err := system.GetCurrentEnvPath()
+		if err != nil {
+			return message, err
+		}
+		if err = ioutil.WriteFile(currentEnvPath, []byte(newName), 0644); err != nil {
+			return message, err
+		}
+	}
+	message = fmt.Sprintf("environment %s renamed to %s", oldName, newName)
+	return message, nil
+}
+
 func SetEnv(envName string) (string, error) {
 	var msg string
 	currentEnvPath, err := system.GetCurrentEnvPath()
@@ -122,6 +122,19 @@ func NewHelmInstall(version, namespace, releaseName string) (*action.Install, er
 	return client, nil
 }
 
+// NewHelmTemplate creates an install client which only renders charts locally like `helm template`,
+// it doesn't need to access the cluster
+func NewHelmTemplate(namespace, releaseName string) *action.Install {
+	client := action.NewInstall(&action.Configuration{Log: debug})
+	client.ReleaseName = releaseName
+	client.Namespace = namespace
+	client.DryRun = true
+	client.ClientOnly = true
+	client.Replace = true
+	client.IncludeCRDs = true
+	return client
+}
+
 func NewHelmUninstall(namespace string) (*action.Uninstall, error) {
 	actionConfig := new(action.Configuration)
 
@@ -0,0 +1,81 @@
+package autoscaler
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/oam-dev/kubevela/api/v1alpha1"
+	autoscalers "github.com/oam-dev/kubevela/pkg/controller/v1alpha1/autoscaler"
+)
+
+func TestAutoscaler(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Autoscaler Suite")
+}
+
+var _ = Describe("Autoscaler Admission controller Test", func() {
+	var scalerBase v1alpha1.Autoscaler
+
+	BeforeEach(func() {
+		scalerBase = v1alpha1.Autoscaler{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "validate-hook",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.AutoscalerSpec{
+				MinReplicas: pointer.Int32Ptr(1),
+				MaxReplicas: pointer.Int32Ptr(5),
+				Triggers: []v1alpha1.Trigger{
+					{Type: autoscalers.CPUType, Condition: map[string]string{"type": "Utilization", "value": "50"}},
+				},
+			},
+		}
+	})
+
+	It("Test validate valid autoscaler", func() {
+		scaler := scalerBase
+		Expect(ValidateCreate(&scaler).ToAggregate()).NotTo(HaveOccurred())
+		Expect(ValidateUpdate(&scaler, nil).ToAggregate()).NotTo(HaveOccurred())
+	})
+
+	It("Test validate autoscaler without triggers", func() {
+		scaler := scalerBase
+		scaler.Spec.Triggers = nil
+		Expect(ValidateCreate(&scaler).ToAggregate()).To(MatchError(ContainSubstring(
+			autoscalers.SpecWarningTriggersRequired)))
+	})
+
+	It("Test validate autoscaler with minReplicas greater than maxReplicas", func() {
+		scaler := scalerBase
+		scaler.Spec.MinReplicas = pointer.Int32Ptr(10)
+		Expect(ValidateCreate(&scaler).ToAggregate()).To(HaveOccurred())
+	})
+
+	It("Test validate autoscaler with unknown trigger type", func() {
+		scaler := scalerBase
+		scaler.Spec.Triggers = []v1alpha1.Trigger{{Type: "cpuu"}}
+		Expect(ValidateCreate(&scaler).ToAggregate()).To(HaveOccurred())
+	})
+
+	It("Test validate cron trigger with bad startAt", func() {
+		scaler := scalerBase
+		scaler.Spec.Triggers = []v1alpha1.Trigger{{Type: autoscalers.CronType, Condition: map[string]string{
+			"startAt": "8am", "duration": "2h", "days": "Monday", "replicas": "2"}}}
+		Expect(ValidateCreate(&scaler).ToAggregate()).To(MatchError(ContainSubstring(
+			autoscalers.SpecWarningStartAtTimeFormat)))
+	})
+
+	It("Test validate update only if the spec is changed", func() {
+		old := scalerBase
+		old.Spec.Triggers = nil
+		scaler := old
+		scaler.Finalizers = []string{"autoscaler.finalizer.standard.oam.dev"}
+		Expect(ValidateUpdate(&scaler, &old).ToAggregate()).NotTo(HaveOccurred())
+		scaler.Spec.MinReplicas = pointer.Int32Ptr(2)
+		Expect(ValidateUpdate(&scaler, &old).ToAggregate()).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,101 @@
+package autoscaler
+
+import (
+	"context"
+	"net/http"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/oam-dev/kubevela/api/v1alpha1"
+	autoscalers "github.com/oam-dev/kubevela/pkg/controller/v1alpha1/autoscaler"
+)
+
+// ValidatingHandler handles Autoscaler
+type ValidatingHandler struct {
+	Client client.Client
+
+	// Decoder decodes objects
+	Decoder *admission.Decoder
+}
+
+// log is for logging in this package.
+var validatelog = logf.Log.WithName("autoscaler-validate")
+
+var _ admission.Handler = &ValidatingHandler{}
+
+// Handle handles admission requests.
+func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	obj := &v1alpha1.Autoscaler{}
+
+	err := h.Decoder.Decode(req, obj)
+	if err != nil {
+		validatelog.Error(err, "decoder failed", "req operation", req.AdmissionRequest.Operation, "req",
+			req.AdmissionRequest)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	switch req.AdmissionRequest.Operation {
+	case admissionv1beta1.Create:
+		if allErrs := ValidateCreate(obj); len(allErrs) > 0 {
+			validatelog.Info("create failed", "name", obj.Name, "err", allErrs.ToAggregate().Error())
+			return admission.Errored(http.StatusUnprocessableEntity, allErrs.ToAggregate())
+		}
+	case admissionv1beta1.Update:
+		oldObj := &v1alpha1.Autoscaler{}
+		if err := h.Decoder.DecodeRaw(req.AdmissionRequest.OldObject, oldObj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+
+		if allErrs := ValidateUpdate(obj, oldObj); len(allErrs) > 0 {
+			validatelog.Info("update failed", "name", obj.Name, "err", allErrs.ToAggregate().Error())
+			return admission.Errored(http.StatusUnprocessableEntity, allErrs.ToAggregate())
+		}
+	}
+
+	return admission.ValidationResponse(true, "")
+}
+
+// ValidateCreate validates the Autoscaler on creation, the spec is checked by the same validation as the
+// reconcile, whose messages tell the invalid field
+func ValidateCreate(r *v1alpha1.Autoscaler) field.ErrorList {
+	validatelog.Info("validate create", "name", r.Name)
+	allErrs := apimachineryvalidation.ValidateObjectMeta(&r.ObjectMeta, true,
+		apimachineryvalidation.NameIsDNSSubdomain, field.NewPath("metadata"))
+	if err := autoscalers.ValidateSpec(r.Spec); err != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), err.Error()))
+	}
+	return allErrs
+}
+
+// ValidateUpdate validates the Autoscaler on update, the spec is only validated if it's changed, so that the
+// finalizer of an Autoscaler stored before the webhook is enabled could still be added and removed
+func ValidateUpdate(r *v1alpha1.Autoscaler, old *v1alpha1.Autoscaler) field.ErrorList {
+	validatelog.Info("validate update", "name", r.Name)
+	if old != nil && equality.Semantic.DeepEqual(r.Spec, old.Spec) {
+		return nil
+	}
+	return ValidateCreate(r)
+}
+
+var _ inject.Client = &ValidatingHandler{}
+
+// InjectClient injects the client into the ValidatingHandler
+func (h *ValidatingHandler) InjectClient(c client.Client) error {
+	h.Client = c
+	return nil
+}
+
+var _ admission.DecoderInjector = &ValidatingHandler{}
+
+// InjectDecoder injects the decoder into the ValidatingHandler
+func (h *ValidatingHandler) InjectDecoder(d *admission.Decoder) error {
+	h.Decoder = d
+	return nil
+}
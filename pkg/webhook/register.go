@@ -4,6 +4,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	"github.com/oam-dev/kubevela/pkg/webhook/autoscaler"
 	"github.com/oam-dev/kubevela/pkg/webhook/metrics"
 	"github.com/oam-dev/kubevela/pkg/webhook/podspecworkload"
 )
@@ -12,6 +13,7 @@ import (
 // +kubebuilder:webhook:path=/mutate-standard-oam-dev-v1alpha1-metricstrait,mutating=true,failurePolicy=fail,groups=standard.oam.dev,resources=metricstraits,verbs=create;update,versions=v1alpha1,name=mmetricstrait.kb.io
 // +kubebuilder:webhook:verbs=create;update;delete,path=/validate-standard-oam-dev-v1alpha1-podspecworkload,mutating=false,failurePolicy=fail,groups=standard.oam.dev,resources=PodSpecWorkload,versions=v1alpha1,name=vpodspecworkload.kb.io
 // +kubebuilder:webhook:path=/mutate-standard-oam-dev-v1alpha1-podspecworkload,mutating=true,failurePolicy=fail,groups=standard.oam.dev,resources=PodSpecWorkload,verbs=create;update,versions=v1alpha1,name=mpodspecworkload.kb.io
+// +kubebuilder:webhook:verbs=create;update,path=/validate-standard-oam-dev-v1alpha1-autoscaler,mutating=false,failurePolicy=fail,groups=standard.oam.dev,resources=autoscalers,versions=v1alpha1,name=vautoscaler.kb.io
 
 // Register will register all the services to the webhook server
 func Register(mgr manager.Manager) {
@@ -26,4 +28,7 @@ func Register(mgr manager.Manager) {
 		&webhook.Admission{Handler: &podspecworkload.ValidatingHandler{}})
 	server.Register("/mutate-standard-oam-dev-v1alpha1-podspecworkload",
 		&webhook.Admission{Handler: &podspecworkload.MutatingHandler{}})
+	// Autoscaler
+	server.Register("/validate-standard-oam-dev-v1alpha1-autoscaler",
+		&webhook.Admission{Handler: &autoscaler.ValidatingHandler{}})
 }
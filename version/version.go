@@ -5,3 +5,6 @@ var GitRevision = "UNKNOWN"
 
 // VelaVersion is the version of cli.
 var VelaVersion = "UNKNOWN"
+
+// BuildDate is the date when the cli is built.
+var BuildDate = "UNKNOWN"